@@ -0,0 +1,70 @@
+// Command keyring manages the encrypted keyring file that keyring:// secret references read from.
+//
+//	SP_API_KEYRING_PASSPHRASE=... keyring -file keyring.json set refresh-token < token.txt
+//	SP_API_KEYRING_PASSPHRASE=... keyring -file keyring.json list
+//	SP_API_KEYRING_PASSPHRASE=... keyring -file keyring.json delete refresh-token
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+)
+
+func main() {
+	file := flag.String("file", os.Getenv("SP_API_KEYRING_FILE"), "keyring file (SP_API_KEYRING_FILE)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: keyring [-file path] set NAME | delete NAME | list\nThe passphrase comes from SP_API_KEYRING_PASSPHRASE, which may be a file://, env:// or exec:// reference.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("set -file or SP_API_KEYRING_FILE")
+	}
+	passphrase, err := config.NewSecretResolver("", "").Resolve(context.Background(), os.Getenv("SP_API_KEYRING_PASSPHRASE"))
+	if err != nil {
+		log.Fatalf("SP_API_KEYRING_PASSPHRASE: %v", err)
+	}
+	keyring := &config.Keyring{Path: *file, Passphrase: passphrase}
+
+	args := flag.Args()
+	switch {
+	case len(args) == 2 && args[0] == "set":
+		// The value is read from stdin so it never appears in shell history or the process list.
+		value, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if value = strings.TrimRight(value, "\r\n"); value == "" {
+			log.Fatal("pass the secret on stdin")
+		}
+		if err := keyring.Set(args[1], value); err != nil {
+			log.Fatal(err)
+		}
+	case len(args) == 2 && args[0] == "delete":
+		if err := keyring.Set(args[1], ""); err != nil {
+			log.Fatal(err)
+		}
+	case len(args) == 1 && args[0] == "list":
+		secrets, err := keyring.Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+		names := make([]string, 0, len(secrets))
+		for name := range secrets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -1,14 +1,24 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/app"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/onboarding"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/sandbox"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
 )
 
 func main() {
@@ -17,11 +27,51 @@ func main() {
 		log.Printf("no .env file found: %v", err)
 	}
 
-	cfg, err := config.Load()
+	cmd, err := config.ParseFlags(os.Args[0], os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid arguments: %v", err)
+	}
+
+	cfg, err := config.LoadFrom(cmd.Options)
 	if err != nil {
 		log.Fatalf("configuration error: %v", err)
 	}
 
+	if cmd.PrintConfig {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(cfg.Effective()); err != nil {
+			log.Fatalf("printing configuration: %v", err)
+		}
+		return
+	}
+
+	// Tools read VERBOSE from the environment, so a value from the config file or a flag is passed on there.
+	if cfg.Verbose {
+		os.Setenv("VERBOSE", "true")
+	}
+
+	emulator, err := app.StartLocalSandbox(&cfg)
+	if err != nil {
+		log.Fatalf("failed to start the local sandbox: %v", err)
+	}
+	var lwaEndpoint string
+	source := func(ctx context.Context) (spapi.Credentials, error) {
+		creds, err := cfg.ResolveCredentials(ctx)
+		return spapi.Credentials(creds), err
+	}
+	if emulator != nil {
+		defer emulator.Close()
+		// The emulator takes any credentials, so there is nothing to rotate.
+		lwaEndpoint, source = emulator.URL+sandbox.TokenPath, nil
+		log.Printf("[INFO] SP_API_SANDBOX=local: SP-API calls go to the bundled emulator at %s", emulator.URL)
+	} else if cfg.Sandbox == config.SandboxAmazon {
+		log.Printf("[INFO] SP_API_SANDBOX=amazon: SP-API calls go to %s", cfg.SPAPIEndpoint)
+	}
+
 	spClient, err := spapi.NewClient(spapi.Config{
 		Endpoint: cfg.SPAPIEndpoint,
 		Credentials: spapi.Credentials{
@@ -29,6 +79,9 @@ func main() {
 			ClientSecret: cfg.Credentials.ClientSecret,
 			RefreshToken: cfg.Credentials.RefreshToken,
 		},
+		Source:          source,
+		RefreshInterval: cfg.SecretRefresh,
+		LWAEndpoint:     lwaEndpoint,
 	})
 	if err != nil {
 		log.Fatalf("failed to initialize Selling Partner client: %v", err)
@@ -38,24 +91,124 @@ func main() {
 		log.Printf("Selling Partner client status: ready=%t detail=%s", status.Ready, status.Message)
 	}
 
-	srv := app.NewServer(cfg, app.Dependencies{SellingPartner: spClient})
+	var orderStore *store.Store
+	if cfg.StorePath != "" {
+		orderStore, err = store.Open(cfg.StorePath)
+		if err != nil {
+			log.Fatalf("failed to open order store: %v", err)
+		}
+		defer orderStore.Close()
+		orderStore = orderStore.ForProfile(cfg.Profile)
+		log.Printf("local order store at %s", cfg.StorePath)
+	}
+
+	responseCache, err := app.NewResponseCache(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialise response cache: %v", err)
+	}
+
+	// Assigned only when set: a nil *spapi.Cassette in the interface would not read as nil.
+	var transport http.RoundTripper
+	cassette, err := app.NewCassette(cfg)
+	if err != nil {
+		log.Fatalf("failed to open SP-API cassette: %v", err)
+	}
+	if cassette != nil {
+		transport = cassette
+		log.Printf("[INFO] SP_API_CASSETTE_MODE=%s: SP-API exchanges use the cassette %s", cfg.Cassette.Mode, cfg.Cassette.Path)
+	}
+
+	redactor, err := app.NewRedactor(cfg)
+	if err != nil {
+		log.Fatalf("failed to initialise PII redaction: %v", err)
+	}
+	if redactor == nil {
+		log.Printf("[WARN] PII redaction is off; buyer names, emails and addresses reach the client unmodified")
+	}
+
+	auditLog, err := app.NewAuditSink(cfg)
+	if err != nil {
+		log.Fatalf("failed to open audit log: %v", err)
+	}
+	if auditLog != nil {
+		defer auditLog.Close()
+	}
+
+	var (
+		authenticator *auth.Authenticator
+		policy        *auth.Policy
+	)
+	if cfg.Transport != config.TransportSTDIO {
+		authenticator, policy, err = app.NewAccessControl(cfg)
+		if err != nil {
+			log.Fatalf("failed to initialise client authentication: %v", err)
+		}
+		if authenticator == nil {
+			log.Printf("[WARN] no MCP_AUTH_TOKENS or MCP_AUTH_OIDC_ISSUER set; any client that can reach port %s can call every tool", cfg.Port)
+		}
+	}
 
 	baseUrl := "http://" + cfg.Host + ":" + cfg.Port
 	if cfg.Port == "443" {
 		baseUrl = "https://" + cfg.Host
 	}
 
+	onboardingFlow, err := app.NewOnboarding(cfg, baseUrl, policy)
+	if err != nil {
+		log.Fatalf("failed to initialise seller authorization: %v", err)
+	}
+
+	srv, err := app.NewServer(cfg, app.Dependencies{
+		SellingPartner: spClient,
+		Store:          orderStore,
+		Transport:      transport,
+		ResponseCache:  responseCache,
+		Redactor:       redactor,
+		Policy:         policy,
+		AuditLog:       auditLog,
+		Onboarding:     onboardingFlow,
+	})
+	if err != nil {
+		log.Fatalf("failed to build MCP server: %v", err)
+	}
+
+	go app.WatchCredentials(context.Background(), srv, spClient, cfg)
+
+	// protect puts authentication in front of an HTTP transport when it is configured.
+	protect := func(handler http.Handler) http.Handler {
+		if authenticator == nil {
+			return handler
+		}
+		return auth.Middleware(authenticator, policy, cfg.Profile, handler)
+	}
+
+	// route serves the MCP handler, plus the seller authorization callback when onboarding is on. The callback is
+	// opened by the seller's browser, so it stays outside client authentication.
+	route := func(pattern string, handler http.Handler) *http.ServeMux {
+		mux := http.NewServeMux()
+		mux.Handle(pattern, protect(handler))
+		if onboardingFlow != nil {
+			mux.Handle(onboarding.CallbackPath, onboardingFlow)
+			log.Printf("seller authorization callback at %s%s", baseUrl, onboarding.CallbackPath)
+		}
+		return mux
+	}
+
 	switch cfg.Transport {
 	case config.TransportSSE:
 		log.Printf("starting SSE MCP server at %s/sse", baseUrl)
-		sse := server.NewSSEServer(srv)
-		if err := sse.Start(":" + cfg.Port); err != nil {
+		httpServer := &http.Server{Addr: ":" + cfg.Port}
+		sse := server.NewSSEServer(srv, server.WithHTTPServer(httpServer))
+		httpServer.Handler = route("/", sse)
+		if err := sse.Start(httpServer.Addr); err != nil {
 			log.Fatalf("sse server exited: %v", err)
 		}
 	case config.TransportStreamableHTTP:
 		log.Printf("starting StreamableHTTP MCP server at %s/mcp", baseUrl)
-		httpSrv := server.NewStreamableHTTPServer(srv)
-		if err := httpSrv.Start(":" + cfg.Port); err != nil {
+		httpServer := &http.Server{Addr: ":" + cfg.Port}
+		httpSrv := server.NewStreamableHTTPServer(srv, server.WithStreamableHTTPServer(httpServer))
+		httpServer.Handler = route("/mcp", httpSrv)
+		if err := httpSrv.Start(httpServer.Addr); err != nil {
 			log.Fatalf("streamable HTTP server exited: %v", err)
 		}
 	default:
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+)
+
+// toolAccessFilter hides tools the session's principal may not call from tools/list. Contexts without a principal
+// see nothing: a policy is only configured for authenticated HTTP transports, so a missing principal is a wiring bug.
+func toolAccessFilter(policy *auth.Policy) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok {
+			return nil
+		}
+
+		allowed := make([]mcp.Tool, 0, len(tools))
+		for _, tool := range tools {
+			if policy.AllowsTool(principal.Name, tool.Name) {
+				allowed = append(allowed, tool)
+			}
+		}
+		return allowed
+	}
+}
+
+// toolAccessMiddleware enforces the policy on tools/call, since hiding a tool from the list does not stop a client
+// that already knows its name.
+func toolAccessMiddleware(policy *auth.Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok || !policy.AllowsTool(principal.Name, request.Params.Name) {
+				return mcp.NewToolResultError("tool " + request.Params.Name + " is not permitted for this client"), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// NewAccessControl builds the HTTP authenticator and the optional access policy. Both are nil when no authentication
+// is configured, in which case every HTTP client sees every tool.
+func NewAccessControl(cfg config.Config) (*auth.Authenticator, *auth.Policy, error) {
+	if !cfg.Auth.Enabled() {
+		return nil, nil, nil
+	}
+
+	authenticator, err := auth.NewAuthenticator(auth.Config{
+		Tokens:       cfg.Auth.Tokens,
+		OIDCIssuer:   cfg.Auth.OIDCIssuer,
+		OIDCAudience: cfg.Auth.OIDCAudience,
+		OIDCClaim:    cfg.Auth.OIDCClaim,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.Auth.PolicyPath == "" {
+		return authenticator, nil, nil
+	}
+	policy, err := auth.LoadPolicy(cfg.Auth.PolicyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return authenticator, policy, nil
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/audit"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+)
+
+// NewAuditSink opens the configured audit log, or returns nil when auditing is off.
+func NewAuditSink(cfg config.Config) (audit.Sink, error) {
+	switch cfg.Audit.Path {
+	case "":
+		return nil, nil
+	case "stderr":
+		return audit.NewWriter(os.Stderr), nil
+	default:
+		return audit.NewFile(cfg.Audit.Path, int64(cfg.Audit.MaxSizeMB)<<20, cfg.Audit.MaxFiles)
+	}
+}
+
+// auditMiddleware records every tool call, including ones the access policy refuses, so it must run outside the
+// access middleware. Arguments pass through the PII redactor when one is configured.
+func auditMiddleware(sink audit.Sink, redactor *redact.Redactor, mutating map[string]bool) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started := time.Now()
+			ctx, trail := audit.WithTrail(ctx)
+
+			result, err := next(ctx, request)
+
+			record := audit.Record{
+				Time:      started.UTC(),
+				Tool:      request.Params.Name,
+				Mutating:  mutating[request.Params.Name],
+				Arguments: auditArguments(request, redactor),
+				Calls:     trail.Calls(),
+				LatencyMs: time.Since(started).Milliseconds(),
+				Outcome:   audit.OutcomeOK,
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				record.SessionID = session.SessionID()
+			}
+			if principal, ok := auth.PrincipalFromContext(ctx); ok {
+				record.Principal = principal.Name
+			}
+
+			switch {
+			case err != nil:
+				record.Outcome, record.Error = audit.OutcomeFailed, err.Error()
+			case result != nil && result.IsError:
+				record.Outcome, record.Error = audit.OutcomeError, resultText(result)
+			}
+
+			if writeErr := sink.Write(record); writeErr != nil {
+				log.Printf("[ERROR] audit: recording %s failed: %v", record.Tool, writeErr)
+			}
+			return result, err
+		}
+	}
+}
+
+func auditArguments(request mcp.CallToolRequest, redactor *redact.Redactor) any {
+	arguments := request.GetArguments()
+	if len(arguments) == 0 || redactor == nil {
+		return arguments
+	}
+
+	redacted, err := redactor.Value(arguments)
+	if err != nil {
+		return fmt.Sprintf("arguments withheld: %v", err)
+	}
+	return redacted.Value
+}
+
+// resultText joins the text content of an error result.
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/cache"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/tools"
+)
+
+// NewResponseCache builds the configured SP-API response cache, or returns nil when caching is off.
+func NewResponseCache(cfg config.Config) (*tools.ResponseCache, error) {
+	var backend cache.Backend
+	switch cfg.Cache.Backend {
+	case config.CacheOff:
+		return nil, nil
+	case config.CacheDisk:
+		dir := cfg.Cache.Dir
+		if dir == "" {
+			base, err := os.UserCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("locating cache directory (set SP_API_CACHE_DIR): %w", err)
+			}
+			dir = filepath.Join(base, "sp-api-mcp-go", "responses")
+		}
+		disk, err := cache.NewDisk(dir)
+		if err != nil {
+			return nil, err
+		}
+		backend = disk
+	default:
+		backend = cache.NewMemory(cache.DefaultMemoryEntries)
+	}
+
+	return tools.NewResponseCache(backend, cacheNamespace(cfg), cfg.Cache.TTLs)
+}
+
+// cacheNamespace identifies the seller behind the credentials without putting secrets in cache keys or file names.
+func cacheNamespace(cfg config.Config) string {
+	sum := sha256.Sum256([]byte(cfg.SPAPIEndpoint + "\x00" + cfg.Credentials.ClientID + "\x00" + cfg.Credentials.RefreshToken + "\x00" + cfg.SellerID))
+	return hex.EncodeToString(sum[:8])
+}
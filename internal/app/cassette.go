@@ -0,0 +1,19 @@
+package app
+
+import (
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// NewCassette opens the cassette SP_API_CASSETTE names for recording or replay, or returns nil when none is set. Pass
+// it as Dependencies.Transport.
+func NewCassette(cfg config.Config) (*spapi.Cassette, error) {
+	switch {
+	case cfg.Cassette.Path == "":
+		return nil, nil
+	case cfg.Cassette.Mode == config.CassetteRecord:
+		return spapi.RecordCassette(cfg.Cassette.Path, nil)
+	default:
+		return spapi.LoadCassette(cfg.Cassette.Path)
+	}
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// confirmationTokenArgument carries the token of a two-phase confirmation.
+	confirmationTokenArgument = "confirmationToken"
+	// confirmationTokenTTL bounds how long a summary stays approvable; the data behind it may change.
+	confirmationTokenTTL = 5 * time.Minute
+	// elicitationTimeout gives a person time to read the summary before the call is abandoned.
+	elicitationTimeout = 10 * time.Minute
+)
+
+// confirmation asks a person to approve destructive tool calls before they reach SP-API. Clients that declared the
+// elicitation capability get a confirm dialog. Other clients get a two-phase flow: the first call returns a summary and
+// a single-use token, and only a repeat call with identical arguments plus that token runs the tool.
+type confirmation struct {
+	destructive map[string]mcp.Tool
+
+	mu     sync.Mutex
+	tokens map[string]pendingConfirmation
+}
+
+type pendingConfirmation struct {
+	fingerprint string
+	expiresAt   time.Time
+}
+
+// confirmationRequired is returned by the first phase of a two-phase confirmation.
+type confirmationRequired struct {
+	ConfirmationRequired bool      `json:"confirmationRequired"`
+	Tool                 string    `json:"tool"`
+	Summary              string    `json:"summary"`
+	ConfirmationToken    string    `json:"confirmationToken"`
+	ExpiresAt            time.Time `json:"expiresAt"`
+}
+
+// newConfirmation covers the tools annotated as destructive and adds the confirmationToken argument to their schemas.
+func newConfirmation(serverTools []server.ServerTool) *confirmation {
+	c := &confirmation{destructive: make(map[string]mcp.Tool), tokens: make(map[string]pendingConfirmation)}
+	for i := range serverTools {
+		tool := &serverTools[i].Tool
+		if tool.Annotations.DestructiveHint == nil || !*tool.Annotations.DestructiveHint {
+			continue
+		}
+		if tool.InputSchema.Properties == nil {
+			tool.InputSchema.Properties = make(map[string]any)
+		}
+		tool.InputSchema.Properties[confirmationTokenArgument] = map[string]any{
+			"type":        "string",
+			"description": "Token from a confirmationRequired result. Pass it only after the user has approved the summary.",
+		}
+		c.destructive[tool.Name] = *tool
+	}
+	return c
+}
+
+// middleware runs after the access check, so refused callers are never asked to confirm. Dry runs and validation-only
+// calls send nothing that changes data and pass straight through.
+func (c *confirmation) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tool, ok := c.destructive[request.Params.Name]
+		if !ok || previewOnly(tool, request) {
+			return next(ctx, request)
+		}
+
+		summary := describeAction(tool, request.GetArguments())
+
+		if session, ok := elicitationSession(ctx); ok {
+			approved, err := c.elicit(ctx, session, summary)
+			if err == nil {
+				if !approved {
+					return mcp.NewToolResultError(tool.Name + " was not confirmed; nothing was changed"), nil
+				}
+				return next(ctx, request)
+			}
+			// A client that advertised elicitation but failed to answer falls back to the token flow.
+		}
+
+		fingerprint := confirmationFingerprint(ctx, tool.Name, request.GetArguments())
+		if token := request.GetString(confirmationTokenArgument, ""); token != "" {
+			if c.redeem(token, fingerprint) {
+				return next(ctx, request)
+			}
+			return mcp.NewToolResultError("confirmationToken is invalid, expired or was issued for different arguments; call again without it for a new summary"), nil
+		}
+
+		return c.issue(tool.Name, summary, fingerprint), nil
+	}
+}
+
+// previewOnlyArguments make a tool send nothing that changes data, for the tools that accept them.
+var previewOnlyArguments = []string{"dryRun", "validateOnly"}
+
+// previewOnly reports whether the call asks for a dry run or validation only. An argument counts only when the tool
+// declares it: a tool without validateOnly ignores the flag and writes, so passing it must not skip confirmation.
+func previewOnly(tool mcp.Tool, request mcp.CallToolRequest) bool {
+	for _, argument := range previewOnlyArguments {
+		if _, declared := tool.InputSchema.Properties[argument]; declared && request.GetBool(argument, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// elicitationSession returns the session when its client declared the elicitation capability. Sessions that do not
+// record capabilities are not asked, since a client that cannot answer would leave the call hanging.
+func elicitationSession(ctx context.Context) (server.SessionWithElicitation, bool) {
+	session := server.ClientSessionFromContext(ctx)
+	withInfo, ok := session.(server.SessionWithClientInfo)
+	if !ok || withInfo.GetClientCapabilities().Elicitation == nil {
+		return nil, false
+	}
+	elicitation, ok := session.(server.SessionWithElicitation)
+	return elicitation, ok
+}
+
+func (c *confirmation) elicit(ctx context.Context, session server.SessionWithElicitation, summary string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+
+	result, err := session.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: summary,
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"confirm": map[string]any{"type": "boolean", "title": "Proceed with this change?"},
+				},
+				"required": []string{"confirm"},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+	content, _ := result.Content.(map[string]any)
+	confirmed, _ := content["confirm"].(bool)
+	return confirmed, nil
+}
+
+func (c *confirmation) issue(tool, summary, fingerprint string) *mcp.CallToolResult {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return mcp.NewToolResultErrorFromErr("could not create a confirmation token", err)
+	}
+	token := hex.EncodeToString(raw)
+	expiresAt := time.Now().Add(confirmationTokenTTL).UTC()
+
+	c.mu.Lock()
+	now := time.Now()
+	for key, pending := range c.tokens {
+		if now.After(pending.expiresAt) {
+			delete(c.tokens, key)
+		}
+	}
+	c.tokens[token] = pendingConfirmation{fingerprint: fingerprint, expiresAt: expiresAt}
+	c.mu.Unlock()
+
+	result := confirmationRequired{
+		ConfirmationRequired: true,
+		Tool:                 tool,
+		Summary:              summary,
+		ConfirmationToken:    token,
+		ExpiresAt:            expiresAt,
+	}
+	fallback := fmt.Sprintf("%s needs the user's confirmation and nothing was changed yet. Show the user this summary:\n\n%s\n\nIf they approve, call %s again with the same arguments and confirmationToken %q within %d minutes.",
+		tool, summary, tool, token, int(confirmationTokenTTL.Minutes()))
+	return mcp.NewToolResultStructured(result, fallback)
+}
+
+// redeem consumes token if it was issued for the same session, tool and arguments.
+func (c *confirmation) redeem(token, fingerprint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending, ok := c.tokens[token]
+	if !ok {
+		return false
+	}
+	delete(c.tokens, token)
+	return pending.fingerprint == fingerprint && time.Now().Before(pending.expiresAt)
+}
+
+// confirmationFingerprint binds a token to the session, tool and arguments, so approving one summary cannot authorise a
+// different change.
+func confirmationFingerprint(ctx context.Context, tool string, arguments map[string]any) string {
+	scoped := make(map[string]any, len(arguments))
+	for key, value := range arguments {
+		if key != confirmationTokenArgument {
+			scoped[key] = value
+		}
+	}
+	sessionID := ""
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+
+	// encoding/json sorts map keys, so equal arguments always encode the same way.
+	encoded, _ := json.Marshal(scoped)
+	sum := sha256.Sum256([]byte(sessionID + "\x00" + tool + "\x00" + string(encoded)))
+	return hex.EncodeToString(sum[:])
+}
+
+// maxSummaryValue keeps one long argument, such as an A+ document, from burying the rest of the summary.
+const maxSummaryValue = 300
+
+// describeAction renders a summary a person can approve: what the tool does and every argument it was given.
+func describeAction(tool mcp.Tool, arguments map[string]any) string {
+	title := tool.Name
+	if tool.Annotations.Title != "" {
+		title = tool.Annotations.Title + " – " + tool.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n", title, tool.Description)
+
+	keys := make([]string, 0, len(arguments))
+	for key := range arguments {
+		if key != confirmationTokenArgument {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	if len(keys) > 0 {
+		b.WriteString("\nArguments:\n")
+	}
+	for _, key := range keys {
+		value, ok := arguments[key].(string)
+		if !ok {
+			encoded, _ := json.Marshal(arguments[key])
+			value = string(encoded)
+		}
+		if len(value) > maxSummaryValue {
+			value = value[:maxSummaryValue] + "…"
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", key, value)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
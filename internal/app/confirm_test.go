@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/tools"
+)
+
+func TestConfirmationTokenFlow(t *testing.T) {
+	tools := []server.ServerTool{
+		{Tool: mcp.NewTool("orders.confirmShipment", mcp.WithDestructiveHintAnnotation(true), mcp.WithBoolean("dryRun"))},
+		{Tool: mcp.NewTool("orders.getOrder", mcp.WithDestructiveHintAnnotation(false))},
+	}
+	confirm := newConfirmation(tools)
+	if _, ok := tools[0].Tool.InputSchema.Properties[confirmationTokenArgument]; !ok {
+		t.Fatal("destructive tool schema lacks confirmationToken")
+	}
+
+	calls := 0
+	handler := confirm.middleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("done"), nil
+	})
+	call := func(name string, arguments map[string]any) *mcp.CallToolResult {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = name
+		req.Params.Arguments = arguments
+		result, err := handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("call: %v", err)
+		}
+		return result
+	}
+
+	call("orders.getOrder", map[string]any{"amazonOrderId": "1"})
+	if calls != 1 {
+		t.Fatal("read tools must not need confirmation")
+	}
+
+	first := call("orders.confirmShipment", map[string]any{"amazonOrderId": "1"})
+	pending, ok := first.StructuredContent.(confirmationRequired)
+	if !ok || calls != 1 {
+		t.Fatalf("expected a confirmation request, got %+v after %d calls", first.StructuredContent, calls)
+	}
+
+	if result := call("orders.confirmShipment", map[string]any{"amazonOrderId": "2", confirmationTokenArgument: pending.ConfirmationToken}); !result.IsError || calls != 1 {
+		t.Fatal("a token must not approve different arguments")
+	}
+
+	second := call("orders.confirmShipment", map[string]any{"amazonOrderId": "1"})
+	token := second.StructuredContent.(confirmationRequired).ConfirmationToken
+	if result := call("orders.confirmShipment", map[string]any{"amazonOrderId": "1", confirmationTokenArgument: token}); result.IsError || calls != 2 {
+		t.Fatalf("confirmed call did not run: %+v", result)
+	}
+	if result := call("orders.confirmShipment", map[string]any{"amazonOrderId": "1", confirmationTokenArgument: token}); !result.IsError || calls != 2 {
+		t.Fatal("tokens must be single-use")
+	}
+
+	call("orders.confirmShipment", map[string]any{"amazonOrderId": "1", "dryRun": true})
+	if calls != 3 {
+		t.Fatal("dry runs must not need confirmation")
+	}
+}
+
+func TestConfirmationIgnoresUndeclaredPreviewArguments(t *testing.T) {
+	registry, err := tools.NewRegistry(tools.Dependencies{AllowWrites: true, EnabledDomains: []string{"orders", "aplus"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirm := newConfirmation(registry.Tools())
+
+	calls := 0
+	handler := confirm.middleware(func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText("done"), nil
+	})
+	confirmed := func(name string, arguments map[string]any) bool {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Name = name
+		req.Params.Arguments = arguments
+		before := calls
+		if _, err := handler(context.Background(), req); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return calls == before
+	}
+
+	// orders.confirmShipment has no validateOnly argument, so its handler would ignore the flag and ship.
+	if !confirmed("orders.confirmShipment", map[string]any{"amazonOrderId": "1", "validateOnly": true}) {
+		t.Error("validateOnly on a tool that does not declare it skipped confirmation")
+	}
+	if confirmed("orders.confirmShipment", map[string]any{"amazonOrderId": "1", "dryRun": true}) {
+		t.Error("a declared dryRun should skip confirmation")
+	}
+	if confirmed("aplus.createContentDocument", map[string]any{"marketplaceId": "ATVPDKIKX0DER", "validateOnly": true}) {
+		t.Error("a declared validateOnly should skip confirmation")
+	}
+}
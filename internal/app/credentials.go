@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"log"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// credentialsLogger names the MCP logger that carries watchdog notifications.
+const credentialsLogger = "sp-api.credentials"
+
+// WatchCredentials runs the credential watchdog until ctx ends. Findings go to the server log and, as MCP log
+// notifications, to every connected client, so an assistant can tell the user a token was revoked before tool calls
+// start failing.
+func WatchCredentials(ctx context.Context, srv *server.MCPServer, client spapi.Client, cfg config.Config) {
+	if !client.Status().Ready {
+		return
+	}
+
+	spapi.Watch(ctx, client, spapi.WatchConfig{
+		Interval:               cfg.Health.Interval,
+		AuthorizationExpiresAt: cfg.Health.AuthorizationExpiresAt,
+		WarnBefore:             cfg.Health.AuthorizationWarning,
+	}, func(event spapi.Event) {
+		level := mcp.LoggingLevelWarning
+		switch {
+		case event.Error:
+			level = mcp.LoggingLevelError
+			log.Printf("[ERROR] credentials: %s", event.Message)
+		case event.Kind == spapi.EventRecovered:
+			level = mcp.LoggingLevelInfo
+			log.Printf("[INFO] credentials: %s", event.Message)
+		default:
+			log.Printf("[WARN] credentials: %s", event.Message)
+		}
+
+		srv.SendNotificationToAllClients("notifications/message", map[string]any{
+			"level":  level,
+			"logger": credentialsLogger,
+			"data":   map[string]any{"event": event.Kind, "message": event.Message},
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/onboarding"
+)
+
+// NewOnboarding builds the seller authorization flow behind auth.beginAuthorization. It returns nil when
+// SP_API_APPLICATION_ID is unset or the transport is stdio, which has no endpoint for Seller Central to redirect to.
+// baseURL is where the server is reachable and sets the default redirect URI; policy limits the profile names each
+// client may authorize.
+func NewOnboarding(cfg config.Config, baseURL string, policy *auth.Policy) (*onboarding.Flow, error) {
+	if cfg.OAuth.ApplicationID == "" || cfg.Transport == config.TransportSTDIO {
+		return nil, nil
+	}
+
+	redirectURL := cfg.OAuth.RedirectURL
+	if redirectURL == "" {
+		redirectURL = baseURL + onboarding.CallbackPath
+	}
+
+	return onboarding.New(onboarding.Config{
+		ApplicationID: cfg.OAuth.ApplicationID,
+		RedirectURL:   redirectURL,
+		ClientID:      cfg.Credentials.ClientID,
+		ClientSecret:  cfg.Credentials.ClientSecret,
+		Vendor:        cfg.AccountType == config.AccountTypeVendor,
+		Profiles:      cfg.ProfileStore(),
+		Policy:        policy,
+	})
+}
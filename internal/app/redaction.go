@@ -0,0 +1,14 @@
+package app
+
+import (
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+)
+
+// NewRedactor builds the PII redactor for the configured policy, or returns nil when every category is off.
+func NewRedactor(cfg config.Config) (*redact.Redactor, error) {
+	if !cfg.Redaction.Enabled() {
+		return nil, nil
+	}
+	return redact.New(cfg.Redaction)
+}
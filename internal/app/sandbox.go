@@ -0,0 +1,31 @@
+package app
+
+import (
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/sandbox"
+)
+
+// sandboxCredentials stand in for missing LWA credentials in local sandbox mode; the emulator accepts any.
+var sandboxCredentials = config.Credentials{
+	ClientID:     "amzn1.application-oa2-client.sandbox",
+	ClientSecret: "sandbox-client-secret",
+	RefreshToken: "Atzr|sandbox-refresh-token",
+}
+
+// StartLocalSandbox runs the bundled emulator when SP_API_SANDBOX is local and points cfg at it, filling in
+// placeholder credentials when none are configured. It returns nil for the other sandbox modes. Callers send token
+// requests to the emulator's sandbox.TokenPath and Close it on shutdown.
+func StartLocalSandbox(cfg *config.Config) (*sandbox.Server, error) {
+	if cfg.Sandbox != config.SandboxLocal {
+		return nil, nil
+	}
+	emulator, err := sandbox.Start("127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	cfg.SPAPIEndpoint = emulator.URL
+	if !cfg.Credentials.IsComplete() {
+		cfg.Credentials = sandboxCredentials
+	}
+	return emulator, nil
+}
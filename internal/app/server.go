@@ -1,24 +1,86 @@
 package app
 
 import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/audit"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/onboarding"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/resources"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/tools"
 )
 
 // Dependencies bundles runtime clients the MCP server relies on.
 type Dependencies struct {
 	SellingPartner spapi.Client
+	// Store is the optional local order mirror.
+	Store *store.Store
+	// Transport carries SP-API requests in place of http.DefaultTransport, such as the cassette NewCassette opens.
+	Transport http.RoundTripper
+	// ResponseCache is the optional SP-API response cache; see NewResponseCache.
+	ResponseCache *tools.ResponseCache
+	// Redactor removes buyer PII from tool results; see NewRedactor.
+	Redactor *redact.Redactor
+	// Policy limits which tools each authenticated principal sees and may call; nil exposes every tool.
+	Policy *auth.Policy
+	// AuditLog receives a record of every tool call; nil disables auditing. Queryable sinks back admin.queryAuditLog.
+	AuditLog audit.Sink
+	// Onboarding runs seller authorization for auth.beginAuthorization; nil leaves those tools out.
+	Onboarding *onboarding.Flow
 }
 
 // NewServer constructs the MCP server, wiring tools and resources so additional capabilities can be added in one place.
-func NewServer(cfg config.Config, deps Dependencies) *server.MCPServer {
-	srv := server.NewMCPServer(
-		cfg.ServerName,
-		cfg.ServerVersion,
+// It fails when the tool registry is inconsistent, such as two tools sharing a name or an unknown domain in the
+// configuration.
+func NewServer(cfg config.Config, deps Dependencies) (*server.MCPServer, error) {
+	var auditLog audit.Querier
+	if querier, ok := deps.AuditLog.(audit.Querier); ok {
+		auditLog = querier
+	}
+
+	registry, err := tools.NewRegistry(tools.Dependencies{
+		SellingPartner:  deps.SellingPartner,
+		AllowWrites:     cfg.AllowWrites,
+		DryRun:          cfg.DryRun,
+		SellerID:        cfg.SellerID,
+		Vendor:          cfg.AccountType == config.AccountTypeVendor,
+		Store:           deps.Store,
+		UploadDir:       cfg.UploadDir,
+		Transport:       deps.Transport,
+		ResponseCache:   deps.ResponseCache,
+		Redactor:        deps.Redactor,
+		AuditLog:        auditLog,
+		Onboarding:      deps.Onboarding,
+		EnabledDomains:  cfg.Domains.Enabled,
+		DisabledDomains: cfg.Domains.Disabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("registering tools: %w", err)
+	}
+	logUnexposedDomains(registry.Catalog())
+	serverTools := registry.Tools()
+
+	var options []server.ServerOption
+	if deps.AuditLog != nil {
+		// Middleware runs in the order added, so the audit record also covers panics and refused calls.
+		mutating := make(map[string]bool, len(serverTools))
+		for _, tool := range serverTools {
+			mutating[tool.Tool.Name] = tool.Tool.Annotations.ReadOnlyHint != nil && !*tool.Tool.Annotations.ReadOnlyHint
+		}
+		options = append(options, server.WithToolHandlerMiddleware(auditMiddleware(deps.AuditLog, deps.Redactor, mutating)))
+	}
+
+	options = append(options,
 		server.WithInstructions(cfg.Instructions),
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(false, true),
@@ -27,9 +89,55 @@ func NewServer(cfg config.Config, deps Dependencies) *server.MCPServer {
 		server.WithLogging(),
 		server.WithToolHandlerMiddleware(ErrorLoggingMiddleware),
 	)
+	if deps.Policy != nil {
+		options = append(options,
+			server.WithToolFilter(toolAccessFilter(deps.Policy)),
+			server.WithToolHandlerMiddleware(toolAccessMiddleware(deps.Policy)),
+		)
+	}
+	// Confirmation only matters when a destructive call can actually reach SP-API.
+	if cfg.ConfirmWrites && cfg.AllowWrites && !cfg.DryRun {
+		options = append(options,
+			server.WithElicitation(),
+			server.WithToolHandlerMiddleware(newConfirmation(serverTools).middleware),
+		)
+	}
+
+	srv := server.NewMCPServer(cfg.ServerName, cfg.ServerVersion, options...)
 
-	srv.AddTools(tools.BuildAll(tools.Dependencies{SellingPartner: deps.SellingPartner})...)
+	srv.AddTools(serverTools...)
 	srv.AddResources(resources.Documentation()...)
+	srv.AddResources(resources.StoreSchema())
+
+	return srv, nil
+}
+
+// logUnexposedDomains reports, once per reason, the domains whose tools are not exposed, so a missing store path or a
+// domain filter is visible at startup rather than as an absent tool.
+func logUnexposedDomains(catalog []tools.ToolInfo) {
+	domains := make(map[string]map[string]bool)
+	for _, info := range catalog {
+		if info.Unavailable == "" {
+			continue
+		}
+		if domains[info.Unavailable] == nil {
+			domains[info.Unavailable] = make(map[string]bool)
+		}
+		domains[info.Unavailable][info.Domain] = true
+	}
+
+	reasons := make([]string, 0, len(domains))
+	for reason := range domains {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
 
-	return srv
+	for _, reason := range reasons {
+		names := make([]string, 0, len(domains[reason]))
+		for domain := range domains[reason] {
+			names = append(names, domain)
+		}
+		sort.Strings(names)
+		log.Printf("[INFO] tools: not exposing %s (%s)", strings.Join(names, ", "), reason)
+	}
 }
@@ -0,0 +1,166 @@
+// Package audit records every tool invocation: who called it, with which (redacted) arguments, which SP-API requests it
+// made and how it ended. Records go to a Sink; the rotating file sink can also be queried.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// Outcomes recorded for a tool call.
+const (
+	OutcomeOK     = "ok"
+	OutcomeError  = "error"
+	OutcomeFailed = "failed"
+)
+
+// Call is one HTTP request made to SP-API while serving a tool call.
+type Call struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Record is one tool invocation.
+type Record struct {
+	Time      time.Time `json:"time"`
+	SessionID string    `json:"sessionId,omitempty"`
+	Principal string    `json:"principal,omitempty"`
+	Tool      string    `json:"tool"`
+	Mutating  bool      `json:"mutating"`
+	Arguments any       `json:"arguments,omitempty"`
+	Calls     []Call    `json:"calls,omitempty"`
+	LatencyMs int64     `json:"latencyMs"`
+	// Outcome is OutcomeOK, OutcomeError for an error result, or OutcomeFailed when the handler itself failed.
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Sink stores records. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(record Record) error
+	Close() error
+}
+
+// Filter narrows a query. Zero fields match everything.
+type Filter struct {
+	// Tool is a path.Match pattern such as "listings.*".
+	Tool      string
+	Principal string
+	Outcome   string
+	Since     time.Time
+	Until     time.Time
+	// MutatingOnly keeps write tools only.
+	MutatingOnly bool
+	// Limit caps the result; records come back newest first.
+	Limit int
+}
+
+// Matches reports whether record passes the filter.
+func (f Filter) Matches(record Record) bool {
+	if f.Tool != "" {
+		if matched, _ := path.Match(f.Tool, record.Tool); !matched {
+			return false
+		}
+	}
+	if f.Principal != "" && f.Principal != record.Principal {
+		return false
+	}
+	if f.Outcome != "" && f.Outcome != record.Outcome {
+		return false
+	}
+	if !f.Since.IsZero() && record.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !record.Time.Before(f.Until) {
+		return false
+	}
+	return !f.MutatingOnly || record.Mutating
+}
+
+// Querier is implemented by sinks that can read their records back.
+type Querier interface {
+	Query(ctx context.Context, filter Filter) ([]Record, error)
+}
+
+type writerSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriter returns a sink that writes JSON lines to w, such as stderr for a log collector. It cannot be queried.
+func NewWriter(w io.Writer) Sink {
+	return &writerSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *writerSink) Write(record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(record)
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+// Trail collects the SP-API calls made during one tool invocation.
+type Trail struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+type trailKey struct{}
+
+// WithTrail attaches a new trail to ctx.
+func WithTrail(ctx context.Context) (context.Context, *Trail) {
+	trail := &Trail{}
+	return context.WithValue(ctx, trailKey{}, trail), trail
+}
+
+// Calls returns the calls recorded so far.
+func (t *Trail) Calls() []Call {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Call(nil), t.calls...)
+}
+
+func (t *Trail) add(call Call) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls = append(t.calls, call)
+}
+
+// Transport records requests made under a trail. Only the path is kept: query strings can carry buyer filters such as
+// BuyerEmail, and bodies are already covered by the tool arguments.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trail, _ := req.Context().Value(trailKey{}).(*Trail)
+	if trail == nil {
+		return t.Base.RoundTrip(req)
+	}
+
+	started := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+
+	call := Call{Method: req.Method, Path: req.URL.Path, DurationMs: time.Since(started).Milliseconds()}
+	if err != nil {
+		call.Error = err.Error()
+	} else {
+		call.Status = resp.StatusCode
+		call.RequestID = resp.Header.Get("x-amzn-RequestId")
+	}
+	trail.add(call)
+
+	return resp, err
+}
@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileRotationAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFile(path, 300, 2)
+	if err != nil {
+		t.Fatalf("new file: %v", err)
+	}
+	defer sink.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		record := Record{Time: start.Add(time.Duration(i) * time.Minute), Tool: "orders.getOrder", Outcome: OutcomeOK, Principal: "ops"}
+		if i%2 == 1 {
+			record.Tool, record.Mutating = "listings.putListingsItem", true
+		}
+		if err := sink.Write(record); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("expected a rotated backup: %v", err)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatal("backups beyond maxBackups should be removed")
+	}
+
+	records, err := sink.Query(context.Background(), Filter{Tool: "listings.*", MutatingOnly: true, Limit: 2})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(records) != 2 || !records[0].Time.After(records[1].Time) {
+		t.Fatalf("expected the two newest write records, got %+v", records)
+	}
+	if records[0].Time != start.Add(5*time.Minute) {
+		t.Fatalf("newest record is %s", records[0].Time)
+	}
+}
+
+func TestQueryToleratesRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFile(path, 300, 3)
+	if err != nil {
+		t.Fatalf("new file: %v", err)
+	}
+	defer sink.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	write := func(i int) {
+		t.Helper()
+		if err := sink.Write(Record{Time: start.Add(time.Duration(i) * time.Minute), Tool: "orders.getOrder", Outcome: OutcomeOK}); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		write(i)
+	}
+
+	// Rotate while the query's files are open, as a concurrent tool call would; the scan still sees each record once.
+	files, err := sink.openAll()
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	for i := 4; i < 8; i++ {
+		write(i)
+	}
+	kept := &newestRecords{}
+	for _, file := range files {
+		if err := scanRecords(file, Filter{}, kept.add); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		file.Close()
+	}
+	if records := kept.sorted(); len(records) != 4 || records[0].Time != start.Add(3*time.Minute) {
+		t.Fatalf("expected the four records written before the query, got %+v", records)
+	}
+}
+
+func TestFailedRotationKeepsWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFile(path, 300, 1)
+	if err != nil {
+		t.Fatalf("new file: %v", err)
+	}
+	defer sink.Close()
+
+	// A non-empty directory where the oldest backup goes cannot be removed, so rotation fails.
+	blocker := filepath.Join(path+".1", "keep")
+	if err := os.MkdirAll(blocker, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var failed int
+	for i := 0; i < 6; i++ {
+		if err := sink.Write(Record{Time: start.Add(time.Duration(i) * time.Minute), Tool: "orders.getOrder", Outcome: OutcomeOK}); err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		t.Fatal("expected the blocked rotation to be reported")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.Count(data, []byte("\n")) != 6 {
+		t.Fatalf("records should keep going to the current file, got %q (%v)", data, err)
+	}
+
+	// Once the backup slot is free, the next write rotates.
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(Record{Time: start.Add(time.Hour), Tool: "orders.getOrder", Outcome: OutcomeOK}); err != nil {
+		t.Fatalf("write after unblocking: %v", err)
+	}
+	if info, err := os.Stat(path + ".1"); err != nil || info.IsDir() {
+		t.Fatalf("expected a rotated backup file, got %v", err)
+	}
+	if records, err := sink.Query(context.Background(), Filter{}); err != nil || len(records) != 7 {
+		t.Fatalf("expected every record after rotating, got %d (%v)", len(records), err)
+	}
+}
+
+func TestNewestRecordsKeepsLimit(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	kept := &newestRecords{limit: 3}
+	for _, minute := range []int{4, 1, 7, 3, 9, 2, 8} {
+		kept.add(Record{Time: start.Add(time.Duration(minute) * time.Minute)})
+	}
+
+	records := kept.sorted()
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	for i, minute := range []int{9, 8, 7} {
+		if records[i].Time != start.Add(time.Duration(minute)*time.Minute) {
+			t.Fatalf("record %d is %s", i, records[i].Time)
+		}
+	}
+}
+
+func TestTransportRecordsCalls(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-amzn-RequestId", "req-1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: Transport{Base: http.DefaultTransport}}
+	ctx, trail := WithTrail(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, upstream.URL+"/listings/2021-08-01/items/S1/SKU?buyerEmail=x", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	resp.Body.Close()
+
+	calls := trail.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one call, got %d", len(calls))
+	}
+	call := calls[0]
+	if call.Method != http.MethodPut || call.Path != "/listings/2021-08-01/items/S1/SKU" || call.Status != http.StatusAccepted || call.RequestID != "req-1" {
+		t.Fatalf("unexpected call %+v", call)
+	}
+}
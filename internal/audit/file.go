@@ -0,0 +1,256 @@
+package audit
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Rotation defaults for the file sink.
+const (
+	DefaultMaxBytes   = 100 << 20
+	DefaultMaxBackups = 5
+)
+
+// File is a JSONL sink that rotates at a size limit, keeping path.1 (newest) through path.N (oldest) as backups.
+type File struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+
+	mu sync.Mutex
+	// file is nil after Close, or when a failed rotation could not reopen the log; the next write retries the open.
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+// NewFile opens or creates the log at path. Zero limits use the defaults.
+func NewFile(path string, maxBytes int64, maxBackups int) (*File, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultMaxBackups
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("creating audit log directory: %w", err)
+	}
+
+	sink := &File{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *File) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+func (s *File) backup(n int) string {
+	return fmt.Sprintf("%s.%d", s.path, n)
+}
+
+// Write appends record as one line, rotating first when the line would push the file past its limit.
+func (s *File) Write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("audit log is closed")
+	}
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	var rotateErr error
+	if s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		// A failed rotation still leaves the log open when it can, so the record is kept in the oversized file and
+		// the next write tries again.
+		if rotateErr = s.rotate(); s.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return errors.Join(rotateErr, err)
+}
+
+// rotate moves the current file to the first backup and opens a fresh one. If the backups cannot be shifted, it
+// reopens the current file instead; s.file is nil only when neither can be opened.
+func (s *File) rotate() error {
+	closeErr := s.file.Close()
+	s.file = nil
+	err := errors.Join(closeErr, s.shiftBackups())
+	if openErr := s.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	if err != nil {
+		return fmt.Errorf("rotating audit log: %w", err)
+	}
+	return nil
+}
+
+func (s *File) shiftBackups() error {
+	if err := os.Remove(s.backup(s.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for n := s.maxBackups - 1; n >= 1; n-- {
+		if err := os.Rename(s.backup(n), s.backup(n+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.backup(1))
+}
+
+// Close flushes and closes the current file.
+func (s *File) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Query scans the current file and its backups and returns matching records, newest first. Only the files are opened
+// under the lock, so a long scan does not hold up the tool calls waiting to write their records.
+func (s *File) Query(ctx context.Context, filter Filter) ([]Record, error) {
+	files, err := s.openAll()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+
+	kept := &newestRecords{limit: filter.Limit}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := scanRecords(file, filter, kept.add); err != nil {
+			return nil, err
+		}
+	}
+	return kept.sorted(), nil
+}
+
+// openAll opens the current file and every backup while holding the lock, so a rotation cannot move a file between two
+// opens and have it read twice or not at all. A rotation after that is harmless: the handles keep reading the files
+// they opened, whatever they are renamed to.
+func (s *File) openAll() ([]*os.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{s.path}
+	for n := 1; n <= s.maxBackups; n++ {
+		names = append(names, s.backup(n))
+	}
+
+	var files []*os.File
+	for _, name := range names {
+		file, err := os.Open(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			for _, opened := range files {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("reading audit log: %w", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func scanRecords(file *os.File, filter Filter, add func(Record)) error {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var record Record
+		// A torn final line from a crash should not hide the rest of the log.
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		if filter.Matches(record) {
+			add(record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading audit log %s: %w", file.Name(), err)
+	}
+	return nil
+}
+
+// newestRecords keeps the limit newest records seen, or all of them without a limit, so a query over a full set of
+// backups holds at most limit records however many match. It is a min-heap on time: the root is the oldest record
+// kept and the first to go.
+type newestRecords struct {
+	limit   int
+	records []Record
+}
+
+func (h *newestRecords) Len() int           { return len(h.records) }
+func (h *newestRecords) Less(i, j int) bool { return h.records[i].Time.Before(h.records[j].Time) }
+func (h *newestRecords) Swap(i, j int)      { h.records[i], h.records[j] = h.records[j], h.records[i] }
+func (h *newestRecords) Push(x any)         { h.records = append(h.records, x.(Record)) }
+func (h *newestRecords) Pop() any {
+	last := h.records[len(h.records)-1]
+	h.records = h.records[:len(h.records)-1]
+	return last
+}
+
+func (h *newestRecords) add(record Record) {
+	if h.limit <= 0 {
+		h.records = append(h.records, record)
+		return
+	}
+	if len(h.records) < h.limit {
+		heap.Push(h, record)
+		return
+	}
+	if record.Time.After(h.records[0].Time) {
+		h.records[0] = record
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the kept records newest first.
+func (h *newestRecords) sorted() []Record {
+	sort.SliceStable(h.records, func(i, j int) bool { return h.records[i].Time.After(h.records[j].Time) })
+	return h.records
+}
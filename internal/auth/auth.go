@@ -0,0 +1,139 @@
+// Package auth authenticates MCP clients on the HTTP transports and decides which tools and seller profiles each
+// principal may use. Authentication accepts static bearer tokens and OIDC-issued JWTs; authorisation comes from a
+// policy file. The stdio transport has a single local user and does not use this package.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Name is the token's principal name or the JWT claim chosen by Config.OIDCClaim.
+	Name string
+	// Method is "token" or "oidc".
+	Method string
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns ctx carrying principal.
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller attached by Middleware; ok is false for unauthenticated contexts.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// ErrUnauthenticated means the request carried no usable credentials.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Config selects the accepted credentials. At least one of Tokens and OIDCIssuer must be set.
+type Config struct {
+	// Tokens maps principal names to static bearer tokens.
+	Tokens map[string]string
+	// OIDCIssuer enables JWT validation against the issuer's published keys.
+	OIDCIssuer string
+	// OIDCAudience must appear in the JWT's aud claim.
+	OIDCAudience string
+	// OIDCClaim names the claim used as the principal name; defaults to "sub".
+	OIDCClaim string
+}
+
+// Authenticator checks bearer credentials on HTTP requests.
+type Authenticator struct {
+	// tokens is keyed by the token's SHA-256 so lookups do not leak token prefixes through timing.
+	tokens map[[sha256.Size]byte]string
+	oidc   *oidcVerifier
+}
+
+// NewAuthenticator returns an Authenticator for cfg.
+func NewAuthenticator(cfg Config) (*Authenticator, error) {
+	if len(cfg.Tokens) == 0 && cfg.OIDCIssuer == "" {
+		return nil, errors.New("authentication needs static tokens or an OIDC issuer")
+	}
+
+	authenticator := &Authenticator{tokens: make(map[[sha256.Size]byte]string, len(cfg.Tokens))}
+	for name, token := range cfg.Tokens {
+		if name == "" || token == "" {
+			return nil, errors.New("static tokens need both a principal name and a token")
+		}
+		sum := sha256.Sum256([]byte(token))
+		if _, duplicate := authenticator.tokens[sum]; duplicate {
+			return nil, fmt.Errorf("principal %q reuses another principal's token", name)
+		}
+		authenticator.tokens[sum] = name
+	}
+
+	if cfg.OIDCIssuer != "" {
+		if cfg.OIDCAudience == "" {
+			return nil, errors.New("OIDC validation needs an audience")
+		}
+		claim := cfg.OIDCClaim
+		if claim == "" {
+			claim = "sub"
+		}
+		authenticator.oidc = newOIDCVerifier(cfg.OIDCIssuer, cfg.OIDCAudience, claim, http.DefaultClient)
+	}
+
+	return authenticator, nil
+}
+
+// Authenticate identifies the caller from the Authorization header. Static tokens are checked first; anything else
+// that looks like a JWT goes to the OIDC verifier.
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return Principal{}, ErrUnauthenticated
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	for known, name := range a.tokens {
+		if subtle.ConstantTimeCompare(known[:], sum[:]) == 1 {
+			return Principal{Name: name, Method: "token"}, nil
+		}
+	}
+
+	if a.oidc != nil && strings.Count(token, ".") == 2 {
+		name, err := a.oidc.verify(r.Context(), token)
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+		}
+		return Principal{Name: name, Method: "oidc"}, nil
+	}
+
+	return Principal{}, ErrUnauthenticated
+}
+
+// Middleware authenticates every request, rejects principals the policy does not admit to profile, and passes the
+// principal to the MCP handlers through the request context. A nil policy admits every authenticated principal.
+func Middleware(authenticator *Authenticator, policy *Policy, profile string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := authenticator.Authenticate(r)
+		if err != nil {
+			log.Printf("[WARN] auth: rejected %s %s from %s: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if policy != nil && !policy.AllowsProfile(principal.Name, profile) {
+			log.Printf("[WARN] auth: %s may not use seller profile %q", principal.Name, profile)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithPrincipal(r.Context(), principal)))
+	})
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := &Policy{Principals: map[string]Rule{
+		"analyst": {Allow: []string{"orders.*", "sales.*"}, Deny: []string{"orders.getOrderBuyerInfo"}, Profiles: []string{"acme-*"}},
+		"*":       {Allow: []string{"catalog.*"}},
+	}}
+
+	cases := []struct {
+		principal, tool string
+		want            bool
+	}{
+		{"analyst", "orders.getOrder", true},
+		{"analyst", "orders.getOrderBuyerInfo", false},
+		{"analyst", "catalog.searchCatalogItems", false},
+		{"someone", "catalog.searchCatalogItems", true},
+		{"someone", "orders.getOrder", false},
+	}
+	for _, c := range cases {
+		if got := policy.AllowsTool(c.principal, c.tool); got != c.want {
+			t.Errorf("AllowsTool(%s, %s) = %t, want %t", c.principal, c.tool, got, c.want)
+		}
+	}
+
+	if !policy.AllowsProfile("analyst", "acme-us") || policy.AllowsProfile("analyst", "other") {
+		t.Error("analyst profile patterns not applied")
+	}
+	if !policy.AllowsProfile("someone", "other") {
+		t.Error("a rule without profiles should admit every profile")
+	}
+	if (&Policy{}).AllowsTool("anyone", "orders.getOrder") {
+		t.Error("unlisted principals without a * rule must get nothing")
+	}
+}
+
+func TestMiddlewareStaticToken(t *testing.T) {
+	authenticator, err := NewAuthenticator(Config{Tokens: map[string]string{"ops": "s3cret"}})
+	if err != nil {
+		t.Fatalf("new authenticator: %v", err)
+	}
+	policy := &Policy{Principals: map[string]Rule{"ops": {Allow: []string{"*"}, Profiles: []string{"eu"}}}}
+
+	var seen Principal
+	handler := Middleware(authenticator, policy, "eu", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = PrincipalFromContext(r.Context())
+	}))
+
+	status := func(header string, h http.Handler) int {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := status("", handler); code != http.StatusUnauthorized {
+		t.Fatalf("missing token: got %d", code)
+	}
+	if code := status("Bearer wrong", handler); code != http.StatusUnauthorized {
+		t.Fatalf("wrong token: got %d", code)
+	}
+	if code := status("Bearer s3cret", handler); code != http.StatusOK || seen.Name != "ops" {
+		t.Fatalf("valid token: got %d, principal %+v", code, seen)
+	}
+
+	usProfile := Middleware(authenticator, policy, "us", http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	if code := status("Bearer s3cret", usProfile); code != http.StatusForbidden {
+		t.Fatalf("disallowed profile: got %d", code)
+	}
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	idp := httptest.NewServer(mux)
+	defer idp.Close()
+	issuer = idp.URL
+
+	authenticator, err := NewAuthenticator(Config{OIDCIssuer: issuer, OIDCAudience: "mcp", OIDCClaim: "email"})
+	if err != nil {
+		t.Fatalf("new authenticator: %v", err)
+	}
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return signed
+	}
+	authenticate := func(token string) (Principal, error) {
+		req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return authenticator.Authenticate(req)
+	}
+
+	exp := time.Now().Add(time.Hour).Unix()
+	principal, err := authenticate(sign(jwt.MapClaims{"iss": issuer, "aud": "mcp", "exp": exp, "email": "ana@example.com"}))
+	if err != nil || principal.Name != "ana@example.com" || principal.Method != "oidc" {
+		t.Fatalf("valid JWT: principal %+v, err %v", principal, err)
+	}
+
+	if _, err := authenticate(sign(jwt.MapClaims{"iss": issuer, "aud": "other", "exp": exp, "email": "ana@example.com"})); err == nil {
+		t.Fatal("JWT for another audience was accepted")
+	}
+	if _, err := authenticate(sign(jwt.MapClaims{"iss": issuer, "aud": "mcp", "exp": time.Now().Add(-time.Hour).Unix(), "email": "ana@example.com"})); err == nil {
+		t.Fatal("expired JWT was accepted")
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+)
+
+// Rule lists what one principal may do. Tool patterns use path.Match syntax, so "orders.*" matches every Orders tool;
+// a tool must match an Allow pattern and no Deny pattern. An empty Profiles list admits every seller profile.
+type Rule struct {
+	Allow    []string `json:"allow"`
+	Deny     []string `json:"deny"`
+	Profiles []string `json:"profiles"`
+}
+
+// Policy maps principal names to rules. The "*" entry applies to authenticated principals without their own entry;
+// without it, unlisted principals get nothing.
+//
+//	{"principals": {
+//	  "analyst": {"allow": ["orders.*", "sales.*"], "deny": ["orders.getOrderBuyerInfo"], "profiles": ["acme-us"]},
+//	  "*":       {"allow": ["catalog.*"]}
+//	}}
+type Policy struct {
+	Principals map[string]Rule `json:"principals"`
+}
+
+// LoadPolicy reads a JSON policy file and checks its patterns.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading access policy: %w", err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parsing access policy %s: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("access policy %s: %w", file, err)
+	}
+	return &policy, nil
+}
+
+func (p *Policy) validate() error {
+	for principal, rule := range p.Principals {
+		for _, patterns := range [][]string{rule.Allow, rule.Deny, rule.Profiles} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("principal %q: bad pattern %q", principal, pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Policy) rule(principal string) (Rule, bool) {
+	if rule, ok := p.Principals[principal]; ok {
+		return rule, true
+	}
+	rule, ok := p.Principals["*"]
+	return rule, ok
+}
+
+// AllowsTool reports whether principal may list and call tool.
+func (p *Policy) AllowsTool(principal, tool string) bool {
+	rule, ok := p.rule(principal)
+	if !ok {
+		return false
+	}
+	return matchesAny(rule.Allow, tool) && !matchesAny(rule.Deny, tool)
+}
+
+// AllowsProfile reports whether principal may act for the seller profile.
+func (p *Policy) AllowsProfile(principal, profile string) bool {
+	rule, ok := p.rule(principal)
+	if !ok {
+		return false
+	}
+	return len(rule.Profiles) == 0 || matchesAny(rule.Profiles, profile)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
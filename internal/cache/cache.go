@@ -0,0 +1,99 @@
+// Package cache stores SP-API responses so repeated reads inside their TTL skip the network. It knows nothing about
+// which operations are safe to cache; callers decide that and pick the key.
+package cache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Entry is a cached HTTP response.
+type Entry struct {
+	Status    int         `json:"status"`
+	Header    http.Header `json:"header"`
+	Body      []byte      `json:"body"`
+	StoredAt  time.Time   `json:"storedAt"`
+	ExpiresAt time.Time   `json:"expiresAt"`
+}
+
+// Expired reports whether the entry is past its TTL at now.
+func (e Entry) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// Backend holds entries by key. Implementations must be safe for concurrent use and treat storage failures as misses.
+type Backend interface {
+	Get(key string) (Entry, bool)
+	Set(key string, entry Entry)
+	// Purge drops every entry, for example after a write that may have made cached reads stale.
+	Purge()
+}
+
+// DefaultMemoryEntries bounds the in-memory backend when no size is given.
+const DefaultMemoryEntries = 1000
+
+type memory struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryItem struct {
+	key   string
+	entry Entry
+}
+
+// NewMemory returns an in-process LRU backend holding at most maxEntries responses.
+func NewMemory(maxEntries int) Backend {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryEntries
+	}
+	return &memory{max: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (m *memory) Get(key string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+	item := element.Value.(*memoryItem)
+	if item.entry.Expired(time.Now()) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return Entry{}, false
+	}
+	m.order.MoveToFront(element)
+	return item.entry, true
+}
+
+func (m *memory) Set(key string, entry Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryItem).entry = entry
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryItem{key: key, entry: entry})
+	for m.order.Len() > m.max {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryItem).key)
+	}
+}
+
+func (m *memory) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.order.Init()
+	m.entries = make(map[string]*list.Element)
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackends(t *testing.T) {
+	disk, err := NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatalf("disk: %v", err)
+	}
+
+	for name, backend := range map[string]Backend{"memory": NewMemory(2), "disk": disk} {
+		now := time.Now()
+		backend.Set("a", Entry{Status: 200, Body: []byte("a"), StoredAt: now, ExpiresAt: now.Add(time.Minute)})
+		backend.Set("expired", Entry{Status: 200, StoredAt: now, ExpiresAt: now.Add(-time.Second)})
+
+		if entry, ok := backend.Get("a"); !ok || string(entry.Body) != "a" {
+			t.Fatalf("%s: expected a hit, got %+v", name, entry)
+		}
+		if _, ok := backend.Get("expired"); ok {
+			t.Fatalf("%s: expired entry was served", name)
+		}
+
+		backend.Purge()
+		if _, ok := backend.Get("a"); ok {
+			t.Fatalf("%s: entry survived purge", name)
+		}
+	}
+
+	memory := NewMemory(1)
+	memory.Set("a", Entry{ExpiresAt: time.Now().Add(time.Minute)})
+	memory.Set("b", Entry{ExpiresAt: time.Now().Add(time.Minute)})
+	if _, ok := memory.Get("a"); ok {
+		t.Fatalf("expected the oldest entry to be evicted")
+	}
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type disk struct {
+	dir string
+}
+
+// NewDisk returns a backend that keeps one JSON file per entry under dir, so cached responses survive restarts.
+// Expired files are removed when read.
+func NewDisk(dir string) (Backend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	return &disk{dir: dir}, nil
+}
+
+func (d *disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *disk) Get(key string) (Entry, bool) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		os.Remove(path)
+		return Entry{}, false
+	}
+	if entry.Expired(time.Now()) {
+		os.Remove(path)
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+func (d *disk) Set(key string, entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] cache: encoding entry: %v", err)
+		return
+	}
+
+	// Write then rename so a concurrent reader never sees a partial file.
+	tmp, err := os.CreateTemp(d.dir, "entry-*.tmp")
+	if err != nil {
+		log.Printf("[WARN] cache: %v", err)
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		log.Printf("[WARN] cache: writing entry failed")
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		log.Printf("[WARN] cache: %v", err)
+	}
+}
+
+func (d *disk) Purge() {
+	files, err := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
 )
 
 const (
@@ -15,6 +19,7 @@ const (
 	defaultTransport     = "stdio"
 	defaultHost          = "localhost"
 	defaultPort          = "8080"
+	defaultProfile       = "default"
 )
 
 // Transport is the mechanism used to expose the MCP server.
@@ -29,6 +34,122 @@ const (
 	TransportStreamableHTTP Transport = "streamablehttp"
 )
 
+// AccountType identifies whether the configured credentials belong to a seller (3P) or vendor (1P) account.
+type AccountType string
+
+const (
+	// AccountTypeSeller exposes the seller-side APIs such as Orders, FBA Inventory and Listings. This is the default.
+	AccountTypeSeller AccountType = "seller"
+	// AccountTypeVendor exposes the Vendor Central APIs such as Vendor Orders, Direct Fulfillment and Invoices.
+	AccountTypeVendor AccountType = "vendor"
+)
+
+// SandboxMode routes SP-API calls away from production.
+type SandboxMode string
+
+const (
+	// SandboxOff calls the production endpoint. This is the default.
+	SandboxOff SandboxMode = "off"
+	// SandboxAmazon calls Amazon's sandbox host for the endpoint's region, which answers the documented static and
+	// dynamic sandbox requests.
+	SandboxAmazon SandboxMode = "amazon"
+	// SandboxLocal calls the bundled emulator, which needs neither credentials nor network access.
+	SandboxLocal SandboxMode = "local"
+)
+
+// CassetteMode says whether a cassette records SP-API exchanges or replays them.
+type CassetteMode string
+
+const (
+	// CassetteReplay answers SP-API calls from the cassette without network access. This is the default.
+	CassetteReplay CassetteMode = "replay"
+	// CassetteRecord sends SP-API calls and writes the redacted exchanges to the cassette.
+	CassetteRecord CassetteMode = "record"
+)
+
+// CassetteConfig records or replays SP-API exchanges; see spapi.Cassette.
+type CassetteConfig struct {
+	// Path is the cassette file; empty disables recording and replay.
+	Path string
+	Mode CassetteMode
+}
+
+// CacheBackend selects where cached SP-API responses are kept.
+type CacheBackend string
+
+const (
+	// CacheMemory keeps responses in process memory. This is the default.
+	CacheMemory CacheBackend = "memory"
+	// CacheDisk keeps responses as files so they survive restarts.
+	CacheDisk CacheBackend = "disk"
+	// CacheOff disables response caching.
+	CacheOff CacheBackend = "off"
+)
+
+// CacheConfig controls the SP-API response cache.
+type CacheConfig struct {
+	Backend CacheBackend
+	// Dir is the disk backend's directory; empty uses the user cache directory.
+	Dir string
+	// TTLs overrides default TTLs by operation name (e.g. "catalog", "orders.getOrder"). Zero disables an operation.
+	TTLs map[string]time.Duration
+}
+
+// AuthConfig controls client authentication on the HTTP transports. It is ignored for stdio.
+type AuthConfig struct {
+	// Tokens maps principal names to static bearer tokens.
+	Tokens map[string]string
+	// OIDCIssuer enables JWT validation against the issuer's published signing keys.
+	OIDCIssuer string
+	// OIDCAudience is the audience JWTs must be issued for.
+	OIDCAudience string
+	// OIDCClaim is the JWT claim used as the principal name.
+	OIDCClaim string
+	// PolicyPath is a JSON file mapping principals to allowed tools and seller profiles. Empty allows everything.
+	PolicyPath string
+}
+
+// Enabled reports whether any authentication method is configured.
+func (a AuthConfig) Enabled() bool {
+	return len(a.Tokens) > 0 || a.OIDCIssuer != ""
+}
+
+// AuditConfig controls the tool call audit log.
+type AuditConfig struct {
+	// Path is a JSONL file, "stderr", or empty to disable auditing.
+	Path string
+	// MaxSizeMB rotates the file once it reaches this size.
+	MaxSizeMB int
+	// MaxFiles is how many rotated files are kept besides the current one.
+	MaxFiles int
+}
+
+// DomainConfig selects tool domains, the part of a tool name before the first dot such as "orders" or "dataKiosk".
+type DomainConfig struct {
+	// Enabled limits the server to these domains; empty enables every domain.
+	Enabled []string
+	// Disabled removes domains after Enabled is applied.
+	Disabled []string
+}
+
+// HealthConfig controls the credential watchdog.
+type HealthConfig struct {
+	// Interval is how often a test token exchange runs; zero disables the watchdog.
+	Interval time.Duration
+	// AuthorizationExpiresAt is when the seller's authorization of the app lapses; zero skips the expiry warning.
+	AuthorizationExpiresAt time.Time
+	// AuthorizationWarning is how long before AuthorizationExpiresAt the warnings start.
+	AuthorizationWarning time.Duration
+}
+
+// OAuthConfig enables onboarding sellers through the Seller Central authorization flow.
+type OAuthConfig struct {
+	// ApplicationID is the SP-API application the seller authorizes; empty disables onboarding.
+	ApplicationID string
+	// RedirectURL is the redirect URI registered for the app; empty uses the server's own callback URL.
+	RedirectURL string
+}
+
 // Credentials encapsulates SP-API credentials sourced from the environment.
 type Credentials struct {
 	ClientID     string
@@ -56,56 +177,348 @@ type Config struct {
 	Transport     Transport
 	Host          string
 	Port          string
+	// AllowWrites enables tools that change seller data (listings, content, shipments). Disabled by default.
+	AllowWrites bool
+	// ConfirmWrites asks a person to approve each destructive tool call before it runs.
+	ConfirmWrites bool
+	// DryRun makes every write-capable tool return the request it would send instead of sending it.
+	DryRun bool
+	// SellerID is the merchant token used by APIs that take a sellerId parameter, such as Listings.
+	SellerID string
+	// AccountType selects which API families are registered for the configured credentials.
+	AccountType AccountType
+	// StorePath is the SQLite file that mirrors orders locally. Empty disables the store and its tools.
+	StorePath string
+	// UploadDir is the only directory uploads.uploadFile reads from. Empty disables the tool.
+	UploadDir string
+	// Cache configures the SP-API response cache.
+	Cache CacheConfig
+	// Profile names the seller credential profile this server acts for; access policies grant profiles by name.
+	Profile string
+	// Auth configures client authentication and tool access on the HTTP transports.
+	Auth AuthConfig
+	// Audit configures the tool call audit log.
+	Audit AuditConfig
+	// Domains enables and disables tool domains.
+	Domains DomainConfig
+	// Redaction decides how buyer PII in tool results is masked, hashed or dropped.
+	Redaction redact.Policy
+	// Verbose logs SP-API requests and responses.
+	Verbose bool
+	// Sandbox routes SP-API calls to Amazon's sandbox or the local emulator.
+	Sandbox SandboxMode
+	// Cassette records SP-API exchanges to a file or replays them from one.
+	Cassette CassetteConfig
+	// ProfilesFile holds named credential profiles; see ProfileStore.
+	ProfilesFile string
+	// OAuth configures seller onboarding through auth.beginAuthorization.
+	OAuth OAuthConfig
+	// Health configures the credential watchdog.
+	Health HealthConfig
+	// SecretRefresh is how often credential references are resolved again to pick up rotated secrets.
+	SecretRefresh time.Duration
+
+	secrets        *SecretResolver
+	keyring        *Keyring
+	credentialRefs Credentials
+	effective      []SettingValue
 }
 
 // Load constructs a Config from environment variables, applying defaults and validation.
 func Load() (Config, error) {
-	transport, err := parseTransport(envOrDefault("MCP_TRANSPORT", defaultTransport))
+	return LoadFrom(Options{})
+}
+
+// LoadFrom layers defaults, the config file, environment variables and flags, in that order, then validates the result.
+// Errors name the file key, variable or flag that holds the offending value.
+func LoadFrom(opts Options) (Config, error) {
+	l, err := newLoader(opts)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := applyProfile(l); err != nil {
+		return Config{}, err
+	}
+
+	transport, err := parseTransport(l.where("MCP_TRANSPORT"), l.get("MCP_TRANSPORT"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	accountType, err := parseAccountType(l.where("SP_API_ACCOUNT_TYPE"), l.get("SP_API_ACCOUNT_TYPE"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	allowWrites, err := parseBool(l.where("SP_API_ENABLE_WRITES"), l.get("SP_API_ENABLE_WRITES"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	confirmWrites, err := parseBool(l.where("SP_API_CONFIRM_WRITES"), l.get("SP_API_CONFIRM_WRITES"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	dryRun, err := parseBool(l.where("SP_API_DRY_RUN"), l.get("SP_API_DRY_RUN"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	verbose, err := parseBool(l.where("VERBOSE"), l.get("VERBOSE"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	sandbox, err := parseSandboxMode(l.where("SP_API_SANDBOX"), l.get("SP_API_SANDBOX"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	cassetteMode, err := parseCassetteMode(l.where("SP_API_CASSETTE_MODE"), l.get("SP_API_CASSETTE_MODE"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	cacheBackend, err := parseCacheBackend(l.where("SP_API_CACHE"), l.get("SP_API_CACHE"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	cacheTTLs, err := parseDurations(l.where("SP_API_CACHE_TTLS"), l.get("SP_API_CACHE_TTLS"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	redaction, err := parseRedactionPolicy(l.where("SP_API_PII_POLICY"), l.get("SP_API_PII_POLICY"), l.where("SP_API_PII_RULES"), l.get("SP_API_PII_RULES"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	healthInterval, err := parseDuration(l.where("SP_API_HEALTH_INTERVAL"), l.get("SP_API_HEALTH_INTERVAL"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	authorizationExpires, err := parseDate(l.where("SP_API_AUTHORIZATION_EXPIRES"), l.get("SP_API_AUTHORIZATION_EXPIRES"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	authorizationWarning, err := parsePositiveDuration(l.where("SP_API_AUTHORIZATION_WARNING"), l.get("SP_API_AUTHORIZATION_WARNING"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	secretRefresh, err := parsePositiveDuration(l.where("SP_API_SECRET_REFRESH"), l.get("SP_API_SECRET_REFRESH"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	// The passphrase may itself live in a file, variable or helper, but not in the keyring it unlocks.
+	passphrase, err := NewSecretResolver("", "").Resolve(context.Background(), l.get("SP_API_KEYRING_PASSPHRASE"))
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", l.where("SP_API_KEYRING_PASSPHRASE"), err)
+	}
+	secrets := NewSecretResolver(l.get("SP_API_KEYRING_FILE"), passphrase)
+	var keyring *Keyring
+	if path := l.get("SP_API_KEYRING_FILE"); path != "" {
+		keyring = &Keyring{Path: path, Passphrase: passphrase}
+	}
+
+	resolved := make(map[string]string)
+	for _, key := range []string{"SP_API_CLIENT_ID", "SP_API_CLIENT_SECRET", "SP_API_REFRESH_TOKEN", "SP_API_PII_HASH_KEY", "MCP_AUTH_TOKENS"} {
+		value, err := secrets.Resolve(context.Background(), l.get(key))
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", l.where(key), err)
+		}
+		resolved[key] = value
+	}
+	redaction.HashKey = []byte(resolved["SP_API_PII_HASH_KEY"])
+
+	authTokens, err := parsePairs(l.where("MCP_AUTH_TOKENS"), resolved["MCP_AUTH_TOKENS"], "principal=token")
+	if err != nil {
+		return Config{}, err
+	}
+
+	auditMaxSize, err := parsePositiveInt(l.where("SP_API_AUDIT_MAX_SIZE_MB"), l.get("SP_API_AUDIT_MAX_SIZE_MB"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	auditMaxFiles, err := parsePositiveInt(l.where("SP_API_AUDIT_MAX_FILES"), l.get("SP_API_AUDIT_MAX_FILES"))
 	if err != nil {
 		return Config{}, err
 	}
 
 	cfg := Config{
-		ServerName:    envOrDefault("MCP_SERVER_NAME", defaultServerName),
-		ServerVersion: envOrDefault("MCP_SERVER_VERSION", defaultServerVersion),
-		Instructions:  envOrDefault("MCP_SERVER_INSTRUCTIONS", defaultInstructions),
-		SPAPIEndpoint: envOrDefault("SP_API_ENDPOINT", defaultEndpoint),
+		ServerName:    l.get("MCP_SERVER_NAME"),
+		ServerVersion: l.get("MCP_SERVER_VERSION"),
+		Instructions:  l.get("MCP_SERVER_INSTRUCTIONS"),
+		SPAPIEndpoint: l.get("SP_API_ENDPOINT"),
+		Sandbox:       sandbox,
 		Credentials: Credentials{
-			ClientID:     strings.TrimSpace(os.Getenv("SP_API_CLIENT_ID")),
-			ClientSecret: strings.TrimSpace(os.Getenv("SP_API_CLIENT_SECRET")),
-			RefreshToken: strings.TrimSpace(os.Getenv("SP_API_REFRESH_TOKEN")),
+			ClientID:     resolved["SP_API_CLIENT_ID"],
+			ClientSecret: resolved["SP_API_CLIENT_SECRET"],
+			RefreshToken: resolved["SP_API_REFRESH_TOKEN"],
 		},
-		Transport: transport,
-		Host:      envOrDefault("HOST", defaultHost),
-		Port:      envOrDefault("PORT", defaultPort),
+		SecretRefresh: secretRefresh,
+		Transport:     transport,
+		Host:          l.get("HOST"),
+		Port:          l.get("PORT"),
+		AllowWrites:   allowWrites,
+		DryRun:        dryRun,
+		ConfirmWrites: confirmWrites,
+		SellerID:      l.get("SP_API_SELLER_ID"),
+		AccountType:   accountType,
+		StorePath:     l.get("SP_API_STORE_PATH"),
+		UploadDir:     l.get("SP_API_UPLOAD_DIR"),
+		Cache: CacheConfig{
+			Backend: cacheBackend,
+			Dir:     l.get("SP_API_CACHE_DIR"),
+			TTLs:    cacheTTLs,
+		},
+		Cassette: CassetteConfig{
+			Path: l.get("SP_API_CASSETTE"),
+			Mode: cassetteMode,
+		},
+		Profile:      l.get("SP_API_PROFILE"),
+		ProfilesFile: l.get("SP_API_PROFILES_FILE"),
+		OAuth: OAuthConfig{
+			ApplicationID: l.get("SP_API_APPLICATION_ID"),
+			RedirectURL:   l.get("SP_API_OAUTH_REDIRECT_URL"),
+		},
+		Auth: AuthConfig{
+			Tokens:       authTokens,
+			OIDCIssuer:   l.get("MCP_AUTH_OIDC_ISSUER"),
+			OIDCAudience: l.get("MCP_AUTH_OIDC_AUDIENCE"),
+			OIDCClaim:    l.get("MCP_AUTH_OIDC_CLAIM"),
+			PolicyPath:   l.get("MCP_AUTH_POLICY"),
+		},
+		Audit: AuditConfig{
+			Path:      l.get("SP_API_AUDIT_LOG"),
+			MaxSizeMB: auditMaxSize,
+			MaxFiles:  auditMaxFiles,
+		},
+		Domains: DomainConfig{
+			Enabled:  parseList(l.get("SP_API_ENABLE_DOMAINS")),
+			Disabled: parseList(l.get("SP_API_DISABLE_DOMAINS")),
+		},
+		Health: HealthConfig{
+			Interval:               healthInterval,
+			AuthorizationExpiresAt: authorizationExpires,
+			AuthorizationWarning:   authorizationWarning,
+		},
+		Redaction: redaction,
+		Verbose:   verbose,
+		secrets:   secrets,
+		keyring:   keyring,
+		credentialRefs: Credentials{
+			ClientID:     l.get("SP_API_CLIENT_ID"),
+			ClientSecret: l.get("SP_API_CLIENT_SECRET"),
+			RefreshToken: l.get("SP_API_REFRESH_TOKEN"),
+		},
+		effective: l.redacted(),
+	}
+
+	if cfg.Sandbox == SandboxAmazon {
+		cfg.SPAPIEndpoint = SandboxEndpoint(cfg.SPAPIEndpoint)
 	}
 
-	if err := cfg.validate(); err != nil {
+	if err := cfg.validate(l); err != nil {
 		return Config{}, err
 	}
 
 	return cfg, nil
 }
 
-func (c Config) validate() error {
-	if !c.Credentials.IsEmpty() && !c.Credentials.IsComplete() {
+// SandboxEndpoint returns the sandbox host of a regional SP-API endpoint, such as
+// https://sandbox.sellingpartnerapi-eu.amazon.com for the EU endpoint. Other endpoints come back unchanged.
+func SandboxEndpoint(endpoint string) string {
+	const prefix = "https://sellingpartnerapi-"
+	if strings.HasPrefix(endpoint, prefix) {
+		return "https://sandbox.sellingpartnerapi-" + strings.TrimPrefix(endpoint, prefix)
+	}
+	return endpoint
+}
+
+// ResolveCredentials resolves the credential settings again, so secrets rotated in a file, keyring or secret store
+// are picked up without a restart. Literal values come back unchanged.
+func (c Config) ResolveCredentials(ctx context.Context) (Credentials, error) {
+	if c.secrets == nil {
+		return c.Credentials, nil
+	}
+	var creds Credentials
+	for _, field := range []struct {
+		key    string
+		ref    string
+		target *string
+	}{
+		{"SP_API_CLIENT_ID", c.credentialRefs.ClientID, &creds.ClientID},
+		{"SP_API_CLIENT_SECRET", c.credentialRefs.ClientSecret, &creds.ClientSecret},
+		{"SP_API_REFRESH_TOKEN", c.credentialRefs.RefreshToken, &creds.RefreshToken},
+	} {
+		value, err := c.secrets.Resolve(ctx, field.ref)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("%s: %w", field.key, err)
+		}
+		*field.target = value
+	}
+	return creds, nil
+}
+
+// ProfileStore returns the credential profiles file, sealing new refresh tokens in the keyring when one is configured.
+// It is nil when SP_API_PROFILES_FILE is unset.
+func (c Config) ProfileStore() *ProfileStore {
+	if c.ProfilesFile == "" {
+		return nil
+	}
+	return &ProfileStore{Path: c.ProfilesFile, Keyring: c.keyring}
+}
+
+// Effective lists every setting with its value and source, secrets redacted, for --print-config.
+func (c Config) Effective() []SettingValue {
+	return c.effective
+}
+
+func (c Config) validate(l *loader) error {
+	// A server that only onboards sellers has the app's client credentials but no refresh token yet.
+	onboardingOnly := c.OAuth.ApplicationID != "" && c.Credentials.ClientID != "" && c.Credentials.ClientSecret != ""
+	// The local sandbox accepts any credentials and fills in missing ones.
+	if !c.Credentials.IsEmpty() && !c.Credentials.IsComplete() && !onboardingOnly && c.Sandbox != SandboxLocal {
 		return fmt.Errorf("SP-API credentials are partially configured; provide all values or none")
 	}
 
 	if (c.Transport == TransportSSE || c.Transport == TransportStreamableHTTP) && strings.TrimSpace(c.Port) == "" {
-		return fmt.Errorf("PORT must be set for transport %q", c.Transport)
+		return fmt.Errorf("%s must be set for transport %q", l.where("PORT"), c.Transport)
+	}
+
+	if c.Auth.OIDCIssuer != "" && c.Auth.OIDCAudience == "" {
+		return fmt.Errorf("MCP_AUTH_OIDC_AUDIENCE must be set when %s is", l.where("MCP_AUTH_OIDC_ISSUER"))
+	}
+
+	if c.Auth.PolicyPath != "" && !c.Auth.Enabled() {
+		return fmt.Errorf("%s needs MCP_AUTH_TOKENS or MCP_AUTH_OIDC_ISSUER so principals can be identified", l.where("MCP_AUTH_POLICY"))
+	}
+
+	if c.UploadDir != "" {
+		if info, err := os.Stat(c.UploadDir); err != nil {
+			return fmt.Errorf("%s: %w", l.where("SP_API_UPLOAD_DIR"), err)
+		} else if !info.IsDir() {
+			return fmt.Errorf("%s: %s is not a directory", l.where("SP_API_UPLOAD_DIR"), c.UploadDir)
+		}
 	}
 
 	if c.Port != "" {
 		if _, err := strconv.Atoi(c.Port); err != nil {
-			return fmt.Errorf("PORT must be numeric: %w", err)
+			return fmt.Errorf("%s must be numeric: %w", l.where("PORT"), err)
 		}
 	}
 
 	return nil
 }
 
-func parseTransport(raw string) (Transport, error) {
+func parseTransport(key, raw string) (Transport, error) {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "", string(TransportSTDIO):
 		return TransportSTDIO, nil
@@ -114,13 +527,183 @@ func parseTransport(raw string) (Transport, error) {
 	case string(TransportStreamableHTTP):
 		return TransportStreamableHTTP, nil
 	default:
-		return Transport(""), fmt.Errorf("unsupported MCP transport %q", raw)
+		return Transport(""), fmt.Errorf("%s: unsupported MCP transport %q; use stdio, sse or streamablehttp", key, raw)
+	}
+}
+
+func parseAccountType(key, raw string) (AccountType, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", string(AccountTypeSeller):
+		return AccountTypeSeller, nil
+	case string(AccountTypeVendor):
+		return AccountTypeVendor, nil
+	default:
+		return AccountType(""), fmt.Errorf("%s: unsupported account type %q; use seller or vendor", key, raw)
+	}
+}
+
+func parseSandboxMode(key, raw string) (SandboxMode, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "false", string(SandboxOff):
+		return SandboxOff, nil
+	case "true", string(SandboxAmazon):
+		return SandboxAmazon, nil
+	case string(SandboxLocal):
+		return SandboxLocal, nil
+	default:
+		return SandboxMode(""), fmt.Errorf("%s: unsupported sandbox mode %q; use off, amazon or local", key, raw)
+	}
+}
+
+func parseCassetteMode(key, raw string) (CassetteMode, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", string(CassetteReplay):
+		return CassetteReplay, nil
+	case string(CassetteRecord):
+		return CassetteRecord, nil
+	default:
+		return CassetteMode(""), fmt.Errorf("%s: unsupported cassette mode %q; use replay or record", key, raw)
+	}
+}
+
+func parseCacheBackend(key, raw string) (CacheBackend, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", string(CacheMemory):
+		return CacheMemory, nil
+	case string(CacheDisk):
+		return CacheDisk, nil
+	case string(CacheOff):
+		return CacheOff, nil
+	default:
+		return CacheBackend(""), fmt.Errorf("%s: unsupported cache backend %q; use memory, disk or off", key, raw)
+	}
+}
+
+// parseRedactionPolicy reads a default action and comma-separated category=action rules such as "email=hash,address=drop".
+func parseRedactionPolicy(defaultKey, rawDefault, rulesKey, rawRules string) (redact.Policy, error) {
+	action, err := redact.ParseAction(rawDefault)
+	if err != nil {
+		return redact.Policy{}, fmt.Errorf("%s: %w", defaultKey, err)
+	}
+
+	policy := redact.Policy{Default: action, Rules: make(map[redact.Category]redact.Action)}
+	for _, pair := range strings.Split(rawRules, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return redact.Policy{}, fmt.Errorf("%s entry %q must look like category=action", rulesKey, pair)
+		}
+		category, err := redact.ParseCategory(name)
+		if err != nil {
+			return redact.Policy{}, fmt.Errorf("%s: %w", rulesKey, err)
+		}
+		categoryAction, err := redact.ParseAction(value)
+		if err != nil {
+			return redact.Policy{}, fmt.Errorf("%s: %w", rulesKey, err)
+		}
+		policy.Rules[category] = categoryAction
+	}
+	return policy, nil
+}
+
+// parsePairs reads a comma-separated list of name=value pairs; format describes one entry for error messages.
+func parsePairs(key, raw, format string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("%s entries must look like %s", key, format)
+		}
+		pairs[name] = value
 	}
+	return pairs, nil
 }
 
-func envOrDefault(key, fallback string) string {
-	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
-		return value
+// parseList reads a comma-separated list, dropping empty entries.
+func parseList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseDurations reads a comma-separated list of name=duration pairs such as "catalog=24h,productPricing=60s".
+func parseDurations(key, raw string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s entry %q must look like name=duration", key, pair)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s entry %q: %w", key, pair, err)
+		}
+		durations[strings.TrimSpace(name)] = duration
+	}
+	return durations, nil
+}
+
+// parseDuration reads a duration where zero turns the feature off.
+func parseDuration(key, raw string) (time.Duration, error) {
+	value, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a duration such as 15m, or 0 to disable", key)
+	}
+	return value, nil
+}
+
+// parseDate reads a YYYY-MM-DD date or an RFC 3339 timestamp; empty is the zero time.
+func parseDate(key, raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if value, err := time.Parse(time.DateOnly, raw); err == nil {
+		return value, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date such as 2026-03-31", key)
+	}
+	return value, nil
+}
+
+func parsePositiveDuration(key, raw string) (time.Duration, error) {
+	value, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 5m", key)
+	}
+	return value, nil
+}
+
+func parsePositiveInt(key, raw string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", key)
+	}
+	return value, nil
+}
+
+func parseBool(key, raw string) (bool, error) {
+	value, err := strconv.ParseBool(strings.TrimSpace(raw))
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean: %w", key, err)
 	}
-	return fallback
+	return value, nil
 }
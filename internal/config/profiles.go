@@ -0,0 +1,163 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SourceProfile marks settings taken from the selected credential profile, which replace every other layer.
+const SourceProfile Source = "profile"
+
+// Profile is one seller's credentials in the profiles file. Each field overrides the setting named in profileFields.
+type Profile struct {
+	// RefreshToken is the seller's LWA refresh token or a secret reference such as keyring://profile.acme.refresh-token.
+	RefreshToken     string `json:"refreshToken"`
+	SellingPartnerID string `json:"sellingPartnerId,omitempty"`
+	Endpoint         string `json:"endpoint,omitempty"`
+	// AuthorizationExpires is the date the seller's authorization of the app lapses, as YYYY-MM-DD.
+	AuthorizationExpires string    `json:"authorizationExpires,omitempty"`
+	AuthorizedAt         time.Time `json:"authorizedAt,omitzero"`
+	// Sandbox is off, amazon or local; see SandboxMode.
+	Sandbox string `json:"sandbox,omitempty"`
+	// AccountType is seller or vendor, so a vendor profile gets the vendor tools whatever SP_API_ACCOUNT_TYPE says.
+	AccountType string `json:"accountType,omitempty"`
+}
+
+// profileFields maps profile fields to the settings they replace.
+var profileFields = []struct {
+	name  string
+	key   string
+	value func(Profile) string
+}{
+	{"refreshToken", "SP_API_REFRESH_TOKEN", func(p Profile) string { return p.RefreshToken }},
+	{"sellingPartnerId", "SP_API_SELLER_ID", func(p Profile) string { return p.SellingPartnerID }},
+	{"endpoint", "SP_API_ENDPOINT", func(p Profile) string { return p.Endpoint }},
+	{"authorizationExpires", "SP_API_AUTHORIZATION_EXPIRES", func(p Profile) string { return p.AuthorizationExpires }},
+	{"sandbox", "SP_API_SANDBOX", func(p Profile) string { return p.Sandbox }},
+	{"accountType", "SP_API_ACCOUNT_TYPE", func(p Profile) string { return p.AccountType }},
+}
+
+// ProfileStore is the JSON file of credential profiles named by SP_API_PROFILES_FILE, keyed by profile name.
+type ProfileStore struct {
+	Path string
+	// Keyring, when set, receives the refresh tokens Save is given; the profile then holds a keyring:// reference.
+	Keyring *Keyring
+}
+
+// Load reads every profile. A missing file has no profiles.
+func (s *ProfileStore) Load() (map[string]Profile, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Profile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading profiles: %w", err)
+	}
+
+	profiles := make(map[string]Profile)
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("reading profiles %s: %w", s.Path, err)
+	}
+	return profiles, nil
+}
+
+// ErrProfileExists is returned by Save when the name is taken and overwrite was not requested.
+var ErrProfileExists = errors.New("profile already exists")
+
+// Save stores the named profile. An existing profile is only updated when overwrite is set, and then only the fields
+// profile sets replace it, so settings such as sandbox survive re-authorizing a seller. profile.RefreshToken is the
+// token itself; with a keyring it is sealed there and the file only records the reference.
+func (s *ProfileStore) Save(name string, profile Profile, overwrite bool) error {
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+	profiles, err := s.Load()
+	if err != nil {
+		return err
+	}
+	existing, exists := profiles[name]
+	if exists && !overwrite {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+
+	if s.Keyring != nil && !IsSecretReference(profile.RefreshToken) {
+		secretName := "profile." + name + ".refresh-token"
+		if err := s.Keyring.Set(secretName, profile.RefreshToken); err != nil {
+			return fmt.Errorf("storing refresh token: %w", err)
+		}
+		profile.RefreshToken = schemeKeyring + secretName
+	}
+	if exists {
+		profile = existing.merge(profile)
+	}
+	profiles[name] = profile
+
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return fmt.Errorf("creating profiles directory: %w", err)
+	}
+	// The file may hold refresh tokens, so it is private, and written then renamed like the keyring.
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing profiles: %w", err)
+	}
+	return os.Rename(tmp, s.Path)
+}
+
+// merge returns p with every field update sets replaced.
+func (p Profile) merge(update Profile) Profile {
+	for _, field := range []struct {
+		into *string
+		from string
+	}{
+		{&p.RefreshToken, update.RefreshToken},
+		{&p.SellingPartnerID, update.SellingPartnerID},
+		{&p.Endpoint, update.Endpoint},
+		{&p.AuthorizationExpires, update.AuthorizationExpires},
+		{&p.Sandbox, update.Sandbox},
+		{&p.AccountType, update.AccountType},
+	} {
+		if field.from != "" {
+			*field.into = field.from
+		}
+	}
+	if !update.AuthorizedAt.IsZero() {
+		p.AuthorizedAt = update.AuthorizedAt
+	}
+	return p
+}
+
+// applyProfile replaces settings with the fields of the profile SP_API_PROFILE selects. A profile named explicitly must
+// be in the profiles file; the default one may be missing, so a server that only onboards sellers still starts.
+func applyProfile(l *loader) error {
+	path := l.get("SP_API_PROFILES_FILE")
+	if path == "" {
+		return nil
+	}
+	profiles, err := (&ProfileStore{Path: path}).Load()
+	if err != nil {
+		return fmt.Errorf("%s: %w", l.where("SP_API_PROFILES_FILE"), err)
+	}
+	name := l.get("SP_API_PROFILE")
+	profile, ok := profiles[name]
+	if !ok {
+		if l.values["SP_API_PROFILE"].Source == SourceDefault {
+			return nil
+		}
+		return fmt.Errorf("%s: profile %q is not in %s", l.where("SP_API_PROFILE"), name, path)
+	}
+
+	for _, field := range profileFields {
+		if value := field.value(profile); value != "" {
+			l.values[field.key] = SettingValue{Key: field.key, Value: value, Source: SourceProfile, Location: fmt.Sprintf("%s: %s.%s", path, name, field.name)}
+		}
+	}
+	return nil
+}
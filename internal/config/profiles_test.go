@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfileReplacesCredentialSettings(t *testing.T) {
+	dir := t.TempDir()
+	keyringPath := filepath.Join(dir, "keyring.json")
+	profilesPath := filepath.Join(dir, "profiles.json")
+
+	store := &ProfileStore{Path: profilesPath, Keyring: &Keyring{Path: keyringPath, Passphrase: "correct horse"}}
+	if err := store.Save("acme-uk", Profile{
+		RefreshToken:         "Atzr|acme",
+		SellingPartnerID:     "A3ACME",
+		Endpoint:             "https://sellingpartnerapi-eu.amazon.com",
+		AuthorizationExpires: "2027-10-18",
+	}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	data, err := os.ReadFile(profilesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Atzr|acme") || !strings.Contains(string(data), "keyring://profile.acme-uk.refresh-token") {
+		t.Fatalf("the refresh token should be sealed in the keyring, got %s", data)
+	}
+
+	t.Setenv("SP_API_CLIENT_ID", "client")
+	t.Setenv("SP_API_CLIENT_SECRET", "secret")
+	t.Setenv("SP_API_REFRESH_TOKEN", "Atzr|default")
+	t.Setenv("SP_API_KEYRING_FILE", keyringPath)
+	t.Setenv("SP_API_KEYRING_PASSPHRASE", "correct horse")
+	t.Setenv("SP_API_PROFILES_FILE", profilesPath)
+	t.Setenv("SP_API_PROFILE", "acme-uk")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Credentials.RefreshToken != "Atzr|acme" || cfg.SellerID != "A3ACME" || cfg.SPAPIEndpoint != "https://sellingpartnerapi-eu.amazon.com" {
+		t.Fatalf("profile values should win, got %+v seller=%s endpoint=%s", cfg.Credentials, cfg.SellerID, cfg.SPAPIEndpoint)
+	}
+	if cfg.Health.AuthorizationExpiresAt.IsZero() {
+		t.Fatalf("the profile's authorization expiry should feed the watchdog")
+	}
+	if got := effective(cfg, "SP_API_REFRESH_TOKEN"); got.Source != SourceProfile || got.Location != profilesPath+": acme-uk.refreshToken" {
+		t.Fatalf("unexpected provenance %+v", got)
+	}
+
+	t.Setenv("SP_API_PROFILE", "")
+	cfg, err = Load()
+	if err != nil || cfg.Credentials.RefreshToken != "Atzr|default" {
+		t.Fatalf("a default profile missing from the file should leave the settings alone, got %q, %v", cfg.Credentials.RefreshToken, err)
+	}
+}
+
+func TestProfileMissingFromFile(t *testing.T) {
+	profilesPath := filepath.Join(t.TempDir(), "profiles.json")
+	if err := (&ProfileStore{Path: profilesPath}).Save("acme-uk", Profile{RefreshToken: "Atzr|acme"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Setenv("SP_API_CLIENT_ID", "client")
+	t.Setenv("SP_API_CLIENT_SECRET", "secret")
+	t.Setenv("SP_API_REFRESH_TOKEN", "Atzr|default")
+	t.Setenv("SP_API_PROFILES_FILE", profilesPath)
+	t.Setenv("SP_API_PROFILE", "acme-us")
+
+	_, err := Load()
+	if err == nil || !strings.Contains(err.Error(), `"acme-us"`) || !strings.Contains(err.Error(), profilesPath) {
+		t.Fatalf("expected an error naming the profile and the file, got %v", err)
+	}
+}
+
+func TestProfileSandboxSwitch(t *testing.T) {
+	profilesPath := filepath.Join(t.TempDir(), "profiles.json")
+	store := &ProfileStore{Path: profilesPath}
+	if err := store.Save("acme-test", Profile{RefreshToken: "Atzr|acme", Endpoint: "https://sellingpartnerapi-eu.amazon.com", Sandbox: "amazon"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save("ci", Profile{Sandbox: "local"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Setenv("SP_API_CLIENT_ID", "client")
+	t.Setenv("SP_API_CLIENT_SECRET", "secret")
+	t.Setenv("SP_API_PROFILES_FILE", profilesPath)
+	t.Setenv("SP_API_PROFILE", "acme-test")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Sandbox != SandboxAmazon || cfg.SPAPIEndpoint != "https://sandbox.sellingpartnerapi-eu.amazon.com" {
+		t.Fatalf("the profile should route to the EU sandbox, got %s %s", cfg.Sandbox, cfg.SPAPIEndpoint)
+	}
+
+	t.Setenv("SP_API_PROFILE", "ci")
+	if cfg, err = Load(); err != nil || cfg.Sandbox != SandboxLocal {
+		t.Fatalf("expected local sandbox mode, got %q, %v", cfg.Sandbox, err)
+	}
+
+	t.Setenv("SP_API_SANDBOX", "staging")
+	t.Setenv("SP_API_PROFILE", "")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "SP_API_SANDBOX") {
+		t.Fatalf("expected an unsupported sandbox mode error, got %v", err)
+	}
+}
+
+func TestProfileAccountType(t *testing.T) {
+	profilesPath := filepath.Join(t.TempDir(), "profiles.json")
+	store := &ProfileStore{Path: profilesPath}
+	if err := store.Save("acme-vendor", Profile{RefreshToken: "Atzr|vendor", AccountType: "vendor"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save("acme-seller", Profile{RefreshToken: "Atzr|seller"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Setenv("SP_API_CLIENT_ID", "client")
+	t.Setenv("SP_API_CLIENT_SECRET", "secret")
+	t.Setenv("SP_API_ACCOUNT_TYPE", "seller")
+	t.Setenv("SP_API_PROFILES_FILE", profilesPath)
+	t.Setenv("SP_API_PROFILE", "acme-vendor")
+
+	cfg, err := Load()
+	if err != nil || cfg.AccountType != AccountTypeVendor {
+		t.Fatalf("the vendor profile should select the vendor tools, got %q, %v", cfg.AccountType, err)
+	}
+
+	t.Setenv("SP_API_PROFILE", "acme-seller")
+	if cfg, err = Load(); err != nil || cfg.AccountType != AccountTypeSeller {
+		t.Fatalf("a profile without accountType should keep SP_API_ACCOUNT_TYPE, got %q, %v", cfg.AccountType, err)
+	}
+}
+
+func TestProfileSaveKeepsExistingProfiles(t *testing.T) {
+	store := &ProfileStore{Path: filepath.Join(t.TempDir(), "profiles.json")}
+	if err := store.Save("acme-test", Profile{RefreshToken: "Atzr|old", SellingPartnerID: "A3ACME", Sandbox: "amazon"}, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if err := store.Save("acme-test", Profile{RefreshToken: "Atzr|other"}, false); !errors.Is(err, ErrProfileExists) {
+		t.Fatalf("expected ErrProfileExists, got %v", err)
+	}
+	if err := store.Save("acme-test", Profile{RefreshToken: "Atzr|new", Endpoint: "https://sellingpartnerapi-eu.amazon.com"}, true); err != nil {
+		t.Fatalf("Save with overwrite: %v", err)
+	}
+
+	profiles, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Profile{RefreshToken: "Atzr|new", SellingPartnerID: "A3ACME", Endpoint: "https://sellingpartnerapi-eu.amazon.com", Sandbox: "amazon"}
+	if got := profiles["acme-test"]; got != want {
+		t.Fatalf("overwriting should merge the new fields into the profile, got %+v", got)
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Secret reference schemes. A secret setting whose value starts with one of these is resolved through the matching
+// provider; any other value is used as written.
+const (
+	schemeFile    = "file://"
+	schemeEnv     = "env://"
+	schemeExec    = "exec://"
+	schemeKeyring = "keyring://"
+)
+
+// secretCommandTimeout bounds helpers such as pass or op, which may wait on an agent.
+const secretCommandTimeout = 30 * time.Second
+
+// SecretProvider resolves the part of a reference after its scheme.
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretResolver maps reference schemes to providers.
+type SecretResolver struct {
+	providers map[string]SecretProvider
+}
+
+// NewSecretResolver supports file://, env:// and exec:// references, plus keyring:// when keyringPath is set.
+func NewSecretResolver(keyringPath, keyringPassphrase string) *SecretResolver {
+	providers := map[string]SecretProvider{
+		schemeFile: fileSecrets{},
+		schemeEnv:  envSecrets{},
+		schemeExec: execSecrets{timeout: secretCommandTimeout},
+	}
+	if keyringPath != "" {
+		providers[schemeKeyring] = &Keyring{Path: keyringPath, Passphrase: keyringPassphrase}
+	}
+	return &SecretResolver{providers: providers}
+}
+
+// IsSecretReference reports whether value names a secret instead of holding it.
+func IsSecretReference(value string) bool {
+	for _, scheme := range []string{schemeFile, schemeEnv, schemeExec, schemeKeyring} {
+		if strings.HasPrefix(value, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the secret value references point at and any other value unchanged. Providers are asked again on
+// every call, so a rotated secret is picked up by the next resolution.
+func (r *SecretResolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsSecretReference(value) {
+		return value, nil
+	}
+	scheme, ref, _ := strings.Cut(value, "://")
+	provider, ok := r.providers[scheme+"://"]
+	if !ok {
+		return "", fmt.Errorf("%s:// references need SP_API_KEYRING_FILE", scheme)
+	}
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%s resolved to an empty value", value)
+	}
+	return secret, nil
+}
+
+// fileSecrets reads file://path, the way container orchestrators mount secrets. Trailing newlines are dropped.
+type fileSecrets struct{}
+
+func (fileSecrets) Resolve(_ context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("reading secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// envSecrets reads env://NAME, for platforms that inject secrets under their own variable names.
+type envSecrets struct{}
+
+func (envSecrets) Resolve(_ context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// execSecrets runs exec://command args, such as exec://pass show amazon/refresh-token, and uses its standard output.
+// The command is split on spaces and run without a shell.
+type execSecrets struct {
+	timeout time.Duration
+}
+
+func (p execSecrets) Resolve(ctx context.Context, ref string) (string, error) {
+	fields := strings.Fields(ref)
+	if len(fields) == 0 {
+		return "", errors.New("exec:// reference has no command")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		// Helpers report problems on stderr; the secret itself would be on stdout, which is never echoed.
+		return "", fmt.Errorf("running %s: %w: %s", fields[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// keyringIterations follows the current OWASP recommendation for PBKDF2-HMAC-SHA256.
+const keyringIterations = 600_000
+
+// keyringFile is the on-disk form: named secrets as JSON, sealed with AES-256-GCM under a passphrase-derived key.
+type keyringFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyring is an encrypted local file of named secrets, referenced as keyring://name.
+type Keyring struct {
+	Path       string
+	Passphrase string
+}
+
+// Resolve decrypts the keyring and returns the named secret. The file is read on every call, so secrets updated with
+// Set are seen without a restart.
+func (k *Keyring) Resolve(_ context.Context, name string) (string, error) {
+	secrets, err := k.Load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("keyring %s has no secret %q", k.Path, name)
+	}
+	return secret, nil
+}
+
+// Load decrypts every secret in the keyring. A missing file is an empty keyring.
+func (k *Keyring) Load() (map[string]string, error) {
+	if k.Passphrase == "" {
+		return nil, errors.New("SP_API_KEYRING_PASSPHRASE is required to open the keyring")
+	}
+
+	data, err := os.ReadFile(k.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring: %w", err)
+	}
+
+	var sealed keyringFile
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("reading keyring %s: %w", k.Path, err)
+	}
+	if sealed.Version != 1 {
+		return nil, fmt.Errorf("keyring %s has unsupported version %d", k.Path, sealed.Version)
+	}
+
+	aead, err := keyringCipher(k.Passphrase, sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("keyring %s could not be decrypted; check SP_API_KEYRING_PASSPHRASE", k.Path)
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("reading keyring %s: %w", k.Path, err)
+	}
+	return secrets, nil
+}
+
+// Set stores or replaces one secret, re-encrypting the keyring with a fresh salt and nonce. An empty value removes it.
+func (k *Keyring) Set(name, value string) error {
+	secrets, err := k.Load()
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(secrets, name)
+	} else {
+		secrets[name] = value
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := keyringCipher(k.Passphrase, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(keyringFile{Version: 1, Salt: salt, Nonce: nonce, Ciphertext: aead.Seal(nil, nonce, plaintext, nil)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(k.Path), 0o700); err != nil {
+		return fmt.Errorf("creating keyring directory: %w", err)
+	}
+	// Write then rename, so a server reading the keyring never sees half a file.
+	tmp := k.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing keyring: %w", err)
+	}
+	return os.Rename(tmp, k.Path)
+}
+
+func keyringCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, keyringIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
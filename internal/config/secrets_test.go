@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestHelperSecretCommand stands in for helpers such as pass or op when exec:// references run the test binary.
+func TestHelperSecretCommand(t *testing.T) {
+	if os.Getenv("SECRET_HELPER") != "1" {
+		return
+	}
+	fmt.Println("from-helper")
+	os.Exit(0)
+}
+
+func TestSecretResolverProviders(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "client-secret")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("INJECTED_SECRET", "from-env")
+	t.Setenv("SECRET_HELPER", "1")
+
+	keyring := &Keyring{Path: filepath.Join(dir, "keyring.json"), Passphrase: "correct horse"}
+	if err := keyring.Set("refresh-token", "from-keyring"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	resolver := NewSecretResolver(keyring.Path, keyring.Passphrase)
+	for value, want := range map[string]string{
+		"literal":                 "literal",
+		"file://" + secretFile:    "from-file",
+		"env://INJECTED_SECRET":   "from-env",
+		"keyring://refresh-token": "from-keyring",
+		"exec://" + os.Args[0] + " -test.run=^TestHelperSecretCommand$": "from-helper",
+	} {
+		got, err := resolver.Resolve(context.Background(), value)
+		if err != nil || got != want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := resolver.Resolve(context.Background(), "env://MISSING_SECRET"); err == nil {
+		t.Errorf("expected an error for an unset variable")
+	}
+	if _, err := NewSecretResolver("", "").Resolve(context.Background(), "keyring://refresh-token"); err == nil || !strings.Contains(err.Error(), "SP_API_KEYRING_FILE") {
+		t.Errorf("expected keyring references to need a keyring file, got %v", err)
+	}
+	wrong := &Keyring{Path: keyring.Path, Passphrase: "wrong"}
+	if _, err := wrong.Load(); err == nil || !strings.Contains(err.Error(), "could not be decrypted") {
+		t.Errorf("expected a decryption error, got %v", err)
+	}
+}
+
+func TestResolveCredentialsPicksUpRotation(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "refresh-token")
+	if err := os.WriteFile(secretFile, []byte("Atzr|first"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SP_API_CLIENT_ID", "client")
+	t.Setenv("SP_API_CLIENT_SECRET", "secret")
+	t.Setenv("SP_API_REFRESH_TOKEN", "file://"+secretFile)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Credentials.RefreshToken != "Atzr|first" {
+		t.Fatalf("expected the file contents, got %q", cfg.Credentials.RefreshToken)
+	}
+	if got := effective(cfg, "SP_API_REFRESH_TOKEN").Value; got != "file://"+secretFile {
+		t.Fatalf("references should be printed as written, got %q", got)
+	}
+
+	if err := os.WriteFile(secretFile, []byte("Atzr|second"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := cfg.ResolveCredentials(context.Background())
+	if err != nil || creds.RefreshToken != "Atzr|second" || creds.ClientID != "client" {
+		t.Fatalf("expected the rotated token, got %+v, %v", creds, err)
+	}
+}
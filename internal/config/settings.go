@@ -0,0 +1,387 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+)
+
+// setting is one configuration key. Keys are the environment variable names, and config files use the same names, so a
+// value can move between the environment, a file and the command line unchanged.
+type setting struct {
+	key      string
+	fallback string
+	// secret keeps the value out of --print-config and off the command line, where it would show up in ps. Secret
+	// settings may also hold a reference such as file:///run/secrets/refresh-token; see SecretResolver.
+	secret bool
+	// boolean flags may be given without a value, as in --dry-run.
+	boolean bool
+	usage   string
+}
+
+var settings = []setting{
+	{key: "MCP_SERVER_NAME", fallback: defaultServerName, usage: "name shown to MCP clients"},
+	{key: "MCP_SERVER_VERSION", fallback: defaultServerVersion, usage: "version reported to MCP clients"},
+	{key: "MCP_SERVER_INSTRUCTIONS", fallback: defaultInstructions, usage: "instructions shared with the assistant"},
+	{key: "MCP_TRANSPORT", fallback: defaultTransport, usage: "stdio, sse or streamablehttp"},
+	{key: "HOST", fallback: defaultHost, usage: "host name used in the advertised URL"},
+	{key: "PORT", fallback: defaultPort, usage: "port for the sse and streamablehttp transports"},
+	{key: "SP_API_ENDPOINT", fallback: defaultEndpoint, usage: "SP-API regional endpoint"},
+	{key: "SP_API_SANDBOX", fallback: string(SandboxOff), usage: "off, amazon (Amazon's sandbox endpoint) or local (bundled emulator, no credentials needed)"},
+	{key: "SP_API_CASSETTE", usage: "file to record SP-API exchanges to or replay them from"},
+	{key: "SP_API_CASSETTE_MODE", fallback: string(CassetteReplay), usage: "replay (serve SP-API calls from SP_API_CASSETTE) or record (write redacted exchanges to it)"},
+	{key: "SP_API_CLIENT_ID", usage: "LWA client ID, or a secret reference"},
+	{key: "SP_API_CLIENT_SECRET", secret: true},
+	{key: "SP_API_REFRESH_TOKEN", secret: true},
+	{key: "SP_API_HEALTH_INTERVAL", fallback: "15m", usage: "how often the credential watchdog tests the token exchange; 0 disables it"},
+	{key: "SP_API_AUTHORIZATION_EXPIRES", usage: "date the seller's authorization of the app expires, for early warnings"},
+	{key: "SP_API_AUTHORIZATION_WARNING", fallback: "720h", usage: "how long before the authorization expires to start warning"},
+	{key: "SP_API_ACCOUNT_TYPE", fallback: string(AccountTypeSeller), usage: "seller or vendor"},
+	{key: "SP_API_SELLER_ID", usage: "merchant token used as the default sellerId"},
+	{key: "SP_API_PROFILE", fallback: defaultProfile, usage: "seller profile name granted by access policies"},
+	{key: "SP_API_PROFILES_FILE", usage: "JSON file of credential profiles; the one named by SP_API_PROFILE overrides the credential settings"},
+	{key: "SP_API_APPLICATION_ID", usage: "SP-API application ID (amzn1.sp.solution...) used for seller authorization links"},
+	{key: "SP_API_OAUTH_REDIRECT_URL", usage: "redirect URI registered for the app; defaults to the server's /oauth/callback"},
+	{key: "SP_API_ENABLE_WRITES", fallback: "false", boolean: true, usage: "allow tools that change seller data"},
+	{key: "SP_API_CONFIRM_WRITES", fallback: "true", boolean: true, usage: "ask the user to approve destructive tool calls"},
+	{key: "SP_API_DRY_RUN", fallback: "false", boolean: true, usage: "return write requests instead of sending them"},
+	{key: "SP_API_ENABLE_DOMAINS", usage: "comma-separated tool domains to expose"},
+	{key: "SP_API_DISABLE_DOMAINS", usage: "comma-separated tool domains to hide"},
+	{key: "SP_API_STORE_PATH", usage: "SQLite file for the local store"},
+	{key: "SP_API_UPLOAD_DIR", usage: "directory uploads.uploadFile may read files from"},
+	{key: "SP_API_CACHE", fallback: string(CacheMemory), usage: "response cache backend: memory, disk or off"},
+	{key: "SP_API_CACHE_DIR", usage: "directory for the disk cache"},
+	{key: "SP_API_CACHE_TTLS", usage: "per-operation TTL overrides such as catalog=24h"},
+	{key: "SP_API_PII_POLICY", fallback: string(redact.ActionMask), usage: "buyer PII action: mask, hash, drop or off"},
+	{key: "SP_API_PII_RULES", usage: "per-category PII actions such as email=hash"},
+	{key: "SP_API_PII_HASH_KEY", secret: true},
+	{key: "SP_API_AUDIT_LOG", usage: "audit log file, or stderr"},
+	{key: "SP_API_AUDIT_MAX_SIZE_MB", fallback: "100", usage: "rotate the audit log at this size"},
+	{key: "SP_API_AUDIT_MAX_FILES", fallback: "5", usage: "rotated audit logs to keep"},
+	{key: "MCP_AUTH_TOKENS", secret: true},
+	{key: "SP_API_KEYRING_FILE", usage: "encrypted keyring file for keyring:// secret references"},
+	{key: "SP_API_KEYRING_PASSPHRASE", secret: true},
+	{key: "SP_API_SECRET_REFRESH", fallback: "5m", usage: "how often secret references are resolved again"},
+	{key: "MCP_AUTH_OIDC_ISSUER", usage: "OIDC issuer URL for JWT bearer tokens"},
+	{key: "MCP_AUTH_OIDC_AUDIENCE", usage: "audience JWTs must be issued for"},
+	{key: "MCP_AUTH_OIDC_CLAIM", fallback: "sub", usage: "JWT claim used as the principal name"},
+	{key: "MCP_AUTH_POLICY", usage: "JSON access policy file"},
+	{key: "VERBOSE", fallback: "false", boolean: true, usage: "log SP-API requests and responses"},
+}
+
+func lookupSetting(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// flagName derives a command-line name from a key: SP_API_DRY_RUN becomes dry-run and MCP_AUTH_POLICY auth-policy.
+func flagName(key string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(key, "SP_API_"), "MCP_")
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// Source says which layer a setting's value came from. Later layers win: defaults, then the config file, then the
+// environment, then flags, then the selected credential profile.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Options tells LoadFrom where to look besides the environment.
+type Options struct {
+	// File is a JSON or YAML config file; empty skips it.
+	File string
+	// Server picks the mcpServers entry of a client-style config file; empty picks the only entry.
+	Server string
+	// Flags holds values given on the command line, keyed by setting key.
+	Flags map[string]string
+}
+
+// SettingValue is one resolved setting as --print-config shows it.
+type SettingValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// Location is where the value was set, such as config.json: mcpServers.sp-api.env.PORT.
+	Location string `json:"location,omitempty"`
+}
+
+// loader resolves settings across the layers and names the place each value came from in validation errors.
+type loader struct {
+	values map[string]SettingValue
+}
+
+func newLoader(opts Options) (*loader, error) {
+	l := &loader{values: make(map[string]SettingValue, len(settings))}
+	for _, s := range settings {
+		l.values[s.key] = SettingValue{Key: s.key, Value: s.fallback, Source: SourceDefault}
+	}
+
+	if opts.File != "" {
+		fileValues, err := readConfigFile(opts.File, opts.Server)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			l.values[key] = value
+		}
+	} else if opts.Server != "" {
+		return nil, fmt.Errorf("--server %q needs --config", opts.Server)
+	}
+
+	for _, s := range settings {
+		if value := strings.TrimSpace(os.Getenv(s.key)); value != "" {
+			l.values[s.key] = SettingValue{Key: s.key, Value: value, Source: SourceEnv, Location: s.key}
+		}
+	}
+
+	for key, value := range opts.Flags {
+		if _, ok := lookupSetting(key); !ok {
+			return nil, fmt.Errorf("unknown setting %s", key)
+		}
+		l.values[key] = SettingValue{Key: key, Value: strings.TrimSpace(value), Source: SourceFlag, Location: "--" + flagName(key)}
+	}
+
+	return l, nil
+}
+
+func (l *loader) get(key string) string {
+	return l.values[key].Value
+}
+
+// where names the place key was set, so errors point at the offending file key, variable or flag.
+func (l *loader) where(key string) string {
+	if location := l.values[key].Location; location != "" {
+		return location
+	}
+	return key
+}
+
+// redacted lists the resolved settings in table order with secrets replaced.
+func (l *loader) redacted() []SettingValue {
+	values := make([]SettingValue, 0, len(settings))
+	for _, s := range settings {
+		value := l.values[s.key]
+		// References name where a secret lives, not the secret, so they are shown as written.
+		if s.secret && value.Value != "" && !IsSecretReference(value.Value) {
+			value.Value = redactSecret(s.key, value.Value)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// redactSecret hides a secret while keeping its shape: token lists keep their principal names.
+func redactSecret(key, value string) string {
+	if key != "MCP_AUTH_TOKENS" {
+		return "***"
+	}
+	var pairs []string
+	for _, pair := range strings.Split(value, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if name != "" {
+			pairs = append(pairs, strings.TrimSpace(name)+"=***")
+		}
+	}
+	return strings.Join(pairs, ",")
+}
+
+// readConfigFile reads settings from a JSON or YAML file. The file is either a flat object of settings or an MCP client
+// configuration whose mcpServers.<server>.env holds them; the client-only keys (command, args, cwd) are ignored.
+func readConfigFile(path, server string) (map[string]SettingValue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var document any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		if err := json.Unmarshal(data, &document); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				line := 1 + strings.Count(string(data[:syntaxErr.Offset]), "\n")
+				return nil, fmt.Errorf("%s: line %d: %w", path, line, err)
+			}
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	root, ok := document.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: the top level must be an object", path)
+	}
+
+	prefix := ""
+	values := root
+	if servers, ok := root["mcpServers"]; ok {
+		entries, ok := servers.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: mcpServers must be an object", path)
+		}
+		if server == "" {
+			if len(entries) != 1 {
+				return nil, fmt.Errorf("%s: mcpServers has %d entries; choose one with --server", path, len(entries))
+			}
+			for name := range entries {
+				server = name
+			}
+		}
+		entry, ok := entries[server].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: mcpServers has no server %q", path, server)
+		}
+		prefix = "mcpServers." + server + ".env."
+		values = map[string]any{}
+		if env, ok := entry["env"]; ok {
+			if values, ok = env.(map[string]any); !ok {
+				return nil, fmt.Errorf("%s: mcpServers.%s.env must be an object", path, server)
+			}
+		}
+	} else if server != "" {
+		return nil, fmt.Errorf("%s: --server %q needs an mcpServers object", path, server)
+	}
+
+	resolved := make(map[string]SettingValue, len(values))
+	for key, raw := range values {
+		location := fmt.Sprintf("%s: %s%s", path, prefix, key)
+		if _, ok := lookupSetting(key); !ok {
+			return nil, fmt.Errorf("%s is not a known setting", location)
+		}
+		value, err := settingString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			resolved[key] = SettingValue{Key: key, Value: value, Source: SourceFile, Location: location}
+		}
+	}
+	return resolved, nil
+}
+
+// settingString turns a file value into the string form the environment would carry. Lists join with commas and
+// objects become name=value pairs, so SP_API_PII_RULES can be written as {"email": "hash"}.
+func settingString(raw any) (string, error) {
+	switch value := raw.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(value), nil
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			part, err := scalarString(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ","), nil
+	case map[string]any:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, 0, len(value))
+		for _, name := range names {
+			part, err := scalarString(value[name])
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", name, err)
+			}
+			parts = append(parts, name+"="+part)
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", raw)
+	}
+}
+
+func scalarString(raw any) (string, error) {
+	switch value := raw.(type) {
+	case string:
+		return value, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("expected a string, number or boolean, got %T", raw)
+	}
+}
+
+// CommandLine is the parsed command line.
+type CommandLine struct {
+	Options
+	// PrintConfig asks for the effective configuration, with secrets redacted, instead of starting the server.
+	PrintConfig bool
+}
+
+// ParseFlags reads --config, --server, --print-config and one flag per non-secret setting, such as --transport or
+// --dry-run. Secrets are left to the environment and config files.
+func ParseFlags(name string, args []string, output io.Writer) (CommandLine, error) {
+	var cmd CommandLine
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&cmd.File, "config", "", "JSON or YAML config file; settings use the environment variable names")
+	fs.StringVar(&cmd.Server, "server", "", "mcpServers entry to read from a client-style config file")
+	fs.BoolVar(&cmd.PrintConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
+
+	values := make(map[string]*flagValue)
+	for _, s := range settings {
+		if s.secret {
+			continue
+		}
+		value := &flagValue{boolean: s.boolean}
+		values[flagName(s.key)] = value
+		fs.Var(value, flagName(s.key), fmt.Sprintf("%s (%s)", s.usage, s.key))
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return CommandLine{}, err
+	}
+	if fs.NArg() > 0 {
+		return CommandLine{}, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	cmd.Flags = make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if !s.secret && flagName(s.key) == f.Name {
+				cmd.Flags[s.key] = values[f.Name].value
+			}
+		}
+	})
+	return cmd, nil
+}
+
+type flagValue struct {
+	value   string
+	boolean bool
+}
+
+func (v *flagValue) String() string       { return v.value }
+func (v *flagValue) Set(raw string) error { v.value = raw; return nil }
+func (v *flagValue) IsBoolFlag() bool     { return v.boolean }
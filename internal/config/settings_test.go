@@ -0,0 +1,87 @@
+package config
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func effective(cfg Config, key string) SettingValue {
+	for _, value := range cfg.Effective() {
+		if value.Key == key {
+			return value
+		}
+	}
+	return SettingValue{}
+}
+
+func TestLoadFromLayersFileEnvAndFlags(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{
+  "mcpServers": {
+    "sp-api": {
+      "command": "go",
+      "args": ["run", "./cmd/server"],
+      "env": {"PORT": 9001, "SP_API_PROFILE": "eu", "SP_API_DRY_RUN": true, "SP_API_CLIENT_ID": "id", "SP_API_CLIENT_SECRET": "hunter2", "SP_API_REFRESH_TOKEN": "Atzr|x"}
+    }
+  }
+}`)
+	t.Setenv("PORT", "9002")
+
+	cfg, err := LoadFrom(Options{File: path, Server: "sp-api", Flags: map[string]string{"SP_API_PROFILE": "us"}})
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if cfg.Port != "9002" || cfg.Profile != "us" || !cfg.DryRun || cfg.Transport != TransportSTDIO {
+		t.Fatalf("unexpected layering: port=%s profile=%s dryRun=%t transport=%s", cfg.Port, cfg.Profile, cfg.DryRun, cfg.Transport)
+	}
+	if got := effective(cfg, "SP_API_DRY_RUN"); got.Source != SourceFile || got.Location != path+": mcpServers.sp-api.env.SP_API_DRY_RUN" {
+		t.Fatalf("unexpected dry-run source: %+v", got)
+	}
+	if got := effective(cfg, "SP_API_CLIENT_SECRET"); got.Value != "***" {
+		t.Fatalf("secrets must be redacted, got %q", got.Value)
+	}
+}
+
+func TestLoadFromErrorsNameTheOffendingKey(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "SP_API_CACHE: sometimes\n")
+	if _, err := LoadFrom(Options{File: path}); err == nil || !strings.Contains(err.Error(), path+": SP_API_CACHE") {
+		t.Fatalf("expected the file key in the error, got %v", err)
+	}
+
+	path = writeConfigFile(t, "config.json", `{"mcpServers": {"sp-api": {"env": {"SP_API_TYPO": "1"}}}}`)
+	if _, err := LoadFrom(Options{File: path}); err == nil || !strings.Contains(err.Error(), "mcpServers.sp-api.env.SP_API_TYPO is not a known setting") {
+		t.Fatalf("expected an unknown setting error, got %v", err)
+	}
+
+	if _, err := LoadFrom(Options{Flags: map[string]string{"SP_API_AUDIT_MAX_FILES": "0"}}); err == nil || !strings.Contains(err.Error(), "--audit-max-files") {
+		t.Fatalf("expected the flag name in the error, got %v", err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	cmd, err := ParseFlags("server", []string{"--config", "c.json", "--server", "sp-api", "--dry-run", "--transport=sse", "--print-config"}, io.Discard)
+	if err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	if cmd.File != "c.json" || cmd.Server != "sp-api" || !cmd.PrintConfig {
+		t.Fatalf("unexpected command line: %+v", cmd)
+	}
+	if cmd.Flags["SP_API_DRY_RUN"] != "true" || cmd.Flags["MCP_TRANSPORT"] != "sse" || len(cmd.Flags) != 2 {
+		t.Fatalf("unexpected flag values: %v", cmd.Flags)
+	}
+
+	if _, err := ParseFlags("server", []string{"--client-secret", "x"}, io.Discard); err == nil {
+		t.Fatalf("secrets must not be accepted as flags")
+	}
+}
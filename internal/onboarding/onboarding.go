@@ -0,0 +1,326 @@
+// Package onboarding runs the Selling Partner authorization flow: it builds Seller Central consent links, receives the
+// redirect carrying spapi_oauth_code and stores the seller's refresh token as a credential profile.
+package onboarding
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// CallbackPath is where the HTTP transports receive the seller's browser after consent.
+const CallbackPath = "/oauth/callback"
+
+// consentTTL bounds how long a consent link stays usable, so stale states cannot be replayed.
+const consentTTL = 30 * time.Minute
+
+// authorizationLifetime is how long a seller's authorization lasts before they have to renew it.
+const authorizationLifetime = 365 * 24 * time.Hour
+
+// Status is where an authorization stands.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired"
+)
+
+// Config carries what the flow needs from the server configuration.
+type Config struct {
+	// ApplicationID is the SP-API application the seller authorizes.
+	ApplicationID string
+	// RedirectURL must match a redirect URI registered for the application.
+	RedirectURL string
+	// ClientID and ClientSecret are the application's LWA credentials, used to exchange the authorization code.
+	ClientID     string
+	ClientSecret string
+	// Vendor sends sellers to Vendor Central instead of Seller Central.
+	Vendor bool
+	// Profiles receives the new refresh tokens.
+	Profiles *config.ProfileStore
+	// Policy, when set, limits callers to the profile names their rule admits.
+	Policy *auth.Policy
+	// LWAEndpoint and HTTPClient override the token exchange, for tests.
+	LWAEndpoint string
+	HTTPClient  *http.Client
+}
+
+// Authorization is one consent request and its outcome.
+type Authorization struct {
+	State            string    `json:"state"`
+	Profile          string    `json:"profile"`
+	MarketplaceID    string    `json:"marketplaceId"`
+	ConsentURL       string    `json:"consentUrl"`
+	Status           Status    `json:"status"`
+	SellingPartnerID string    `json:"sellingPartnerId,omitempty"`
+	Error            string    `json:"error,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	Overwrite        bool      `json:"overwrite,omitempty"`
+
+	endpoint string
+	// claimed is set once the callback starts exchanging the code, so a replayed redirect is turned away.
+	claimed bool
+}
+
+// Flow tracks consent requests until the seller comes back through the callback.
+type Flow struct {
+	cfg Config
+	now func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*Authorization
+}
+
+// New checks that cfg can complete an authorization.
+func New(cfg Config) (*Flow, error) {
+	switch {
+	case cfg.ApplicationID == "":
+		return nil, errors.New("SP_API_APPLICATION_ID is required for seller authorization")
+	case cfg.ClientID == "" || cfg.ClientSecret == "":
+		return nil, errors.New("SP_API_CLIENT_ID and SP_API_CLIENT_SECRET are required to exchange authorization codes")
+	case cfg.Profiles == nil:
+		return nil, errors.New("SP_API_PROFILES_FILE is required to store authorized sellers")
+	case cfg.RedirectURL == "":
+		return nil, errors.New("a redirect URL is required for seller authorization")
+	}
+	return &Flow{cfg: cfg, now: time.Now, pending: make(map[string]*Authorization)}, nil
+}
+
+// Request describes the profile a consent link is for.
+type Request struct {
+	Profile       string
+	MarketplaceID string
+	// Draft adds version=beta, which apps that are not yet published need.
+	Draft bool
+	// Overwrite allows re-authorizing an existing profile; its other fields, such as sandbox, are kept.
+	Overwrite bool
+}
+
+// Begin creates a consent link for the profile in request. With a policy, the caller in ctx must be admitted to the
+// profile name, so a client cannot plant credentials under a profile another client uses.
+func (f *Flow) Begin(ctx context.Context, request Request) (Authorization, error) {
+	profile := strings.TrimSpace(request.Profile)
+	if profile == "" {
+		return Authorization{}, errors.New("profile is required")
+	}
+	if f.cfg.Policy != nil {
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok || !f.cfg.Policy.AllowsProfile(principal.Name, profile) {
+			return Authorization{}, fmt.Errorf("this client may not authorize profile %q", profile)
+		}
+	}
+	if !request.Overwrite {
+		profiles, err := f.cfg.Profiles.Load()
+		if err != nil {
+			return Authorization{}, err
+		}
+		if _, exists := profiles[profile]; exists {
+			return Authorization{}, fmt.Errorf("profile %q already exists; pass overwrite to re-authorize it", profile)
+		}
+	}
+
+	marketplaceID := request.MarketplaceID
+	if marketplaceID == "" {
+		marketplaceID = defaultMarketplace
+	}
+	market, ok := marketplaces[marketplaceID]
+	if !ok {
+		return Authorization{}, fmt.Errorf("unknown marketplaceId %q", marketplaceID)
+	}
+
+	state, err := newState()
+	if err != nil {
+		return Authorization{}, err
+	}
+
+	host := market.sellerCentral
+	if f.cfg.Vendor {
+		host = strings.Replace(host, "sellercentral", "vendorcentral", 1)
+	}
+	query := url.Values{
+		"application_id": {f.cfg.ApplicationID},
+		"state":          {state},
+		"redirect_uri":   {f.cfg.RedirectURL},
+	}
+	if request.Draft {
+		query.Set("version", "beta")
+	}
+
+	now := f.now()
+	authorization := &Authorization{
+		State:         state,
+		Profile:       profile,
+		MarketplaceID: marketplaceID,
+		ConsentURL:    "https://" + host + "/apps/authorize/consent?" + query.Encode(),
+		Status:        StatusPending,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(consentTTL),
+		Overwrite:     request.Overwrite,
+		endpoint:      market.endpoint,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prune(now)
+	f.pending[state] = authorization
+	return *authorization, nil
+}
+
+// Lookup returns the authorization started with state.
+func (f *Flow) Lookup(state string) (Authorization, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorization, ok := f.pending[state]
+	if !ok {
+		return Authorization{}, false
+	}
+	if authorization.Status == StatusPending && f.now().After(authorization.ExpiresAt) {
+		authorization.Status = StatusExpired
+	}
+	return *authorization, true
+}
+
+// ServeHTTP handles the redirect from Seller Central. It is reached by the seller's browser, not an MCP client, so it
+// sits outside client authentication; the single-use state ties it to a consent link this server issued.
+func (f *Flow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	f.mu.Lock()
+	authorization, ok := f.pending[query.Get("state")]
+	if ok && authorization.Status == StatusPending && f.now().After(authorization.ExpiresAt) {
+		authorization.Status = StatusExpired
+	}
+	if !ok || authorization.Status != StatusPending || authorization.claimed {
+		f.mu.Unlock()
+		writePage(w, http.StatusBadRequest, "This authorization link is unknown, expired or already used. Ask for a new one.")
+		return
+	}
+	authorization.claimed = true
+	profile, endpoint, overwrite := authorization.Profile, authorization.endpoint, authorization.Overwrite
+	f.mu.Unlock()
+
+	sellingPartnerID := query.Get("selling_partner_id")
+	err := f.complete(r, profile, endpoint, overwrite, sellingPartnerID, query.Get("spapi_oauth_code"))
+
+	f.mu.Lock()
+	authorization.SellingPartnerID = sellingPartnerID
+	if err != nil {
+		authorization.Status, authorization.Error = StatusFailed, err.Error()
+	} else {
+		authorization.Status = StatusCompleted
+	}
+	f.mu.Unlock()
+
+	if err != nil {
+		log.Printf("[ERROR] onboarding: authorizing profile %s failed: %v", profile, err)
+		writePage(w, http.StatusBadGateway, "Authorization failed: "+err.Error())
+		return
+	}
+	log.Printf("[INFO] onboarding: stored profile %s for selling partner %s", profile, sellingPartnerID)
+	writePage(w, http.StatusOK, fmt.Sprintf("Authorization complete. Profile %q is ready; you can close this window.", profile))
+}
+
+// complete exchanges code and saves the profile. Save checks again for an existing name, since another authorization
+// may have created it while the seller was on the consent page.
+func (f *Flow) complete(r *http.Request, profile, endpoint string, overwrite bool, sellingPartnerID, code string) error {
+	if code == "" {
+		return errors.New("the redirect carried no spapi_oauth_code")
+	}
+	token, err := spapi.ExchangeAuthorizationCode(r.Context(), f.cfg.HTTPClient, f.cfg.LWAEndpoint, f.cfg.ClientID, f.cfg.ClientSecret, code)
+	if err != nil {
+		return err
+	}
+
+	accountType := config.AccountTypeSeller
+	if f.cfg.Vendor {
+		accountType = config.AccountTypeVendor
+	}
+
+	now := f.now()
+	return f.cfg.Profiles.Save(profile, config.Profile{
+		RefreshToken:         token.RefreshToken,
+		SellingPartnerID:     sellingPartnerID,
+		Endpoint:             endpoint,
+		AuthorizationExpires: now.Add(authorizationLifetime).Format(time.DateOnly),
+		AuthorizedAt:         now.UTC(),
+		AccountType:          string(accountType),
+	}, overwrite)
+}
+
+// prune forgets authorizations a day after their link expired; finished ones stay around for Lookup until then.
+func (f *Flow) prune(now time.Time) {
+	for state, authorization := range f.pending {
+		if now.Sub(authorization.ExpiresAt) > 24*time.Hour {
+			delete(f.pending, state)
+		}
+	}
+}
+
+func newState() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func writePage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<!doctype html><title>Selling Partner authorization</title><p>%s</p>\n", html.EscapeString(message))
+}
+
+// marketplace is where a marketplace's sellers sign in and which regional endpoint serves them.
+type marketplace struct {
+	sellerCentral string
+	endpoint      string
+}
+
+const (
+	defaultMarketplace = "ATVPDKIKX0DER"
+
+	endpointNA = "https://sellingpartnerapi-na.amazon.com"
+	endpointEU = "https://sellingpartnerapi-eu.amazon.com"
+	endpointFE = "https://sellingpartnerapi-fe.amazon.com"
+)
+
+var marketplaces = map[string]marketplace{
+	"ATVPDKIKX0DER":  {"sellercentral.amazon.com", endpointNA},
+	"A2EUQ1WTGCTBG2": {"sellercentral.amazon.ca", endpointNA},
+	"A1AM78C64UM0Y8": {"sellercentral.amazon.com.mx", endpointNA},
+	"A2Q3Y263D00KWC": {"sellercentral.amazon.com.br", endpointNA},
+	"A1F83G8C2ARO7P": {"sellercentral.amazon.co.uk", endpointEU},
+	"A1PA6795UKMFR9": {"sellercentral.amazon.de", endpointEU},
+	"A13V1IB3VIYZZH": {"sellercentral.amazon.fr", endpointEU},
+	"APJ6JRA9NG5V4":  {"sellercentral.amazon.it", endpointEU},
+	"A1RKKUPIHCS9HS": {"sellercentral.amazon.es", endpointEU},
+	"A1805IZSGTT6HS": {"sellercentral.amazon.nl", endpointEU},
+	"A2NODRKZP88ZB9": {"sellercentral.amazon.se", endpointEU},
+	"A1C3SOZRARQ6R3": {"sellercentral.amazon.pl", endpointEU},
+	"AMEN7PMS3EDWL":  {"sellercentral.amazon.com.be", endpointEU},
+	"A33AVAJ2PDY3EV": {"sellercentral.amazon.com.tr", endpointEU},
+	"A2VIGQ35RCS4UG": {"sellercentral.amazon.ae", endpointEU},
+	"A17E79C6D8DWNP": {"sellercentral.amazon.sa", endpointEU},
+	"ARBP9OOSHTCHU":  {"sellercentral.amazon.eg", endpointEU},
+	"A21TJRUUN4KGV":  {"sellercentral.amazon.in", endpointEU},
+	"A1VC38T7YXB528": {"sellercentral.amazon.co.jp", endpointFE},
+	"A39IBJ37TRP1C6": {"sellercentral.amazon.com.au", endpointFE},
+	"A19VAU5U5O7RUS": {"sellercentral.amazon.sg", endpointFE},
+}
@@ -0,0 +1,159 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/auth"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+)
+
+func TestAuthorizationStoresProfile(t *testing.T) {
+	lwa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != "ANDGSzxgVtAHXmjNKeiH" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"Atza|new","refresh_token":"Atzr|new","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer lwa.Close()
+
+	store := &config.ProfileStore{Path: filepath.Join(t.TempDir(), "profiles.json")}
+	flow, err := New(Config{
+		ApplicationID: "amzn1.sp.solution.example",
+		RedirectURL:   "https://mcp.example.com/oauth/callback",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		Profiles:      store,
+		LWAEndpoint:   lwa.URL,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	authorization, err := flow.Begin(context.Background(), Request{Profile: "acme-de", MarketplaceID: "A1PA6795UKMFR9", Draft: true})
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	consent, err := url.Parse(authorization.ConsentURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consent.Host != "sellercentral.amazon.de" || consent.Query().Get("state") != authorization.State || consent.Query().Get("version") != "beta" {
+		t.Fatalf("unexpected consent URL %s", authorization.ConsentURL)
+	}
+
+	callback := CallbackPath + "?" + url.Values{
+		"state":              {authorization.State},
+		"selling_partner_id": {"A3ACME"},
+		"spapi_oauth_code":   {"ANDGSzxgVtAHXmjNKeiH"},
+	}.Encode()
+	rec := httptest.NewRecorder()
+	flow.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, callback, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback answered %d: %s", rec.Code, rec.Body)
+	}
+
+	profiles, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := profiles["acme-de"]
+	if profile.RefreshToken != "Atzr|new" || profile.SellingPartnerID != "A3ACME" || profile.Endpoint != endpointEU || profile.AuthorizationExpires == "" {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+	if got, _ := flow.Lookup(authorization.State); got.Status != StatusCompleted {
+		t.Fatalf("expected a completed authorization, got %+v", got)
+	}
+
+	replay := httptest.NewRecorder()
+	flow.ServeHTTP(replay, httptest.NewRequest(http.MethodGet, callback, nil))
+	if replay.Code != http.StatusBadRequest || !strings.Contains(replay.Body.String(), "already used") {
+		t.Fatalf("a replayed state should be refused, got %d", replay.Code)
+	}
+}
+
+func TestUnknownStateIsRefused(t *testing.T) {
+	flow, err := New(Config{
+		ApplicationID: "amzn1.sp.solution.example",
+		RedirectURL:   "https://mcp.example.com/oauth/callback",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		Profiles:      &config.ProfileStore{Path: filepath.Join(t.TempDir(), "profiles.json")},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	flow.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, CallbackPath+"?state=forged&spapi_oauth_code=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestBeginGuardsExistingAndForeignProfiles(t *testing.T) {
+	lwa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"Atza|new","refresh_token":"Atzr|new","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer lwa.Close()
+
+	store := &config.ProfileStore{Path: filepath.Join(t.TempDir(), "profiles.json")}
+	if err := store.Save("acme-us", config.Profile{RefreshToken: "Atzr|old", SellingPartnerID: "A3ACME", Sandbox: "amazon"}, false); err != nil {
+		t.Fatal(err)
+	}
+	flow, err := New(Config{
+		ApplicationID: "amzn1.sp.solution.example",
+		RedirectURL:   "https://mcp.example.com/oauth/callback",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		Profiles:      store,
+		Policy:        &auth.Policy{Principals: map[string]auth.Rule{"ops": {Allow: []string{"*"}, Profiles: []string{"acme-*"}}}},
+		LWAEndpoint:   lwa.URL,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ops := auth.ContextWithPrincipal(context.Background(), auth.Principal{Name: "ops", Method: "token"})
+
+	if _, err := flow.Begin(ops, Request{Profile: "other-us"}); err == nil || !strings.Contains(err.Error(), "may not authorize") {
+		t.Fatalf("a profile outside the caller's policy should be refused, got %v", err)
+	}
+	if _, err := flow.Begin(context.Background(), Request{Profile: "acme-de"}); err == nil {
+		t.Fatal("a caller without a principal should be refused while a policy is set")
+	}
+	if _, err := flow.Begin(ops, Request{Profile: "acme-us"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("an existing profile should need overwrite, got %v", err)
+	}
+
+	authorization, err := flow.Begin(ops, Request{Profile: "acme-us", Overwrite: true})
+	if err != nil {
+		t.Fatalf("Begin with overwrite: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	flow.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, CallbackPath+"?"+url.Values{
+		"state":              {authorization.State},
+		"selling_partner_id": {"A3ACME"},
+		"spapi_oauth_code":   {"code"},
+	}.Encode(), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("callback answered %d: %s", rec.Code, rec.Body)
+	}
+
+	profiles, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile := profiles["acme-us"]; profile.RefreshToken != "Atzr|new" || profile.Sandbox != "amazon" || profile.Endpoint != endpointNA {
+		t.Fatalf("re-authorizing should replace the token and keep the other settings, got %+v", profile)
+	}
+}
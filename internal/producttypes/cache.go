@@ -0,0 +1,77 @@
+package producttypes
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a downloaded schema is reused. Amazon publishes new product type versions rarely, and
+// the schema checksum is compared on every getDefinitionsProductType call anyway.
+const DefaultCacheTTL = 24 * time.Hour
+
+// Entry is a compiled schema together with the checksum and product type version Amazon reported for it.
+type Entry struct {
+	Validator *Validator
+	Checksum  string
+	Version   string
+	FetchedAt time.Time
+}
+
+// Cache keeps compiled product type schemas in memory. Compiling a schema means downloading several megabytes, so one
+// cache is shared by every tool in the process.
+type Cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]Entry
+}
+
+// NewCache returns an empty cache whose entries expire after ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, now: time.Now, entries: make(map[string]Entry)}
+}
+
+// Get returns the entry stored under key when it has not expired.
+func (c *Cache) Get(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+	if c.ttl > 0 && c.now().Sub(entry.FetchedAt) > c.ttl {
+		delete(c.entries, key)
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// GetByChecksum returns any live entry compiled from the schema with the given checksum, letting different lookups
+// that resolve to the same schema share one compiled validator.
+func (c *Cache) GetByChecksum(checksum string) (Entry, bool) {
+	if checksum == "" {
+		return Entry{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.entries {
+		if entry.Checksum == checksum && (c.ttl <= 0 || c.now().Sub(entry.FetchedAt) <= c.ttl) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Put stores an entry under key, stamping FetchedAt when it is unset.
+func (c *Cache) Put(key string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry.FetchedAt.IsZero() {
+		entry.FetchedAt = c.now()
+	}
+	c.entries[key] = entry
+}
@@ -0,0 +1,274 @@
+// Package producttypes validates listing attribute payloads against Amazon Product Type Definitions schemas locally,
+// so attribute errors can be fixed before a listing is submitted.
+package producttypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Issue is a single schema violation, addressed by a JSON pointer into the attributes payload.
+type Issue struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+	Message string `json:"message"`
+}
+
+// Validator checks listing attributes against one compiled product type schema.
+type Validator struct {
+	schema     *jsonschema.Schema
+	required   []string
+	properties map[string]json.RawMessage
+}
+
+// Compile builds a validator from a product type schema and the Amazon meta-schema it declares. Both documents are
+// supplied up front; the compiler never fetches URLs on its own.
+func Compile(schemaURL string, schemaDoc []byte, metaSchemaURL string, metaSchemaDoc []byte) (*Validator, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("product type schema references unknown document %s", url)
+	}
+	compiler.RegisterExtension("amazon", amazonVocabularyMeta, amazonVocabularyCompiler{})
+
+	metaSchemaDoc, err := relaxAmazonVocabulary(metaSchemaDoc)
+	if err != nil {
+		return nil, fmt.Errorf("reading meta-schema: %w", err)
+	}
+
+	if err := compiler.AddResource(metaSchemaURL, bytes.NewReader(metaSchemaDoc)); err != nil {
+		return nil, fmt.Errorf("adding meta-schema: %w", err)
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(schemaDoc)); err != nil {
+		return nil, fmt.Errorf("adding product type schema: %w", err)
+	}
+
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("compiling product type schema: %w", err)
+	}
+
+	var outline struct {
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(schemaDoc, &outline); err != nil {
+		return nil, fmt.Errorf("reading product type schema: %w", err)
+	}
+
+	return &Validator{schema: schema, required: outline.Required, properties: outline.Properties}, nil
+}
+
+// Required lists the top-level attributes the schema requires.
+func (v *Validator) Required() []string {
+	return v.required
+}
+
+// PropertyNames lists every top-level attribute defined by the schema, sorted by name.
+func (v *Validator) PropertyNames() []string {
+	names := make([]string, 0, len(v.properties))
+	for name := range v.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Property returns the raw schema fragment for one top-level attribute.
+func (v *Validator) Property(name string) (json.RawMessage, bool) {
+	fragment, ok := v.properties[name]
+	return fragment, ok
+}
+
+// Validate checks an attributes payload and returns every leaf violation, sorted by path. A nil slice means the
+// payload satisfies the schema.
+func (v *Validator) Validate(attributes map[string]any) ([]Issue, error) {
+	// Round-trip through json.Number so numeric keywords compare exact values rather than float64 approximations.
+	raw, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("encoding attributes: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var instance any
+	if err := decoder.Decode(&instance); err != nil {
+		return nil, fmt.Errorf("decoding attributes: %w", err)
+	}
+
+	err = v.schema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	issues := collectIssues(validationErr, nil)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues, nil
+}
+
+func collectIssues(err *jsonschema.ValidationError, issues []Issue) []Issue {
+	if len(err.Causes) == 0 {
+		return append(issues, Issue{
+			Path:    displayPath(err.InstanceLocation),
+			Keyword: lastKeyword(err.KeywordLocation),
+			Message: err.Message,
+		})
+	}
+	for _, cause := range err.Causes {
+		issues = collectIssues(cause, issues)
+	}
+	return issues
+}
+
+func displayPath(location string) string {
+	if location == "" {
+		return "/"
+	}
+	return location
+}
+
+func lastKeyword(location string) string {
+	location = strings.TrimRight(location, "/")
+	if idx := strings.LastIndex(location, "/"); idx >= 0 {
+		return location[idx+1:]
+	}
+	return location
+}
+
+// relaxAmazonVocabulary marks non-standard vocabularies as optional. The Amazon vocabulary is required by the
+// meta-schema but implemented here as a compiler extension, which the compiler cannot see when checking $vocabulary.
+func relaxAmazonVocabulary(doc []byte) ([]byte, error) {
+	var meta map[string]any
+	if err := json.Unmarshal(doc, &meta); err != nil {
+		return nil, err
+	}
+
+	vocabulary, ok := meta["$vocabulary"].(map[string]any)
+	if !ok {
+		return doc, nil
+	}
+
+	for url := range vocabulary {
+		if !strings.HasPrefix(url, "https://json-schema.org/") {
+			vocabulary[url] = false
+		}
+	}
+
+	return json.Marshal(meta)
+}
+
+var amazonVocabularyMeta = jsonschema.MustCompileString("amazon-vocabulary.json", `{
+	"properties": {
+		"maxUtf8ByteLength": {"type": "integer", "minimum": 0},
+		"minUtf8ByteLength": {"type": "integer", "minimum": 0},
+		"maxUniqueItems": {"type": "integer", "minimum": 0},
+		"selectors": {"type": "array", "items": {"type": "string"}}
+	}
+}`)
+
+// amazonVocabularyCompiler implements the validation keywords from the Amazon product type vocabulary: byte lengths for
+// strings, and selectors/maxUniqueItems for arrays, where uniqueness is judged by the selector properties. Annotation-only
+// keywords such as editable, hidden and enumNames do not constrain payloads and are ignored.
+type amazonVocabularyCompiler struct{}
+
+func (amazonVocabularyCompiler) Compile(_ jsonschema.CompilerContext, m map[string]any) (jsonschema.ExtSchema, error) {
+	var schema amazonVocabularySchema
+	present := false
+
+	for keyword, target := range map[string]**int64{
+		"maxUtf8ByteLength": &schema.maxUtf8ByteLength,
+		"minUtf8ByteLength": &schema.minUtf8ByteLength,
+		"maxUniqueItems":    &schema.maxUniqueItems,
+	} {
+		raw, ok := m[keyword]
+		if !ok {
+			continue
+		}
+		number, ok := raw.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a number", keyword)
+		}
+		value, err := number.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer: %w", keyword, err)
+		}
+		*target = &value
+		present = true
+	}
+
+	if raw, ok := m["selectors"].([]any); ok {
+		for _, selector := range raw {
+			if name, ok := selector.(string); ok {
+				schema.selectors = append(schema.selectors, name)
+			}
+		}
+	}
+
+	if !present && len(schema.selectors) == 0 {
+		return nil, nil
+	}
+	return schema, nil
+}
+
+type amazonVocabularySchema struct {
+	maxUtf8ByteLength *int64
+	minUtf8ByteLength *int64
+	maxUniqueItems    *int64
+	selectors         []string
+}
+
+func (s amazonVocabularySchema) Validate(ctx jsonschema.ValidationContext, v any) error {
+	switch value := v.(type) {
+	case string:
+		length := int64(len(value))
+		if s.maxUtf8ByteLength != nil && length > *s.maxUtf8ByteLength {
+			return ctx.Error("maxUtf8ByteLength", "value is %d UTF-8 bytes, maximum is %d", length, *s.maxUtf8ByteLength)
+		}
+		if s.minUtf8ByteLength != nil && length < *s.minUtf8ByteLength {
+			return ctx.Error("minUtf8ByteLength", "value is %d UTF-8 bytes, minimum is %d", length, *s.minUtf8ByteLength)
+		}
+	case []any:
+		if s.maxUniqueItems == nil && len(s.selectors) == 0 {
+			return nil
+		}
+		seen := make(map[string]bool, len(value))
+		for _, item := range value {
+			key := selectorKey(item, s.selectors)
+			if seen[key] && len(s.selectors) > 0 {
+				return ctx.Error("selectors", "multiple items share the same %s", strings.Join(s.selectors, ", "))
+			}
+			seen[key] = true
+		}
+		if s.maxUniqueItems != nil && int64(len(seen)) > *s.maxUniqueItems {
+			return ctx.Error("maxUniqueItems", "%d unique items, maximum is %d", len(seen), *s.maxUniqueItems)
+		}
+	}
+	return nil
+}
+
+// selectorKey identifies an array item by the values of its selector properties, so items targeting the same
+// marketplace and language are treated as the same entry. Without selectors the whole item is the key.
+func selectorKey(item any, selectors []string) string {
+	if len(selectors) == 0 {
+		encoded, _ := json.Marshal(item)
+		return string(encoded)
+	}
+
+	object, _ := item.(map[string]any)
+	parts := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		encoded, _ := json.Marshal(object[selector])
+		parts = append(parts, string(encoded))
+	}
+	return strings.Join(parts, "\x00")
+}
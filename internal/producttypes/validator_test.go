@@ -0,0 +1,163 @@
+package producttypes
+
+import (
+	"testing"
+	"time"
+)
+
+const testMetaSchemaURL = "https://schemas.amazon.com/selling-partners/definitions/product-types/meta-schema/v1"
+
+const testMetaSchema = `{
+	"$schema": "https://json-schema.org/draft/2019-09/schema",
+	"$id": "https://schemas.amazon.com/selling-partners/definitions/product-types/meta-schema/v1",
+	"$vocabulary": {
+		"https://json-schema.org/draft/2019-09/vocab/core": true,
+		"https://json-schema.org/draft/2019-09/vocab/applicator": true,
+		"https://json-schema.org/draft/2019-09/vocab/validation": true,
+		"https://json-schema.org/draft/2019-09/vocab/meta-data": true,
+		"https://schemas.amazon.com/selling-partners/definitions/product-types/vocabulary/v1": true
+	},
+	"$recursiveAnchor": true,
+	"allOf": [
+		{"$ref": "https://json-schema.org/draft/2019-09/meta/core"},
+		{"$ref": "https://json-schema.org/draft/2019-09/meta/applicator"},
+		{"$ref": "https://json-schema.org/draft/2019-09/meta/validation"},
+		{"$ref": "https://json-schema.org/draft/2019-09/meta/meta-data"}
+	]
+}`
+
+const testSchema = `{
+	"$schema": "https://schemas.amazon.com/selling-partners/definitions/product-types/meta-schema/v1",
+	"$id": "https://schemas.amazon.com/selling-partners/definitions/product-types/schema/v1/LUGGAGE",
+	"type": "object",
+	"required": ["item_name"],
+	"properties": {
+		"item_name": {
+			"type": "array",
+			"selectors": ["marketplace_id", "language_tag"],
+			"items": {
+				"type": "object",
+				"required": ["value", "marketplace_id"],
+				"properties": {
+					"value": {"type": "string", "maxUtf8ByteLength": 10},
+					"marketplace_id": {"type": "string"},
+					"language_tag": {"type": "string"}
+				}
+			}
+		},
+		"color": {
+			"type": "array",
+			"maxUniqueItems": 1,
+			"items": {"type": "object", "properties": {"value": {"type": "string"}}}
+		}
+	}
+}`
+
+func compileTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	validator, err := Compile("https://example.com/luggage.json", []byte(testSchema), testMetaSchemaURL, []byte(testMetaSchema))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return validator
+}
+
+func TestValidatorAcceptsValidAttributes(t *testing.T) {
+	validator := compileTestValidator(t)
+
+	issues, err := validator.Validate(map[string]any{
+		"item_name": []any{
+			map[string]any{"value": "Carry-on", "marketplace_id": "ATVPDKIKX0DER", "language_tag": "en_US"},
+			map[string]any{"value": "Valise", "marketplace_id": "A2EUQ1WTGCTBG2", "language_tag": "fr_CA"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+
+	if required := validator.Required(); len(required) != 1 || required[0] != "item_name" {
+		t.Fatalf("unexpected required properties %v", required)
+	}
+	if _, ok := validator.Property("color"); !ok {
+		t.Fatalf("expected color property fragment")
+	}
+}
+
+func TestValidatorReportsAmazonKeywords(t *testing.T) {
+	validator := compileTestValidator(t)
+
+	cases := []struct {
+		name       string
+		attributes map[string]any
+		keyword    string
+		path       string
+	}{
+		{
+			name:       "missing required attribute",
+			attributes: map[string]any{"color": []any{map[string]any{"value": "red"}}},
+			keyword:    "required",
+			path:       "/",
+		},
+		{
+			name: "utf8 byte length",
+			attributes: map[string]any{"item_name": []any{
+				map[string]any{"value": "Bagage à main", "marketplace_id": "ATVPDKIKX0DER"},
+			}},
+			keyword: "maxUtf8ByteLength",
+			path:    "/item_name/0/value",
+		},
+		{
+			name: "duplicate selectors",
+			attributes: map[string]any{"item_name": []any{
+				map[string]any{"value": "One", "marketplace_id": "ATVPDKIKX0DER", "language_tag": "en_US"},
+				map[string]any{"value": "Two", "marketplace_id": "ATVPDKIKX0DER", "language_tag": "en_US"},
+			}},
+			keyword: "selectors",
+			path:    "/item_name",
+		},
+		{
+			name: "max unique items",
+			attributes: map[string]any{
+				"item_name": []any{map[string]any{"value": "Bag", "marketplace_id": "ATVPDKIKX0DER"}},
+				"color":     []any{map[string]any{"value": "red"}, map[string]any{"value": "blue"}},
+			},
+			keyword: "maxUniqueItems",
+			path:    "/color",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, err := validator.Validate(tc.attributes)
+			if err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			if len(issues) != 1 {
+				t.Fatalf("expected one issue, got %+v", issues)
+			}
+			if issues[0].Keyword != tc.keyword || issues[0].Path != tc.path {
+				t.Fatalf("expected %s at %s, got %+v", tc.keyword, tc.path, issues[0])
+			}
+		})
+	}
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	cache := NewCache(time.Hour)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("LUGGAGE", Entry{Checksum: "abc"})
+	if _, ok := cache.GetByChecksum("abc"); !ok {
+		t.Fatalf("expected entry by checksum")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := cache.Get("LUGGAGE"); ok {
+		t.Fatalf("expected entry to expire")
+	}
+}
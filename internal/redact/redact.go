@@ -0,0 +1,355 @@
+// Package redact masks, hashes or drops buyer PII in JSON-shaped values before they reach an LLM. Fields are found by
+// name, ignoring case, hyphens and underscores, so the same rules cover the Orders SDK payloads (BuyerEmail), raw vendor
+// JSON (shipToParty.phone) and flat-file report headers (buyer-email) alike.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Action says what happens to a PII field.
+type Action string
+
+const (
+	// ActionMask keeps the first character and replaces the rest, so a human can still tell values apart at a glance.
+	ActionMask Action = "mask"
+	// ActionHash replaces the value with a keyed hash; equal inputs hash equally, so buyers can still be correlated.
+	ActionHash Action = "hash"
+	// ActionDrop removes the field entirely.
+	ActionDrop Action = "drop"
+	// ActionOff leaves the field untouched.
+	ActionOff Action = "off"
+)
+
+// ParseAction reads an action name.
+func ParseAction(raw string) (Action, error) {
+	switch action := Action(strings.ToLower(strings.TrimSpace(raw))); action {
+	case ActionMask, ActionHash, ActionDrop, ActionOff:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown redaction action %q; use mask, hash, drop or off", raw)
+	}
+}
+
+// Category groups PII fields so a policy can treat, say, emails differently from street addresses.
+type Category string
+
+const (
+	CategoryName    Category = "name"
+	CategoryEmail   Category = "email"
+	CategoryPhone   Category = "phone"
+	CategoryAddress Category = "address"
+	CategoryGift    Category = "gift"
+	CategoryTaxInfo Category = "taxInfo"
+)
+
+// Categories lists every category a policy rule may name.
+func Categories() []Category {
+	return []Category{CategoryName, CategoryEmail, CategoryPhone, CategoryAddress, CategoryGift, CategoryTaxInfo}
+}
+
+// ParseCategory reads a category name, case-insensitively.
+func ParseCategory(raw string) (Category, error) {
+	for _, category := range Categories() {
+		if strings.EqualFold(strings.TrimSpace(raw), string(category)) {
+			return category, nil
+		}
+	}
+	return "", fmt.Errorf("unknown PII category %q; use name, email, phone, address, gift or taxInfo", raw)
+}
+
+// buyerFields are PII wherever they appear. Keys are normalised by fieldKey; the ship and recipient entries are the
+// column headers of flat-file order reports.
+var buyerFields = map[string]Category{
+	"buyername":            CategoryName,
+	"buyercompanyname":     CategoryName,
+	"buyeremail":           CategoryEmail,
+	"email":                CategoryEmail,
+	"phone":                CategoryPhone,
+	"phonenumber":          CategoryPhone,
+	"buyercounty":          CategoryAddress,
+	"giftmessagetext":      CategoryGift,
+	"buyertaxinfo":         CategoryTaxInfo,
+	"buyertaxregistration": CategoryTaxInfo,
+	"buyerphonenumber":     CategoryPhone,
+	"recipientname":        CategoryName,
+	"shipaddress1":         CategoryAddress,
+	"shipaddress2":         CategoryAddress,
+	"shipaddress3":         CategoryAddress,
+	"shipphonenumber":      CategoryPhone,
+}
+
+// addressFields are PII only inside an address object, where "name" is the recipient rather than a product. City,
+// region, postal code and country stay visible: they identify nobody on their own and are what sales analysis needs.
+var addressFields = map[string]Category{
+	"name":         CategoryName,
+	"companyname":  CategoryName,
+	"addressline1": CategoryAddress,
+	"addressline2": CategoryAddress,
+	"addressline3": CategoryAddress,
+	"county":       CategoryAddress,
+	"district":     CategoryAddress,
+}
+
+// addressMarkers identify an object as a postal address.
+var addressMarkers = []string{"addressline1", "city", "postalcode", "stateorregion"}
+
+// Policy decides the action per category. Categories without a rule use Default.
+type Policy struct {
+	Default Action
+	Rules   map[Category]Action
+	// HashKey keys ActionHash. Leave it empty for a random per-process key, which keeps hashes stable within a run
+	// but unlinkable across runs.
+	HashKey []byte
+}
+
+// Enabled reports whether the policy redacts anything.
+func (p Policy) Enabled() bool {
+	if p.Default != ActionOff && p.Default != "" {
+		return true
+	}
+	for _, action := range p.Rules {
+		if action != ActionOff {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) action(category Category) Action {
+	if action, ok := p.Rules[category]; ok {
+		return action
+	}
+	if p.Default == "" {
+		return ActionMask
+	}
+	return p.Default
+}
+
+// Redactor applies a Policy. It is safe for concurrent use.
+type Redactor struct {
+	policy Policy
+	key    []byte
+}
+
+// New returns a Redactor for policy.
+func New(policy Policy) (*Redactor, error) {
+	key := policy.HashKey
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating PII hash key: %w", err)
+		}
+	}
+	return &Redactor{policy: policy, key: key}, nil
+}
+
+// Field records one redacted field by path, such as "BuyerInfo.BuyerEmail" or "orders[2].ShippingAddress.Name".
+type Field struct {
+	Path     string
+	Category Category
+	Action   Action
+}
+
+// Result is a redacted value plus what was changed.
+type Result struct {
+	Value  any
+	Fields []Field
+	// replacements maps each original string to what replaced it, so text derived from the value can follow suit.
+	replacements map[string]string
+}
+
+// Changed reports whether anything was redacted.
+func (r Result) Changed() bool {
+	return len(r.Fields) > 0
+}
+
+// Paths returns the redacted field paths.
+func (r Result) Paths() []string {
+	paths := make([]string, len(r.Fields))
+	for i, field := range r.Fields {
+		paths[i] = field.Path
+	}
+	return paths
+}
+
+// minTextReplacement skips very short originals when rewriting text; replacing every "J" in a sentence would do more
+// damage than the single initial it hides.
+const minTextReplacement = 3
+
+// Text rewrites text so values redacted from the structured result do not leak through a summary built from them.
+// Longer originals are replaced first so a full name wins over a surname inside it.
+func (r Result) Text(text string) string {
+	if len(r.replacements) == 0 {
+		return text
+	}
+
+	originals := make([]string, 0, len(r.replacements))
+	for original := range r.replacements {
+		if len(original) >= minTextReplacement {
+			originals = append(originals, original)
+		}
+	}
+	sort.Slice(originals, func(i, j int) bool {
+		if len(originals[i]) != len(originals[j]) {
+			return len(originals[i]) > len(originals[j])
+		}
+		return originals[i] < originals[j]
+	})
+
+	for _, original := range originals {
+		text = strings.ReplaceAll(text, original, r.replacements[original])
+	}
+	return text
+}
+
+// Value redacts anything encoding/json can marshal. The value is round-tripped through JSON first, so typed structs
+// nested in maps are inspected too and the input is never modified.
+func (r *Redactor) Value(value any) (Result, error) {
+	generic, err := toGeneric(value)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{replacements: make(map[string]string)}
+	result.Value = r.walk(generic, "", &result)
+	return result, nil
+}
+
+func toGeneric(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value for redaction: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return nil, fmt.Errorf("decoding value for redaction: %w", err)
+	}
+	return generic, nil
+}
+
+func (r *Redactor) walk(value any, path string, result *Result) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return r.walkObject(typed, path, result)
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = r.walk(item, fmt.Sprintf("%s[%d]", path, i), result)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) walkObject(object map[string]any, path string, result *Result) map[string]any {
+	address := isAddress(object)
+	out := make(map[string]any, len(object))
+
+	// Sorted keys keep the reported paths deterministic.
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := object[key]
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		category, pii := buyerFields[fieldKey(key)]
+		if !pii && address {
+			category, pii = addressFields[fieldKey(key)]
+		}
+		if !pii || isEmpty(value) {
+			out[key] = r.walk(value, fieldPath, result)
+			continue
+		}
+
+		action := r.policy.action(category)
+		if action == ActionOff {
+			out[key] = value
+			continue
+		}
+
+		result.Fields = append(result.Fields, Field{Path: fieldPath, Category: category, Action: action})
+		replacement := r.replace(value, action)
+		if original, ok := value.(string); ok {
+			result.replacements[original] = textReplacement(replacement, action)
+		}
+		if action != ActionDrop {
+			out[key] = replacement
+		}
+	}
+	return out
+}
+
+// fieldKey normalises a field name for lookup: buyer-email, buyer_email and BuyerEmail all become buyeremail.
+func fieldKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+}
+
+func isAddress(object map[string]any) bool {
+	for key := range object {
+		for _, marker := range addressMarkers {
+			if fieldKey(key) == marker {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isEmpty(value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(typed) == ""
+	default:
+		return false
+	}
+}
+
+const redactedPlaceholder = "[redacted]"
+
+func (r *Redactor) replace(value any, action Action) any {
+	switch action {
+	case ActionDrop:
+		return nil
+	case ActionHash:
+		original, ok := value.(string)
+		if !ok {
+			encoded, _ := json.Marshal(value)
+			original = string(encoded)
+		}
+		mac := hmac.New(sha256.New, r.key)
+		mac.Write([]byte(original))
+		return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		original, ok := value.(string)
+		if !ok {
+			return redactedPlaceholder
+		}
+		runes := []rune(strings.TrimSpace(original))
+		return string(runes[0]) + "***"
+	}
+}
+
+func textReplacement(replacement any, action Action) string {
+	if text, ok := replacement.(string); ok && action != ActionDrop {
+		return text
+	}
+	return redactedPlaceholder
+}
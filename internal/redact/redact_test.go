@@ -0,0 +1,99 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactorValue(t *testing.T) {
+	redactor, err := New(Policy{
+		Default: ActionMask,
+		Rules:   map[Category]Action{CategoryEmail: ActionHash, CategoryGift: ActionDrop},
+		HashKey: []byte("key"),
+	})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	input := map[string]any{
+		"AmazonOrderId": "111-2",
+		"BuyerInfo": map[string]any{
+			"BuyerEmail":      "jane@example.com",
+			"BuyerName":       "Jane Doe",
+			"GiftMessageText": "Happy birthday",
+		},
+		"ShippingAddress": map[string]any{
+			"Name":         "Jane Doe",
+			"AddressLine1": "1 Main St",
+			"City":         "Seattle",
+		},
+		"items": []any{map[string]any{"productName": "Widget", "Name": "Widget"}},
+	}
+
+	result, err := redactor.Value(input)
+	if err != nil {
+		t.Fatalf("value: %v", err)
+	}
+
+	got := strings.Join(result.Paths(), ",")
+	want := "BuyerInfo.BuyerEmail,BuyerInfo.BuyerName,BuyerInfo.GiftMessageText,ShippingAddress.AddressLine1,ShippingAddress.Name"
+	if got != want {
+		t.Fatalf("paths = %s, want %s", got, want)
+	}
+
+	value := result.Value.(map[string]any)
+	buyer := value["BuyerInfo"].(map[string]any)
+	if buyer["BuyerName"] != "J***" {
+		t.Fatalf("name not masked: %v", buyer["BuyerName"])
+	}
+	if email, _ := buyer["BuyerEmail"].(string); !strings.HasPrefix(email, "sha256:") {
+		t.Fatalf("email not hashed: %v", buyer["BuyerEmail"])
+	}
+	if _, ok := buyer["GiftMessageText"]; ok {
+		t.Fatal("gift message was not dropped")
+	}
+	if value["ShippingAddress"].(map[string]any)["City"] != "Seattle" {
+		t.Fatal("city should stay visible")
+	}
+	if value["items"].([]any)[0].(map[string]any)["Name"] != "Widget" {
+		t.Fatal("names outside addresses are not PII")
+	}
+	if input["BuyerInfo"].(map[string]any)["BuyerName"] != "Jane Doe" {
+		t.Fatal("input was modified")
+	}
+
+	text := result.Text("Retrieved shipping address for order 111-2 (Jane Doe), note: Happy birthday")
+	if strings.Contains(text, "Jane") || strings.Contains(text, "Happy birthday") {
+		t.Fatalf("text still carries PII: %s", text)
+	}
+}
+
+func TestRedactorMatchesFlatFileHeaders(t *testing.T) {
+	redactor, err := New(Policy{Default: ActionMask})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	result, err := redactor.Value(map[string]any{
+		"order-id":       "111-2",
+		"buyer-email":    "jane@example.com",
+		"buyer_name":     "Jane Doe",
+		"ship-address-1": "1 Main St",
+		"ship-city":      "Seattle",
+	})
+	if err != nil {
+		t.Fatalf("value: %v", err)
+	}
+	if got, want := strings.Join(result.Paths(), ","), "buyer-email,buyer_name,ship-address-1"; got != want {
+		t.Fatalf("paths = %s, want %s", got, want)
+	}
+}
+
+func TestPolicyEnabled(t *testing.T) {
+	if (Policy{Default: ActionOff}).Enabled() {
+		t.Fatal("an all-off policy should be disabled")
+	}
+	if !(Policy{Default: ActionOff, Rules: map[Category]Action{CategoryEmail: ActionDrop}}).Enabled() {
+		t.Fatal("a policy with an active rule should be enabled")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
 )
 
 // documentationEntry captures metadata for an MCP documentation resource.
@@ -161,6 +163,29 @@ func Documentation() []server.ServerResource {
 	return resources
 }
 
+// StoreSchema describes the local store tables that analytics.sql queries.
+func StoreSchema() server.ServerResource {
+	const uri = "amazon-sp-api://store/schema"
+
+	resource := mcp.NewResource(
+		uri,
+		"Local Store Schema",
+		mcp.WithResourceDescription("Tables and columns of the local SQLite store, for writing analytics.sql queries."),
+		mcp.WithMIMEType("text/markdown"),
+	)
+
+	return server.ServerResource{
+		Resource: resource,
+		Handler: func(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     store.SchemaDocumentation,
+			}}, nil
+		},
+	}
+}
+
 func documentationHandler(uri string, entry documentationEntry) server.ResourceHandlerFunc {
 	return func(_ context.Context, _ mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 		body := buildDocumentationBody(entry)
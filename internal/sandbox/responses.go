@@ -0,0 +1,765 @@
+package sandbox
+
+import "net/http"
+
+// routes are the static sandbox examples from Amazon's API models, one per operation the tools call. Request values
+// are not matched: any well-formed call gets the example, and TEST_CASE_400 anywhere gets the error case.
+var routes = []route{
+	// Orders v0
+	{"GET /orders/v0/orders", http.StatusOK, getOrders},
+	{"GET /orders/v0/orders/{orderId}", http.StatusOK, getOrder},
+	{"GET /orders/v0/orders/{orderId}/address", http.StatusOK, getOrderAddress},
+	{"GET /orders/v0/orders/{orderId}/buyerInfo", http.StatusOK, getOrderBuyerInfo},
+	{"GET /orders/v0/orders/{orderId}/orderItems", http.StatusOK, getOrderItems},
+	{"GET /orders/v0/orders/{orderId}/orderItems/buyerInfo", http.StatusOK, getOrderItemsBuyerInfo},
+	{"POST /orders/v0/orders/{orderId}/shipment", http.StatusNoContent, ""},
+	{"POST /orders/v0/orders/{orderId}/shipmentConfirmation", http.StatusNoContent, ""},
+	{"GET /orders/v0/orders/{orderId}/regulatedInfo", http.StatusOK, getOrderRegulatedInfo},
+	{"PATCH /orders/v0/orders/{orderId}/regulatedInfo", http.StatusNoContent, ""},
+	{"GET /orders/v0/orders/{orderId}/approvals", http.StatusOK, getOrderItemsApprovals},
+
+	// Sales v1
+	{"GET /sales/v1/orderMetrics", http.StatusOK, getOrderMetrics},
+
+	// Reports 2021-06-30
+	{"GET /reports/2021-06-30/reports", http.StatusOK, getReports},
+	{"POST /reports/2021-06-30/reports", http.StatusAccepted, createReport},
+	{"GET /reports/2021-06-30/reports/{reportId}", http.StatusOK, getReport},
+	{"GET /reports/2021-06-30/documents/{reportDocumentId}", http.StatusOK, getReportDocument},
+
+	// FBA Inventory v1
+	{"GET /fba/inventory/v1/summaries", http.StatusOK, getInventorySummaries},
+
+	// Product Pricing v0
+	{"GET /products/pricing/v0/price", http.StatusOK, getPricing},
+	{"GET /products/pricing/v0/competitivePrice", http.StatusOK, getCompetitivePricing},
+
+	// Finances v0
+	{"GET /finances/v0/financialEvents", http.StatusOK, listFinancialEvents},
+
+	// Data Kiosk 2023-11-15
+	{"GET /dataKiosk/2023-11-15/queries", http.StatusOK, getQueries},
+	{"POST /dataKiosk/2023-11-15/queries", http.StatusAccepted, createQuery},
+	{"GET /dataKiosk/2023-11-15/queries/{queryId}", http.StatusOK, getQuery},
+	{"DELETE /dataKiosk/2023-11-15/queries/{queryId}", http.StatusNoContent, ""},
+	{"GET /dataKiosk/2023-11-15/documents/{documentId}", http.StatusOK, getDataKioskDocument},
+
+	// Uploads 2020-11-01; the resource is a path such as aplus/2020-11-01/contentDocuments.
+	{"POST /uploads/2020-11-01/uploadDestinations/{resource...}", http.StatusCreated, createUploadDestination},
+
+	// A+ Content 2020-11-01
+	{"GET /aplus/2020-11-01/contentDocuments", http.StatusOK, searchContentDocuments},
+	{"POST /aplus/2020-11-01/contentDocuments", http.StatusOK, createContentDocument},
+	{"GET /aplus/2020-11-01/contentDocuments/{contentReferenceKey}", http.StatusOK, getContentDocument},
+	{"POST /aplus/2020-11-01/contentDocuments/{contentReferenceKey}", http.StatusOK, createContentDocument},
+	{"POST /aplus/2020-11-01/contentDocuments/{contentReferenceKey}/asins", http.StatusOK, aplusWarnings},
+	{"POST /aplus/2020-11-01/contentAsinValidations", http.StatusOK, aplusWarnings},
+
+	// Product Type Definitions 2020-09-01
+	{"GET /definitions/2020-09-01/productTypes", http.StatusOK, searchDefinitionsProductTypes},
+	{"GET /definitions/2020-09-01/productTypes/{productType}", http.StatusOK, getDefinitionsProductType},
+
+	// Listings Restrictions 2021-08-01 and FBA Inbound Eligibility v1
+	{"GET /listings/2021-08-01/restrictions", http.StatusOK, getListingsRestrictions},
+	{"GET /fba/inbound/v1/eligibility/itemPreview", http.StatusOK, getItemEligibilityPreview},
+
+	// Vendor Orders, Invoices and Transaction Status v1
+	{"GET /vendor/orders/v1/purchaseOrders", http.StatusOK, getVendorPurchaseOrders},
+	{"GET /vendor/orders/v1/purchaseOrders/{purchaseOrderNumber}", http.StatusOK, getVendorPurchaseOrder},
+	{"POST /vendor/orders/v1/acknowledgements", http.StatusAccepted, vendorTransaction},
+	{"POST /vendor/payments/v1/invoices", http.StatusAccepted, vendorTransaction},
+	{"GET /vendor/transactions/v1/transactions/{transactionId}", http.StatusOK, getVendorTransactionStatus},
+
+	// Vendor Direct Fulfillment 2021-12-28 (results at the top level) and Payments v1
+	{"GET /vendor/directFulfillment/orders/2021-12-28/purchaseOrders", http.StatusOK, getDirectFulfillmentOrders},
+	{"GET /vendor/directFulfillment/orders/2021-12-28/purchaseOrders/{purchaseOrderNumber}", http.StatusOK, getDirectFulfillmentOrder},
+	{"POST /vendor/directFulfillment/orders/2021-12-28/acknowledgements", http.StatusAccepted, directFulfillmentTransaction},
+	{"GET /vendor/directFulfillment/shipping/2021-12-28/shippingLabels", http.StatusOK, getShippingLabels},
+	{"GET /vendor/directFulfillment/shipping/2021-12-28/shippingLabels/{purchaseOrderNumber}", http.StatusOK, getShippingLabel},
+	{"POST /vendor/directFulfillment/shipping/2021-12-28/shipmentConfirmations", http.StatusAccepted, directFulfillmentTransaction},
+	{"POST /vendor/directFulfillment/payments/v1/invoices", http.StatusAccepted, vendorTransaction},
+	{"GET /vendor/directFulfillment/transactions/2021-12-28/transactions/{transactionId}", http.StatusOK, getDirectFulfillmentTransactionStatus},
+}
+
+const getOrders = `{
+  "payload": {
+    "CreatedBefore": "1.569521782042E9",
+    "Orders": [
+      {
+        "AmazonOrderId": "902-1845936-5435065",
+        "PurchaseDate": "1970-01-19T03:58:30Z",
+        "LastUpdateDate": "1970-01-19T03:58:32Z",
+        "OrderStatus": "Unshipped",
+        "FulfillmentChannel": "MFN",
+        "SalesChannel": "Amazon.com",
+        "ShipServiceLevel": "Std US D2D Dom",
+        "OrderTotal": {"CurrencyCode": "USD", "Amount": "11.01"},
+        "NumberOfItemsShipped": 0,
+        "NumberOfItemsUnshipped": 1,
+        "PaymentMethod": "Other",
+        "PaymentMethodDetails": ["Standard"],
+        "IsReplacementOrder": "false",
+        "MarketplaceId": "ATVPDKIKX0DER",
+        "ShipmentServiceLevelCategory": "Standard",
+        "OrderType": "StandardOrder",
+        "EarliestShipDate": "1970-01-19T03:59:27Z",
+        "LatestShipDate": "1970-01-19T04:05:13Z",
+        "IsBusinessOrder": false,
+        "IsPrime": false,
+        "IsGlobalExpressEnabled": false,
+        "IsPremiumOrder": false,
+        "IsSoldByAB": false,
+        "IsIBA": false,
+        "DefaultShipFromLocationAddress": {
+          "Name": "MFNIntegrationTestMerchant",
+          "AddressLine1": "2201 WESTLAKE AVE",
+          "City": "SEATTLE",
+          "StateOrRegion": "WA",
+          "PostalCode": "98121-2778",
+          "CountryCode": "US",
+          "Phone": "+1 480-386-0930 ext. 73824",
+          "AddressType": "Commercial"
+        },
+        "FulfillmentInstruction": {"FulfillmentSupplySourceId": "sampleSupplySourceId"},
+        "IsISPU": false,
+        "AutomatedShippingSettings": {"HasAutomatedShippingSettings": false}
+      }
+    ]
+  }
+}`
+
+const getOrder = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "PurchaseDate": "1970-01-19T03:58:30Z",
+    "LastUpdateDate": "1970-01-19T03:58:32Z",
+    "OrderStatus": "Unshipped",
+    "FulfillmentChannel": "MFN",
+    "SalesChannel": "Amazon.com",
+    "ShipServiceLevel": "Std US D2D Dom",
+    "OrderTotal": {"CurrencyCode": "USD", "Amount": "11.01"},
+    "NumberOfItemsShipped": 0,
+    "NumberOfItemsUnshipped": 1,
+    "PaymentMethod": "Other",
+    "PaymentMethodDetails": ["Standard"],
+    "IsReplacementOrder": "false",
+    "MarketplaceId": "ATVPDKIKX0DER",
+    "ShipmentServiceLevelCategory": "Standard",
+    "OrderType": "StandardOrder",
+    "EarliestShipDate": "1970-01-19T03:59:27Z",
+    "LatestShipDate": "1970-01-19T04:05:13Z",
+    "IsBusinessOrder": false,
+    "IsPrime": false,
+    "IsGlobalExpressEnabled": false,
+    "IsPremiumOrder": false,
+    "IsSoldByAB": false,
+    "IsIBA": false,
+    "IsISPU": false
+  }
+}`
+
+const getOrderAddress = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "ShippingAddress": {
+      "Name": "MFNIntegrationTestMerchant",
+      "AddressLine1": "2201 WESTLAKE AVE",
+      "City": "SEATTLE",
+      "StateOrRegion": "WA",
+      "PostalCode": "98121-2778",
+      "CountryCode": "US",
+      "Phone": "+1 480-386-0930 ext. 73824",
+      "AddressType": "Commercial"
+    }
+  }
+}`
+
+const getOrderBuyerInfo = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "BuyerEmail": "user@example.com",
+    "BuyerName": "John Doe",
+    "BuyerTaxInfo": {"CompanyLegalName": "A Company Name"},
+    "PurchaseOrderNumber": "1234567890123"
+  }
+}`
+
+const getOrderItems = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "OrderItems": [
+      {
+        "ASIN": "B00551Q3CS",
+        "OrderItemId": "05015851154158",
+        "SellerSKU": "NABetaASINB00551Q3CS",
+        "Title": "B00551Q3CS [Card Book]",
+        "QuantityOrdered": 1,
+        "QuantityShipped": 0,
+        "ProductInfo": {"NumberOfItems": "1"},
+        "ItemPrice": {"CurrencyCode": "USD", "Amount": "10.00"},
+        "ItemTax": {"CurrencyCode": "USD", "Amount": "1.01"},
+        "PromotionDiscount": {"CurrencyCode": "USD", "Amount": "0.00"},
+        "IsGift": "false",
+        "ConditionId": "New",
+        "ConditionSubtypeId": "New",
+        "IsTransparency": false,
+        "SerialNumberRequired": false
+      }
+    ]
+  }
+}`
+
+const getOrderItemsBuyerInfo = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "OrderItems": [
+      {
+        "OrderItemId": "05015851154158",
+        "GiftMessageText": "For you!",
+        "GiftWrapPrice": {"CurrencyCode": "USD", "Amount": "1.99"},
+        "GiftWrapLevel": "Classic"
+      }
+    ]
+  }
+}`
+
+const getOrderRegulatedInfo = `{
+  "payload": {
+    "AmazonOrderId": "902-1845936-5435065",
+    "RequiresDosageLabel": false,
+    "RegulatedInformation": {
+      "Fields": [
+        {"FieldId": "pet_prescription_name", "FieldLabel": "Name", "FieldType": "Text", "FieldValue": "Ruffus"}
+      ]
+    },
+    "RegulatedOrderVerificationStatus": {
+      "Status": "Pending",
+      "RequiresMerchantAction": true,
+      "ValidRejectionReasons": [
+        {"RejectionReasonId": "shield_pom_vps_reject_product", "RejectionReasonDescription": "This medicine is not suitable for your pet."}
+      ]
+    }
+  }
+}`
+
+const getOrderItemsApprovals = `{
+  "payload": {
+    "orderItemsApprovals": [
+      {
+        "orderItemId": "05015851154158",
+        "approvalType": "CUSTOMIZATION",
+        "approvalStatus": "PENDING_SELLING_PARTNER_APPROVAL",
+        "approvalSupportData": {"customizationData": "Engraving: Happy Birthday"}
+      }
+    ]
+  }
+}`
+
+const getOrderMetrics = `{
+  "payload": [
+    {
+      "interval": "2018-09-01T00:00:00-07:00--2018-09-04T00:00:00-07:00",
+      "unitCount": 2,
+      "orderItemCount": 1,
+      "orderCount": 1,
+      "averageUnitPrice": {"amount": "22.50", "currencyCode": "USD"},
+      "totalSales": {"amount": "45.00", "currencyCode": "USD"}
+    }
+  ]
+}`
+
+const getReports = `{
+  "reports": [
+    {
+      "reportId": "ReportId1",
+      "reportType": "GET_MERCHANT_LISTINGS_ALL_DATA",
+      "dataStartTime": "2019-12-11T13:47:20.677Z",
+      "dataEndTime": "2019-12-12T13:47:20.677Z",
+      "createdTime": "2019-12-10T13:47:20.677Z",
+      "processingStatus": "DONE",
+      "processingStartTime": "2019-12-10T13:47:20.677Z",
+      "processingEndTime": "2019-12-12T13:47:20.677Z",
+      "reportDocumentId": "0356cf79-b8b0-4226-b4b9-0ee058ea5760",
+      "marketplaceIds": ["ATVPDKIKX0DER"]
+    }
+  ]
+}`
+
+const createReport = `{"reportId": "ID323"}`
+
+const getReport = `{
+  "reportId": "ReportId1",
+  "reportType": "GET_MERCHANT_LISTINGS_ALL_DATA",
+  "dataStartTime": "2019-12-11T13:47:20.677Z",
+  "dataEndTime": "2019-12-12T13:47:20.677Z",
+  "createdTime": "2019-12-10T13:47:20.677Z",
+  "processingStatus": "DONE",
+  "processingStartTime": "2019-12-10T13:47:20.677Z",
+  "processingEndTime": "2019-12-12T13:47:20.677Z",
+  "reportDocumentId": "0356cf79-b8b0-4226-b4b9-0ee058ea5760",
+  "marketplaceIds": ["ATVPDKIKX0DER"]
+}`
+
+const getReportDocument = `{
+  "reportDocumentId": "0356cf79-b8b0-4226-b4b9-0ee058ea5760",
+  "url": "{base}/sandbox/documents/merchant-listings.tsv"
+}`
+
+const getInventorySummaries = `{
+  "payload": {
+    "granularity": {"granularityType": "Marketplace", "granularityId": "ATVPDKIKX0DER"},
+    "inventorySummaries": [
+      {
+        "asin": "B0020MLK00",
+        "fnSku": "B0020MLK00",
+        "sellerSku": "PSMM-TEST-SKU-Jan-21_19_59_19-0992",
+        "condition": "NewItem",
+        "inventoryDetails": {
+          "fulfillableQuantity": 20,
+          "inboundWorkingQuantity": 0,
+          "inboundShippedQuantity": 0,
+          "inboundReceivingQuantity": 0,
+          "reservedQuantity": {"totalReservedQuantity": 0, "pendingCustomerOrderQuantity": 0, "pendingTransshipmentQuantity": 0, "fcProcessingQuantity": 0},
+          "researchingQuantity": {"totalResearchingQuantity": 0, "researchingQuantityBreakdown": []},
+          "unfulfillableQuantity": {"totalUnfulfillableQuantity": 0, "customerDamagedQuantity": 0, "warehouseDamagedQuantity": 0, "distributorDamagedQuantity": 0, "carrierDamagedQuantity": 0, "defectiveQuantity": 0, "expiredQuantity": 0}
+        },
+        "lastUpdatedTime": "2021-01-21T20:00:00Z",
+        "productName": "Sample product",
+        "totalQuantity": 20
+      }
+    ]
+  },
+  "pagination": {}
+}`
+
+const getPricing = `{
+  "payload": [
+    {
+      "status": "Success",
+      "ASIN": "B00V5DG6IQ",
+      "Product": {
+        "Identifiers": {
+          "MarketplaceASIN": {"MarketplaceId": "ATVPDKIKX0DER", "ASIN": "B00V5DG6IQ"},
+          "SKUIdentifier": {"MarketplaceId": "ATVPDKIKX0DER", "SellerId": "AXXXXXXXXXXXXX", "SellerSKU": "NABetaASINB00V5DG6IQ"}
+        },
+        "Offers": [
+          {
+            "BuyingPrice": {
+              "ListingPrice": {"CurrencyCode": "USD", "Amount": 10.0},
+              "LandedPrice": {"CurrencyCode": "USD", "Amount": 10.0},
+              "Shipping": {"CurrencyCode": "USD", "Amount": 0.0}
+            },
+            "RegularPrice": {"CurrencyCode": "USD", "Amount": 10.0},
+            "FulfillmentChannel": "MERCHANT",
+            "ItemCondition": "New",
+            "ItemSubCondition": "New",
+            "SellerSKU": "NABetaASINB00V5DG6IQ"
+          }
+        ]
+      }
+    }
+  ]
+}`
+
+const getCompetitivePricing = `{
+  "payload": [
+    {
+      "status": "Success",
+      "ASIN": "B00V5DG6IQ",
+      "Product": {
+        "Identifiers": {
+          "MarketplaceASIN": {"MarketplaceId": "ATVPDKIKX0DER", "ASIN": "B00V5DG6IQ"}
+        },
+        "CompetitivePricing": {
+          "CompetitivePrices": [
+            {
+              "CompetitivePriceId": "1",
+              "Price": {
+                "LandedPrice": {"CurrencyCode": "USD", "Amount": 12.5},
+                "ListingPrice": {"CurrencyCode": "USD", "Amount": 10.0},
+                "Shipping": {"CurrencyCode": "USD", "Amount": 2.5}
+              },
+              "condition": "New",
+              "belongsToRequester": false
+            }
+          ],
+          "NumberOfOfferListings": [
+            {"Count": 2, "condition": "New"}
+          ]
+        },
+        "SalesRankings": [
+          {"ProductCategoryId": "toy_display_on_website", "Rank": 48602}
+        ]
+      }
+    }
+  ]
+}`
+
+const listFinancialEvents = `{
+  "payload": {
+    "FinancialEvents": {
+      "ShipmentEventList": [
+        {
+          "AmazonOrderId": "902-1845936-5435065",
+          "MarketplaceName": "Amazon.com",
+          "PostedDate": "2020-02-05T13:56:00.363Z",
+          "ShipmentItemList": [
+            {
+              "SellerSKU": "NABetaASINB00551Q3CS",
+              "OrderItemId": "05015851154158",
+              "QuantityShipped": 1,
+              "ItemChargeList": [
+                {"ChargeType": "Principal", "ChargeAmount": {"CurrencyCode": "USD", "CurrencyAmount": 10.0}},
+                {"ChargeType": "Tax", "ChargeAmount": {"CurrencyCode": "USD", "CurrencyAmount": 1.01}}
+              ],
+              "ItemFeeList": [
+                {"FeeType": "Commission", "FeeAmount": {"CurrencyCode": "USD", "CurrencyAmount": -1.5}}
+              ]
+            }
+          ]
+        }
+      ],
+      "RefundEventList": [],
+      "ServiceFeeEventList": [],
+      "AdjustmentEventList": []
+    }
+  }
+}`
+
+const getQueries = `{
+  "queries": [
+    {
+      "queryId": "QueryId1",
+      "query": "query { analytics_salesAndTraffic_2023_11_15 { salesAndTrafficByDate(startDate: \"2023-01-01\", endDate: \"2023-01-31\", aggregateBy: DAY, marketplaceIds: [\"ATVPDKIKX0DER\"]) { startDate } } }",
+      "createdTime": "2023-11-01T00:00:00Z",
+      "processingStatus": "DONE",
+      "processingStartTime": "2023-11-01T00:00:05Z",
+      "processingEndTime": "2023-11-01T00:01:00Z",
+      "dataDocumentId": "DataDocumentId1"
+    }
+  ],
+  "pagination": {}
+}`
+
+const createQuery = `{"queryId": "QueryId1"}`
+
+const getQuery = `{
+  "queryId": "QueryId1",
+  "query": "query { analytics_salesAndTraffic_2023_11_15 { salesAndTrafficByDate(startDate: \"2023-01-01\", endDate: \"2023-01-31\", aggregateBy: DAY, marketplaceIds: [\"ATVPDKIKX0DER\"]) { startDate } } }",
+  "createdTime": "2023-11-01T00:00:00Z",
+  "processingStatus": "DONE",
+  "processingStartTime": "2023-11-01T00:00:05Z",
+  "processingEndTime": "2023-11-01T00:01:00Z",
+  "dataDocumentId": "DataDocumentId1"
+}`
+
+const getDataKioskDocument = `{
+  "documentId": "DataDocumentId1",
+  "documentUrl": "{base}/sandbox/documents/sales-and-traffic.jsonl"
+}`
+
+const createUploadDestination = `{
+  "payload": {
+    "uploadDestinationId": "sc/12345678-1234-1234-1234-123456789012.png",
+    "url": "{base}/sandbox/documents/upload",
+    "headers": {"Content-Type": "image/png"}
+  }
+}`
+
+const searchContentDocuments = `{
+  "warnings": [],
+  "contentMetadataRecords": [
+    {
+      "contentReferenceKey": "sandbox-content-1",
+      "contentMetadata": {
+        "name": "Spring launch",
+        "marketplaceId": "ATVPDKIKX0DER",
+        "status": "APPROVED",
+        "badgeSet": ["STANDARD"],
+        "updateTime": "2023-01-01T00:00:00Z"
+      }
+    }
+  ]
+}`
+
+const getContentDocument = `{
+  "warnings": [],
+  "contentRecord": {
+    "contentReferenceKey": "sandbox-content-1",
+    "contentMetadata": {
+      "name": "Spring launch",
+      "marketplaceId": "ATVPDKIKX0DER",
+      "status": "APPROVED",
+      "badgeSet": ["STANDARD"],
+      "updateTime": "2023-01-01T00:00:00Z"
+    },
+    "contentDocument": {
+      "name": "Spring launch",
+      "contentType": "EBC",
+      "locale": "en-US",
+      "contentModuleList": [
+        {
+          "contentModuleType": "STANDARD_TEXT",
+          "standardText": {
+            "headline": {"value": "Built to last", "decoratorSet": []},
+            "body": {"textList": [{"value": "Every seam is double-stitched.", "decoratorSet": []}]}
+          }
+        }
+      ]
+    }
+  }
+}`
+
+const createContentDocument = `{"warnings": [], "contentReferenceKey": "sandbox-content-1"}`
+
+const aplusWarnings = `{"warnings": []}`
+
+const searchDefinitionsProductTypes = `{
+  "productTypes": [
+    {"name": "LUGGAGE", "displayName": "Luggage", "marketplaceIds": ["ATVPDKIKX0DER"]}
+  ],
+  "productTypeVersion": "U8d6bd6a0-8ad6-4c6c-a1e0-1e1b2fd1f1f1"
+}`
+
+const getDefinitionsProductType = `{
+  "metaSchema": {
+    "link": {"resource": "{base}/sandbox/documents/meta-schema.json", "verb": "GET"},
+    "checksum": "sandbox-meta-schema"
+  },
+  "schema": {
+    "link": {"resource": "{base}/sandbox/documents/luggage-schema.json", "verb": "GET"},
+    "checksum": "sandbox-luggage-schema"
+  },
+  "requirements": "LISTING",
+  "requirementsEnforced": "ENFORCED",
+  "propertyGroups": {
+    "offer": {"title": "Offer", "description": "Label to describe the offer", "propertyNames": ["color"]},
+    "product_identity": {"title": "Product Identity", "description": "Product identity", "propertyNames": ["item_name"]}
+  },
+  "locale": "en_US",
+  "marketplaceIds": ["ATVPDKIKX0DER"],
+  "productType": "LUGGAGE",
+  "displayName": "Luggage",
+  "productTypeVersion": {"version": "U8d6bd6a0-8ad6-4c6c-a1e0-1e1b2fd1f1f1", "latest": true, "releaseCandidate": false}
+}`
+
+const getListingsRestrictions = `{
+  "restrictions": [
+    {
+      "marketplaceId": "ATVPDKIKX0DER",
+      "conditionType": "used_acceptable",
+      "reasons": [
+        {
+          "message": "You cannot list the product in this condition.",
+          "reasonCode": "APPROVAL_REQUIRED",
+          "links": [
+            {"resource": "https://sellercentral.amazon.com/hz/approvalrequest?asin=B0000ASIN1", "verb": "GET", "title": "Request Approval via Seller Central.", "type": "text/html"}
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+const getItemEligibilityPreview = `{
+  "payload": {
+    "asin": "B0000ASIN1",
+    "marketplaceId": "ATVPDKIKX0DER",
+    "program": "INBOUND",
+    "isEligibleForProgram": true
+  }
+}`
+
+const getVendorPurchaseOrders = `{
+  "payload": {
+    "orders": [
+      {
+        "purchaseOrderNumber": "4Z32PABC",
+        "purchaseOrderState": "Acknowledged",
+        "orderDetails": {
+          "purchaseOrderDate": "2019-08-20T07:00:00Z",
+          "purchaseOrderChangedDate": "2019-08-22T07:00:00Z",
+          "purchaseOrderStateChangedDate": "2019-08-21T07:00:00Z",
+          "purchaseOrderType": "RegularOrder",
+          "buyingParty": {"partyId": "ABCD1"},
+          "sellingParty": {"partyId": "XYZ12"},
+          "shipToParty": {"partyId": "ABCD1"},
+          "billToParty": {"partyId": "ABCD1"},
+          "shipWindow": "2019-08-25T07:00:00Z--2019-08-30T07:00:00Z",
+          "items": [
+            {
+              "itemSequenceNumber": "1",
+              "amazonProductIdentifier": "ABC123434",
+              "vendorProductIdentifier": "028877454078",
+              "orderedQuantity": {"amount": 2, "unitOfMeasure": "Cases", "unitSize": 10},
+              "isBackOrderAllowed": true,
+              "netCost": {"currencyCode": "USD", "amount": "1.00"},
+              "listPrice": {"currencyCode": "USD", "amount": "1.00"}
+            }
+          ]
+        }
+      }
+    ]
+  }
+}`
+
+const getVendorPurchaseOrder = `{
+  "payload": {
+    "purchaseOrderNumber": "4Z32PABC",
+    "purchaseOrderState": "Acknowledged",
+    "orderDetails": {
+      "purchaseOrderDate": "2019-08-20T07:00:00Z",
+      "purchaseOrderType": "RegularOrder",
+      "sellingParty": {"partyId": "XYZ12"},
+      "shipToParty": {"partyId": "ABCD1"},
+      "items": [
+        {
+          "itemSequenceNumber": "1",
+          "amazonProductIdentifier": "ABC123434",
+          "vendorProductIdentifier": "028877454078",
+          "orderedQuantity": {"amount": 2, "unitOfMeasure": "Cases", "unitSize": 10},
+          "netCost": {"currencyCode": "USD", "amount": "1.00"}
+        }
+      ]
+    }
+  }
+}`
+
+const vendorTransaction = `{"payload": {"transactionId": "20190904190535-eef8cad8-418e-4ed3-ac72-789e2ee6214a"}}`
+
+const getVendorTransactionStatus = `{
+  "payload": {
+    "transactionStatus": {
+      "transactionId": "20190904190535-eef8cad8-418e-4ed3-ac72-789e2ee6214a",
+      "status": "Success"
+    }
+  }
+}`
+
+const getDirectFulfillmentOrders = `{
+  "pagination": {},
+  "orders": [
+    {
+      "purchaseOrderNumber": "2JK3S9VC",
+      "orderDetails": {
+        "customerOrderNumber": "123-4567890-1234567",
+        "orderDate": "2019-08-20T07:00:00Z",
+        "orderStatus": "NEW",
+        "shipmentDetails": {"isPriorityShipment": false, "isScheduledDeliveryShipment": false, "isPslipRequired": false, "shipMethod": "UPS_GR_RES", "shipmentDates": {"requiredShipDate": "2019-08-22T07:00:00Z"}},
+        "sellingParty": {"partyId": "999US"},
+        "shipFromParty": {"partyId": "ABCD"},
+        "items": [
+          {"itemSequenceNumber": "00001", "buyerProductIdentifier": "B07DFVDRAB", "orderedQuantity": {"amount": 1, "unitOfMeasure": "Each"}, "netPrice": {"currencyCode": "USD", "amount": "10.00"}}
+        ]
+      }
+    }
+  ]
+}`
+
+const getDirectFulfillmentOrder = `{
+  "purchaseOrderNumber": "2JK3S9VC",
+  "orderDetails": {
+    "customerOrderNumber": "123-4567890-1234567",
+    "orderDate": "2019-08-20T07:00:00Z",
+    "orderStatus": "NEW",
+    "sellingParty": {"partyId": "999US"},
+    "shipFromParty": {"partyId": "ABCD"},
+    "items": [
+      {"itemSequenceNumber": "00001", "buyerProductIdentifier": "B07DFVDRAB", "orderedQuantity": {"amount": 1, "unitOfMeasure": "Each"}, "netPrice": {"currencyCode": "USD", "amount": "10.00"}}
+    ]
+  }
+}`
+
+const directFulfillmentTransaction = `{"transactionId": "20190905010908-8a3b6901-ef20-412f-9270-21c021796af7"}`
+
+const getDirectFulfillmentTransactionStatus = `{
+  "transactionStatus": {
+    "transactionId": "20190905010908-8a3b6901-ef20-412f-9270-21c021796af7",
+    "status": "Success"
+  }
+}`
+
+const getShippingLabels = `{
+  "pagination": {},
+  "shippingLabels": [
+    {
+      "purchaseOrderNumber": "2JK3S9VC",
+      "sellingParty": {"partyId": "999US"},
+      "shipFromParty": {"partyId": "ABCD"},
+      "labelFormat": "PNG",
+      "labelData": [{"packageIdentifier": "PKG001", "trackingNumber": "1Z6A34Y60369738804", "shipMethod": "UPS_GR_RES", "shipMethodName": "UPS Ground Residential", "content": "iVBORw0KGgo="}]
+    }
+  ]
+}`
+
+const getShippingLabel = `{
+  "purchaseOrderNumber": "2JK3S9VC",
+  "sellingParty": {"partyId": "999US"},
+  "shipFromParty": {"partyId": "ABCD"},
+  "labelFormat": "PNG",
+  "labelData": [{"packageIdentifier": "PKG001", "trackingNumber": "1Z6A34Y60369738804", "shipMethod": "UPS_GR_RES", "shipMethodName": "UPS Ground Residential", "content": "iVBORw0KGgo="}]
+}`
+
+// document is a file the canned responses link to, standing in for a pre-signed S3 URL.
+type document struct {
+	contentType string
+	body        string
+}
+
+var documents = map[string]document{
+	"merchant-listings.tsv": {"text/tab-separated-values", "item-name\tseller-sku\tprice\tquantity\tasin1\tstatus\n" +
+		"B00551Q3CS [Card Book]\tNABetaASINB00551Q3CS\t10.00\t20\tB00551Q3CS\tActive\n" +
+		"B00V5DG6IQ [Travel Mug]\tNABetaASINB00V5DG6IQ\t10.00\t5\tB00V5DG6IQ\tActive\n"},
+	"sales-and-traffic.jsonl": {"application/x-ndjson", `{"startDate":"2023-01-01","endDate":"2023-01-01","marketplaceId":"ATVPDKIKX0DER","sales":{"orderedProductSales":{"amount":45.0,"currencyCode":"USD"},"unitsOrdered":2,"totalOrderItems":1},"traffic":{"pageViews":120,"sessions":80}}` + "\n" +
+		`{"startDate":"2023-01-02","endDate":"2023-01-02","marketplaceId":"ATVPDKIKX0DER","sales":{"orderedProductSales":{"amount":22.5,"currencyCode":"USD"},"unitsOrdered":1,"totalOrderItems":1},"traffic":{"pageViews":95,"sessions":61}}` + "\n"},
+	"meta-schema.json":    {"application/json", metaSchema},
+	"luggage-schema.json": {"application/json", luggageSchema},
+}
+
+const metaSchema = `{
+  "$schema": "https://json-schema.org/draft/2019-09/schema",
+  "$id": "https://schemas.amazon.com/selling-partners/definitions/product-types/meta-schema/v1",
+  "$vocabulary": {
+    "https://json-schema.org/draft/2019-09/vocab/core": true,
+    "https://json-schema.org/draft/2019-09/vocab/applicator": true,
+    "https://json-schema.org/draft/2019-09/vocab/validation": true,
+    "https://json-schema.org/draft/2019-09/vocab/meta-data": true,
+    "https://schemas.amazon.com/selling-partners/definitions/product-types/vocabulary/v1": true
+  },
+  "$recursiveAnchor": true,
+  "allOf": [
+    {"$ref": "https://json-schema.org/draft/2019-09/meta/core"},
+    {"$ref": "https://json-schema.org/draft/2019-09/meta/applicator"},
+    {"$ref": "https://json-schema.org/draft/2019-09/meta/validation"},
+    {"$ref": "https://json-schema.org/draft/2019-09/meta/meta-data"}
+  ]
+}`
+
+const luggageSchema = `{
+  "$schema": "https://schemas.amazon.com/selling-partners/definitions/product-types/meta-schema/v1",
+  "$id": "https://schemas.amazon.com/selling-partners/definitions/product-types/schema/v1/LUGGAGE",
+  "type": "object",
+  "required": ["item_name"],
+  "properties": {
+    "item_name": {
+      "title": "Item Name",
+      "type": "array",
+      "selectors": ["marketplace_id", "language_tag"],
+      "items": {
+        "type": "object",
+        "required": ["value", "marketplace_id"],
+        "properties": {
+          "value": {"type": "string", "maxUtf8ByteLength": 200},
+          "marketplace_id": {"type": "string"},
+          "language_tag": {"type": "string"}
+        }
+      }
+    },
+    "color": {
+      "title": "Color",
+      "type": "array",
+      "maxUniqueItems": 1,
+      "items": {"type": "object", "properties": {"value": {"type": "string"}}}
+    }
+  }
+}`
@@ -0,0 +1,166 @@
+// Package sandbox is a local stand-in for the SP-API static sandbox. It answers every operation the server's tools call
+// with the documented sandbox examples, issues Login with Amazon tokens to any client, and hosts the report, Data
+// Kiosk and schema documents those answers link to, so every tool can run without an Amazon account or network access.
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokenPath is the emulator's Login with Amazon token endpoint.
+const TokenPath = "/auth/o2/token"
+
+// documentsPath hosts the pre-signed document downloads the canned responses link to.
+const documentsPath = "/sandbox/documents/"
+
+// errorTestCase is the value Amazon's static sandbox uses to request an error response; the emulator honours it in
+// any path segment or query parameter.
+const errorTestCase = "TEST_CASE_400"
+
+// route is one canned operation.
+type route struct {
+	pattern string
+	status  int
+	// body is the response; {base} is replaced with the emulator's own URL so document links resolve to it.
+	body string
+}
+
+// NewHandler returns the emulator as an http.Handler.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	for _, r := range routes {
+		mux.Handle(r.pattern, authorized(errorCases(cannedResponse(r.status, r.body))))
+	}
+	mux.HandleFunc("POST "+TokenPath, issueToken)
+	mux.HandleFunc("GET "+documentsPath+"{name}", serveDocument)
+	mux.HandleFunc("PUT "+documentsPath+"{name}", acceptUpload)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeErrors(w, http.StatusNotFound, "NotFound", fmt.Sprintf("the sandbox emulator does not implement %s %s", r.Method, r.URL.Path))
+	})
+	return mux
+}
+
+// Server is a running emulator.
+type Server struct {
+	// URL is the base URL to use as the SP-API endpoint.
+	URL    string
+	server *http.Server
+}
+
+// Start serves the emulator on addr, such as 127.0.0.1:0 for any free local port, until Close.
+func Start(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("starting sandbox emulator: %w", err)
+	}
+	srv := &Server{
+		URL:    "http://" + listener.Addr().String(),
+		server: &http.Server{Handler: NewHandler(), ReadHeaderTimeout: 10 * time.Second},
+	}
+	go func() {
+		if err := srv.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[ERROR] sandbox emulator stopped: %v", err)
+		}
+	}()
+	return srv, nil
+}
+
+// Close stops the emulator.
+func (s *Server) Close() error {
+	return s.server.Shutdown(context.Background())
+}
+
+// authorized rejects calls without an access token, like the real sandbox, so a client that skips LWA is caught here.
+func authorized(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Access-Token") == "" {
+			writeErrors(w, http.StatusForbidden, "Unauthorized", "Access to requested resource is denied.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// errorCases answers requests that carry TEST_CASE_400 with the sandbox's invalid input error.
+func errorCases(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, errorTestCase) || strings.Contains(r.URL.RawQuery, errorTestCase) {
+			writeErrors(w, http.StatusBadRequest, "InvalidInput", "Invalid Input")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func cannedResponse(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drain write payloads so clients see the whole request accepted.
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("X-Amzn-Requestid", "sandbox-"+fmt.Sprint(time.Now().UnixNano()))
+		if body == "" {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, strings.ReplaceAll(body, "{base}", baseURL(r)))
+	})
+}
+
+// issueToken accepts any client credentials. Authorization codes also return a refresh token, as LWA does.
+func issueToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeLWAError(w, "invalid_request", err.Error())
+		return
+	}
+	switch r.PostForm.Get("grant_type") {
+	case "refresh_token":
+		writeJSON(w, http.StatusOK, `{"access_token":"Atza|sandbox-access-token","token_type":"bearer","expires_in":3600}`)
+	case "authorization_code":
+		writeJSON(w, http.StatusOK, `{"access_token":"Atza|sandbox-access-token","refresh_token":"Atzr|sandbox-refresh-token","token_type":"bearer","expires_in":3600}`)
+	default:
+		writeLWAError(w, "unsupported_grant_type", "grant_type must be refresh_token or authorization_code")
+	}
+}
+
+func serveDocument(w http.ResponseWriter, r *http.Request) {
+	doc, ok := documents[r.PathValue("name")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", doc.contentType)
+	io.WriteString(w, doc.body)
+}
+
+// acceptUpload stands in for the pre-signed S3 upload destination.
+func acceptUpload(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.Copy(io.Discard, r.Body)
+	w.WriteHeader(http.StatusOK)
+}
+
+func baseURL(r *http.Request) string {
+	return "http://" + r.Host
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	io.WriteString(w, body)
+}
+
+func writeErrors(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, fmt.Sprintf(`{"errors":[{"code":%q,"message":%q}]}`, code, message))
+}
+
+func writeLWAError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, fmt.Sprintf(`{"error":%q,"error_description":%q}`, code, description))
+}
@@ -0,0 +1,209 @@
+package sandbox_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/sandbox"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/tools"
+)
+
+var contentDocument = map[string]any{
+	"name":        "Spring launch",
+	"contentType": "EBC",
+	"locale":      "en-US",
+	"contentModuleList": []any{map[string]any{
+		"contentModuleType": "STANDARD_TEXT",
+		"standardText": map[string]any{
+			"headline": map[string]any{"value": "Built to last"},
+			"body":     map[string]any{"textList": []any{map[string]any{"value": "Every seam is double-stitched."}}},
+		},
+	}},
+}
+
+// sampleArguments are the arguments each tool is called with, taken from the sandbox examples.
+func sampleArguments(t *testing.T, uploadDir string) map[string]map[string]any {
+	upload := filepath.Join(uploadDir, "banner.png")
+	if err := os.WriteFile(upload, []byte("\x89PNG\r\n\x1a\nsandbox"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	us := []any{"ATVPDKIKX0DER"}
+	order := "902-1845936-5435065"
+
+	return map[string]map[string]any{
+		"orders.listOrders":             {"marketplaceIds": us, "createdAfter": "2020-01-01T00:00:00Z"},
+		"orders.getOrder":               {"amazonOrderId": order},
+		"orders.getOrderAddress":        {"amazonOrderId": order},
+		"orders.getOrderBuyerInfo":      {"amazonOrderId": order},
+		"orders.getOrderItems":          {"amazonOrderId": order},
+		"orders.getOrderItemsBuyerInfo": {"amazonOrderId": order},
+		"orders.confirmShipment": {"amazonOrderId": order, "marketplaceId": "ATVPDKIKX0DER", "packageReferenceId": "1",
+			"carrierCode": "UPS", "trackingNumber": "1Z6A34Y60369738804", "orderItems": []any{map[string]any{"orderItemId": "05015851154158", "quantity": 1}}},
+		"orders.updateShipmentStatus":     {"amazonOrderId": order, "marketplaceId": "ATVPDKIKX0DER", "shipmentStatus": "ReadyForPickup"},
+		"orders.getOrderRegulatedInfo":    {"amazonOrderId": order},
+		"orders.updateVerificationStatus": {"amazonOrderId": order, "status": "Approved"},
+		"orders.getOrderItemsApprovals":   {"amazonOrderId": order},
+
+		"sync.orders":          {"marketplaceIds": us},
+		"store.queryOrders":    {},
+		"sync.inventory":       {"marketplaceIds": us},
+		"sync.financialEvents": {"postedAfter": "2020-02-01T00:00:00Z", "postedBefore": "2020-03-01T00:00:00Z"},
+		"store.importReport":   {"reportDocumentId": "0356cf79-b8b0-4226-b4b9-0ee058ea5760", "reportType": "GET_MERCHANT_LISTINGS_ALL_DATA"},
+		"analytics.sql":        {"query": "SELECT COUNT(*) AS n FROM orders"},
+
+		"sales.getOrderMetrics":                {"marketplaceIds": us, "interval": "2018-09-01T00:00:00-07:00--2018-09-04T00:00:00-07:00", "granularity": "Total"},
+		"fbaInventory.getInventorySummaries":   {"granularityType": "Marketplace", "granularityId": "ATVPDKIKX0DER", "marketplaceIds": us},
+		"productPricing.getPricing":            {"marketplaceId": "ATVPDKIKX0DER", "itemType": "Asin", "asins": []any{"B00V5DG6IQ"}},
+		"productPricing.getCompetitivePricing": {"marketplaceId": "ATVPDKIKX0DER", "itemType": "Asin", "asins": []any{"B00V5DG6IQ"}},
+		"listings.getListingsRestrictions":     {"asin": "B0000ASIN1", "marketplaceIds": us, "sellerId": "AXXXXXXXXXXXXX"},
+		"listings.checkSellability":            {"asin": "B0000ASIN1", "marketplaceIds": us, "sellerId": "AXXXXXXXXXXXXX"},
+
+		"reports.getReports":        {"reportTypes": []any{"GET_MERCHANT_LISTINGS_ALL_DATA"}},
+		"reports.createReport":      {"reportType": "GET_MERCHANT_LISTINGS_ALL_DATA", "marketplaceIds": us},
+		"reports.getReport":         {"reportId": "ReportId1"},
+		"reports.getReportDocument": {"reportDocumentId": "0356cf79-b8b0-4226-b4b9-0ee058ea5760"},
+
+		"dataKiosk.createQuery":      {"query": "query { analytics_salesAndTraffic_2023_11_15 { salesAndTrafficByDate(startDate: \"2023-01-01\", endDate: \"2023-01-31\", aggregateBy: DAY, marketplaceIds: [\"ATVPDKIKX0DER\"]) { startDate } } }"},
+		"dataKiosk.getQueries":       {},
+		"dataKiosk.getQuery":         {"queryId": "QueryId1"},
+		"dataKiosk.cancelQuery":      {"queryId": "QueryId1"},
+		"dataKiosk.getDocument":      {"documentId": "DataDocumentId1"},
+		"dataKiosk.listSavedQueries": {},
+		"dataKiosk.runSavedQuery":    {"name": "salesAndTrafficByDate", "startDate": "2023-01-01", "endDate": "2023-01-31", "marketplaceIds": us, "wait": true},
+
+		"uploads.createUploadDestinationForResource": {"resource": "aplus/2020-11-01/contentDocuments", "marketplaceIds": us, "contentMD5": "1B2M2Y8AsgTpgAmY7PhCfg=="},
+		"uploads.uploadFile":                         {"filePath": upload, "resource": "aplus/2020-11-01/contentDocuments", "marketplaceIds": us},
+
+		"aplus.searchContentDocuments":           {"marketplaceId": "ATVPDKIKX0DER"},
+		"aplus.getContentDocument":               {"contentReferenceKey": "sandbox-content-1", "marketplaceId": "ATVPDKIKX0DER"},
+		"aplus.validateContentDocument":          {"marketplaceId": "ATVPDKIKX0DER", "contentDocument": contentDocument},
+		"aplus.createContentDocument":            {"marketplaceId": "ATVPDKIKX0DER", "contentDocument": contentDocument},
+		"aplus.updateContentDocument":            {"contentReferenceKey": "sandbox-content-1", "marketplaceId": "ATVPDKIKX0DER", "contentDocument": contentDocument},
+		"aplus.postContentDocumentAsinRelations": {"contentReferenceKey": "sandbox-content-1", "marketplaceId": "ATVPDKIKX0DER", "asins": []any{"B0000ASIN1"}},
+
+		"productTypeDefinitions.searchDefinitionsProductTypes": {"marketplaceIds": us},
+		"productTypeDefinitions.getDefinitionsProductType":     {"productType": "LUGGAGE", "marketplaceIds": us},
+		"productTypeDefinitions.validateListingAttributes": {"productType": "LUGGAGE", "marketplaceIds": us,
+			"attributes": map[string]any{"item_name": []any{map[string]any{"value": "Carry-on", "marketplace_id": "ATVPDKIKX0DER", "language_tag": "en_US"}}}},
+
+		"vendorOrders.getPurchaseOrders":     {"createdAfter": "2019-08-20T00:00:00Z", "createdBefore": "2019-08-21T00:00:00Z"},
+		"vendorOrders.getPurchaseOrder":      {"purchaseOrderNumber": "4Z32PABC"},
+		"vendorOrders.submitAcknowledgement": {"documents": []any{map[string]any{"purchaseOrderNumber": "4Z32PABC", "sellingParty": map[string]any{"partyId": "XYZ12"}, "acknowledgementDate": "2019-08-21T07:00:00Z", "items": []any{}}}},
+		"vendorInvoices.submitInvoices":      {"documents": []any{map[string]any{"invoiceType": "Invoice", "id": "TestInvoice202", "date": "2019-08-21T07:00:00Z"}}},
+		"vendor.getTransactionStatus":        {"transactionId": "20190904190535-eef8cad8-418e-4ed3-ac72-789e2ee6214a"},
+
+		"vendorDirectFulfillment.getOrders":                   {"createdAfter": "2019-08-20T00:00:00Z", "createdBefore": "2019-08-21T00:00:00Z"},
+		"vendorDirectFulfillment.getOrder":                    {"purchaseOrderNumber": "2JK3S9VC"},
+		"vendorDirectFulfillment.submitAcknowledgement":       {"documents": []any{map[string]any{"purchaseOrderNumber": "2JK3S9VC", "vendorOrderNumber": "V123", "acknowledgementDate": "2019-08-21T07:00:00Z", "acknowledgementStatus": map[string]any{"code": "00"}}}},
+		"vendorDirectFulfillment.getShippingLabels":           {"createdAfter": "2019-08-20T00:00:00Z", "createdBefore": "2019-08-21T00:00:00Z"},
+		"vendorDirectFulfillment.getShippingLabel":            {"purchaseOrderNumber": "2JK3S9VC"},
+		"vendorDirectFulfillment.submitShipmentConfirmations": {"documents": []any{map[string]any{"purchaseOrderNumber": "2JK3S9VC", "shipmentDetails": map[string]any{"shippedDate": "2019-08-22T07:00:00Z", "shipmentStatus": "SHIPPED"}}}},
+		"vendorInvoices.submitDirectFulfillmentInvoices":      {"documents": []any{map[string]any{"invoiceNumber": "I1234", "invoiceDate": "2019-08-22T07:00:00Z"}}},
+	}
+}
+
+func TestEveryToolRunsAgainstTheEmulator(t *testing.T) {
+	emulator, err := sandbox.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer emulator.Close()
+
+	client, err := spapi.NewClient(spapi.Config{
+		Endpoint:    emulator.URL,
+		Credentials: spapi.Credentials{ClientID: "sandbox", ClientSecret: "sandbox", RefreshToken: "Atzr|sandbox"},
+		LWAEndpoint: emulator.URL + sandbox.TokenPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderStore, err := store.Open(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer orderStore.Close()
+
+	uploadDir := t.TempDir()
+	arguments := sampleArguments(t, uploadDir)
+	for _, vendor := range []bool{false, true} {
+		registry, err := tools.NewRegistry(tools.Dependencies{SellingPartner: client, AllowWrites: true, Vendor: vendor, Store: orderStore, UploadDir: uploadDir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		available := make(map[string]bool)
+		for _, info := range registry.Catalog() {
+			available[info.Name] = !info.Placeholder && info.Unavailable == ""
+		}
+		for _, tool := range registry.Tools() {
+			name := tool.Tool.Name
+			// Only tools that call SP-API or the store belong here; the rest need onboarding or an audit log.
+			if !available[name] || strings.HasPrefix(name, "auth.") || strings.HasPrefix(name, "admin.") {
+				continue
+			}
+			args, ok := arguments[name]
+			if !ok {
+				t.Errorf("%s: no sample arguments", name)
+				continue
+			}
+			var req mcp.CallToolRequest
+			req.Params.Name, req.Params.Arguments = name, args
+			result, err := tool.Handler(context.Background(), req)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if result.IsError {
+				t.Errorf("%s failed against the emulator: %s", name, resultText(result))
+			}
+		}
+	}
+}
+
+func TestErrorTestCaseAndMissingToken(t *testing.T) {
+	emulator, err := sandbox.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer emulator.Close()
+
+	get := func(path string, token bool) int {
+		req, _ := http.NewRequest(http.MethodGet, emulator.URL+path, nil)
+		if token {
+			req.Header.Set("X-Amz-Access-Token", "Atza|sandbox")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := get("/orders/v0/orders/TEST_CASE_400", true); status != http.StatusBadRequest {
+		t.Fatalf("TEST_CASE_400 should fail with 400, got %d", status)
+	}
+	if status := get("/orders/v0/orders/902-1845936-5435065", false); status != http.StatusForbidden {
+		t.Fatalf("calls without an access token should be refused, got %d", status)
+	}
+	if status := get("/catalog/2022-04-01/items", true); status != http.StatusNotFound {
+		t.Fatalf("unimplemented operations should be 404, got %d", status)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,274 @@
+package spapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+)
+
+// Cassette is an http.RoundTripper that records SP-API exchanges to a file, or replays a recorded file without network
+// access. Replay matches requests by method, path and normalised query; the host is ignored, so a cassette recorded
+// against one regional endpoint replays against any.
+type Cassette struct {
+	path string
+	// base sends requests while recording; nil means the cassette replays.
+	base     http.RoundTripper
+	redactor *redact.Redactor
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Interaction is one recorded exchange.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request. Query is normalised; see normalizeQuery.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+}
+
+// RecordedResponse is a redacted response. JSON bodies are kept as JSON so cassettes stay readable and diffable; any
+// other body, such as a TSV report, is kept as Text.
+type RecordedResponse struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// recordedHeaders are the only response headers kept; the rest carry request IDs, cookies and timing that make
+// cassettes noisy without helping replay.
+var recordedHeaders = []string{"Content-Type", "X-Amzn-Ratelimit-Limit"}
+
+// redactedQuery lists query parameters that carry buyer PII or, in pre-signed upload URLs, credentials. Their values
+// are replaced in both modes, so replay still matches.
+var redactedQuery = map[string]bool{"buyeremail": true, "x-amz-signature": true, "x-amz-credential": true, "x-amz-security-token": true}
+
+// presignedParams matches the credentials in pre-signed S3 links, such as report document URLs.
+var presignedParams = regexp.MustCompile(`(X-Amz-(?:Signature|Credential|Security-Token)=)[^&"\\\s]+`)
+
+// RecordCassette returns a cassette that sends requests through base, or http.DefaultTransport when nil, and rewrites
+// path after every exchange, so an interrupted session still leaves a usable file. Buyer PII is masked, pre-signed
+// URL credentials are removed and request headers, including the access token, are never written.
+func RecordCassette(path string, base http.RoundTripper) (*Cassette, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	redactor, err := redact.New(redact.Policy{Default: redact.ActionMask})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating cassette directory: %w", err)
+	}
+	return &Cassette{path: path, base: base, redactor: redactor}, nil
+}
+
+// LoadCassette returns a cassette that replays path. Each recorded interaction answers once, in order; once every
+// match has been used the last one answers again, so retries and repeated reads still replay.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	// Normalise again so hand-edited cassettes may list query parameters in any order or form.
+	for i := range file.Interactions {
+		query, err := url.ParseQuery(file.Interactions[i].Request.Query)
+		if err != nil {
+			return nil, fmt.Errorf("parsing cassette %s: interaction %d: %w", path, i, err)
+		}
+		file.Interactions[i].Request.Query = normalizeQuery(query)
+	}
+	return &Cassette{path: path, interactions: file.Interactions, used: make([]bool, len(file.Interactions))}, nil
+}
+
+// Interactions returns the recorded exchanges.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip records or replays req.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := RecordedRequest{Method: req.Method, Path: req.URL.Path, Query: normalizeQuery(req.URL.Query())}
+	if c.base == nil {
+		return c.replay(req, recorded)
+	}
+	return c.record(req, recorded)
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for i, interaction := range c.interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		match = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		target := recorded.Path
+		if recorded.Query != "" {
+			target += "?" + recorded.Query
+		}
+		return nil, fmt.Errorf("cassette %s has no interaction for %s %s", c.path, recorded.Method, target)
+	}
+	c.used[match] = true
+
+	response := c.interactions[match].Response
+	body := []byte(response.Text)
+	if len(response.Body) > 0 {
+		body = response.Body
+	}
+	header := response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (c *Cassette) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response to record: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := RecordedResponse{Status: resp.StatusCode, Header: make(http.Header)}
+	for _, key := range recordedHeaders {
+		if values := resp.Header.Values(key); len(values) > 0 {
+			response.Header[key] = values
+		}
+	}
+	if redacted := c.redactBody(body); json.Valid(redacted) {
+		response.Body = redacted
+	} else {
+		response.Text = string(redacted)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{Request: recorded, Response: response})
+	c.used = append(c.used, true)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// redactBody masks buyer PII in a JSON body and strips pre-signed URL credentials from any body. PII is replaced in
+// the original text where that gives the same result as redacting the decoded value, so numbers and field order
+// survive exactly as SP-API sent them; PII that is not a plain string, such as a BuyerTaxInfo object, needs the
+// decoded value re-encoded instead.
+func (c *Cassette) redactBody(body []byte) []byte {
+	text := string(body)
+	var generic any
+	if json.Unmarshal(body, &generic) == nil {
+		if result, err := c.redactor.Value(generic); err == nil && result.Changed() {
+			text = result.Text(text)
+			var rewritten any
+			if json.Unmarshal([]byte(text), &rewritten) != nil || !reflect.DeepEqual(rewritten, result.Value) {
+				encoded, err := json.Marshal(result.Value)
+				if err != nil {
+					return nil
+				}
+				text = string(encoded)
+			}
+		}
+	}
+	return []byte(presignedParams.ReplaceAllString(text, "${1}REDACTED"))
+}
+
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return errors.Join(fmt.Errorf("writing cassette: %w", err), os.Remove(tmp))
+	}
+	return nil
+}
+
+// normalizeQuery makes equivalent queries compare equal: array parameters sent repeated or comma-separated, in any
+// order, normalise to the same sorted, comma-joined form.
+func normalizeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		var values []string
+		for _, value := range query[key] {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = append(values, item)
+				}
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if redactedQuery[strings.ToLower(key)] {
+			values = []string{"REDACTED"}
+		}
+		sort.Strings(values)
+		parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(strings.Join(values, ",")))
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,116 @@
+package spapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordsRedactedAndReplays(t *testing.T) {
+	var upstreamCalls int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamCalls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Amzn-Requestid", "c0ffee")
+		switch r.URL.Path {
+		case "/orders/v0/orders/111/buyerInfo":
+			io.WriteString(w, `{"payload":{"AmazonOrderId":"111","BuyerEmail":"jane.doe@example.com","BuyerName":"Jane Doe","Total":10.50}}`)
+		case "/orders/v0/orders/111/buyerInfo/tax":
+			io.WriteString(w, `{"payload":{"AmazonOrderId":"111","BuyerTaxInfo":{"CompanyLegalName":"Doe Holdings"}}}`)
+		case "/reports/2021-06-30/documents/doc-1":
+			io.WriteString(w, `{"reportDocumentId":"doc-1","url":"https://tortuga-prod-na.s3.amazonaws.com/doc-1?X-Amz-Credential=AKIAEXAMPLE%2F20250101&X-Amz-Signature=abc123&X-Amz-Expires=300"}`)
+		default:
+			w.Header().Set("Content-Type", "text/tab-separated-values")
+			io.WriteString(w, "sku\tqty\nSKU-1\t3\n")
+		}
+	}))
+	defer upstream.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "session.json")
+	recorder, err := RecordCassette(path, nil)
+	if err != nil {
+		t.Fatalf("RecordCassette: %v", err)
+	}
+	get := func(client *http.Client, target string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-Amz-Access-Token", "Atza|secret-access-token")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", target, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	recording := &http.Client{Transport: recorder}
+	if _, body := get(recording, upstream.URL+"/orders/v0/orders/111/buyerInfo"); !strings.Contains(body, "jane.doe@example.com") {
+		t.Fatalf("the caller should see the unredacted response while recording, got %s", body)
+	}
+	get(recording, upstream.URL+"/orders/v0/orders/111/buyerInfo/tax")
+	get(recording, upstream.URL+"/reports/2021-06-30/documents/doc-1")
+	get(recording, upstream.URL+"/download?b=2&a=1")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	for _, leak := range []string{"jane.doe@example.com", "Jane Doe", "Doe Holdings", "abc123", "AKIAEXAMPLE", "secret-access-token", "c0ffee"} {
+		if strings.Contains(string(data), leak) {
+			t.Errorf("cassette contains %q:\n%s", leak, data)
+		}
+	}
+	if !strings.Contains(string(data), "10.50") || !strings.Contains(string(data), "X-Amz-Expires=300") {
+		t.Errorf("cassette should keep non-PII values exactly:\n%s", data)
+	}
+
+	player, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette: %v", err)
+	}
+	replaying := &http.Client{Transport: player}
+	calls := upstreamCalls
+
+	status, body := get(replaying, "https://sellingpartnerapi-eu.amazon.com/orders/v0/orders/111/buyerInfo")
+	if status != http.StatusOK || !strings.Contains(body, `"AmazonOrderId": "111"`) || strings.Contains(body, "jane.doe") {
+		t.Errorf("replayed buyerInfo: %d %s", status, body)
+	}
+	if _, body := get(replaying, "https://example.com/download?a=1&b=2"); body != "sku\tqty\nSKU-1\t3\n" {
+		t.Errorf("replayed text body = %q", body)
+	}
+	if upstreamCalls != calls {
+		t.Errorf("replay reached the network")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/orders/v0/orders/222", nil)
+	if _, err := replaying.Do(req); err == nil || !strings.Contains(err.Error(), "no interaction for GET /orders/v0/orders/222") {
+		t.Errorf("unmatched request error = %v", err)
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"MarketplaceIds=B&MarketplaceIds=A", "MarketplaceIds=A,B"},
+		{"reportTypes=X,Y&pageSize=10", "pageSize=10&reportTypes=Y&reportTypes=X"},
+		{"NextToken=&Asins=1", "Asins=1"},
+		{"BuyerEmail=a%40example.com", "BuyerEmail=b%40example.com"},
+	}
+	for _, tc := range tests {
+		a, errA := url.ParseQuery(tc.a)
+		b, errB := url.ParseQuery(tc.b)
+		if errA != nil || errB != nil {
+			t.Fatalf("parsing %q or %q: %v %v", tc.a, tc.b, errA, errB)
+		}
+		if normalizeQuery(a) != normalizeQuery(b) {
+			t.Errorf("normalizeQuery(%q) = %q, normalizeQuery(%q) = %q", tc.a, normalizeQuery(a), tc.b, normalizeQuery(b))
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package spapi
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 
-	sp "github.com/amzapi/selling-partner-api-sdk/pkg/selling-partner"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client defines the behaviour expected by MCP tools that need to call SP-API endpoints.
@@ -12,20 +17,43 @@ type Client interface {
 	AuthorizeRequest(req *http.Request) error
 	Endpoint() string
 	Status() Status
+	// CheckCredentials performs a fresh access token exchange, so a revoked token shows up before a tool call fails.
+	CheckCredentials(ctx context.Context) error
 }
 
 // Status captures the readiness of the underlying SP-API integration.
 type Status struct {
 	Ready   bool
 	Message string
+	// TokenExpiresAt is when the current access token expires; zero before the first exchange.
+	TokenExpiresAt time.Time
+	// LastRefresh is when an access token was last obtained.
+	LastRefresh time.Time
+	// LastError is the most recent failed exchange, cleared by the next successful one.
+	LastError   string
+	LastErrorAt time.Time
+	// Revoked is set when LWA rejected the refresh token, which needs the seller to authorize the app again. The client
+	// is not Ready until a later exchange succeeds, so tools stop early instead of each failing at LWA.
+	Revoked bool
 }
 
 // Config contains all runtime settings required to initialise the Selling Partner API client.
 type Config struct {
 	Endpoint    string
 	Credentials Credentials
+	// Source, when set, is asked for fresh credentials every RefreshInterval and after a failed token refresh (at most
+	// once a minute), so rotated secrets take effect without a restart.
+	Source          CredentialSource
+	RefreshInterval time.Duration
+	// LWAEndpoint overrides DefaultLWAEndpoint, for tests and sandboxes.
+	LWAEndpoint string
+	// HTTPClient sends token exchanges; nil uses http.DefaultClient.
+	HTTPClient *http.Client
 }
 
+// CredentialSource returns the current credentials, resolving secret references again.
+type CredentialSource func(ctx context.Context) (Credentials, error)
+
 // Credentials mirrors the SP-API secrets required to sign requests.
 type Credentials struct {
 	ClientID     string
@@ -38,31 +66,177 @@ func (c Credentials) IsComplete() bool {
 	return c.ClientID != "" && c.ClientSecret != "" && c.RefreshToken != ""
 }
 
+// expiryMargin refreshes access tokens a little early so clock skew never sends an expired one.
+const expiryMargin = time.Minute
+
+// failedRefreshReloadGap spaces out the credential resolutions a failed token refresh triggers, so a broken refresh
+// token does not run the secret helper for every request.
+const failedRefreshReloadGap = time.Minute
+
 // NewClient builds either a fully-initialised SP-API client or a noop placeholder when credentials are absent.
 func NewClient(cfg Config) (Client, error) {
 	if !cfg.Credentials.IsComplete() {
 		return &noopClient{endpoint: cfg.Endpoint, reason: "selling partner credentials are not configured"}, nil
 	}
 
-	spClient, err := sp.NewSellingPartner(&sp.Config{
-		ClientID:     cfg.Credentials.ClientID,
-		ClientSecret: cfg.Credentials.ClientSecret,
-		RefreshToken: cfg.Credentials.RefreshToken,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("initialising selling partner client: %w", err)
+	lwaEndpoint := cfg.LWAEndpoint
+	if lwaEndpoint == "" {
+		lwaEndpoint = DefaultLWAEndpoint
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
 	}
 
-	return &sellingPartnerClient{endpoint: cfg.Endpoint, client: spClient}, nil
+	return &sellingPartnerClient{
+		endpoint:    cfg.Endpoint,
+		lwaEndpoint: lwaEndpoint,
+		httpClient:  httpClient,
+		source:      cfg.Source,
+		interval:    cfg.RefreshInterval,
+		creds:       cfg.Credentials,
+		resolvedAt:  time.Now(),
+	}, nil
 }
 
 type sellingPartnerClient struct {
-	endpoint string
-	client   *sp.SellingPartner
+	endpoint    string
+	lwaEndpoint string
+	httpClient  *http.Client
+	source      CredentialSource
+	interval    time.Duration
+
+	// renewals collapses concurrent renewals into one credential resolution and one LWA exchange.
+	renewals singleflight.Group
+
+	// mu guards the fields below. It is never held while credentials resolve or a token is exchanged.
+	mu          sync.Mutex
+	creds       Credentials
+	resolvedAt  time.Time
+	token       Token
+	lastRefresh time.Time
+	lastError   error
+	lastErrorAt time.Time
 }
 
 func (c *sellingPartnerClient) AuthorizeRequest(req *http.Request) error {
-	return c.client.AuthorizeRequest(req)
+	c.mu.Lock()
+	token, current := c.token, usable(c.token) && !c.reloadDue()
+	c.mu.Unlock()
+
+	if !current {
+		renewed, err, _ := c.renewals.Do("token", func() (any, error) {
+			return c.renew(req.Context(), false)
+		})
+		if err != nil {
+			return fmt.Errorf("cannot refresh token: %w", err)
+		}
+		token = renewed.(Token)
+	}
+
+	req.Header.Set("X-Amz-Access-Token", token.AccessToken)
+	return nil
+}
+
+func (c *sellingPartnerClient) CheckCredentials(ctx context.Context) error {
+	_, err, _ := c.renewals.Do("check", func() (any, error) {
+		return c.renew(ctx, true)
+	})
+	return err
+}
+
+// renew resolves the credentials when they are due (or when forced) and exchanges the refresh token unless the cached
+// access token is still good. A forced renewal always exchanges, so a revoked token shows up.
+func (c *sellingPartnerClient) renew(ctx context.Context, force bool) (Token, error) {
+	c.mu.Lock()
+	reload := c.source != nil && (force || c.reloadDue())
+	c.mu.Unlock()
+	if reload {
+		c.reload(ctx)
+	}
+
+	c.mu.Lock()
+	creds, token := c.creds, c.token
+	c.mu.Unlock()
+	if !force && usable(token) {
+		return token, nil
+	}
+
+	token, err := c.refresh(ctx, creds)
+	// A rejected token is the usual sign of a rotation the interval has not caught yet.
+	if err != nil && c.source != nil && !reload && c.failedRefreshMayReload() && c.reload(ctx) {
+		c.mu.Lock()
+		creds = c.creds
+		c.mu.Unlock()
+		token, err = c.refresh(ctx, creds)
+	}
+	return token, err
+}
+
+// usable reports whether token can sign a request without a refresh.
+func usable(token Token) bool {
+	return token.AccessToken != "" && time.Until(token.ExpiresAt) >= expiryMargin
+}
+
+// reloadDue reports whether the refresh interval has passed since credentials were last resolved. Callers hold mu.
+func (c *sellingPartnerClient) reloadDue() bool {
+	return c.source != nil && c.interval > 0 && time.Since(c.resolvedAt) >= c.interval
+}
+
+func (c *sellingPartnerClient) failedRefreshMayReload() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Since(c.resolvedAt) >= failedRefreshReloadGap
+}
+
+// refresh exchanges the refresh token in creds and records the outcome for Status. LWA may hand back a new refresh
+// token; it replaces the old one for the rest of the process unless the credentials rotated in the meantime.
+func (c *sellingPartnerClient) refresh(ctx context.Context, creds Credentials) (Token, error) {
+	token, err := exchangeRefreshToken(ctx, c.httpClient, c.lwaEndpoint, creds)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.lastError, c.lastErrorAt = err, time.Now()
+		return Token{}, err
+	}
+
+	if token.RefreshToken != "" && token.RefreshToken != creds.RefreshToken && c.creds == creds {
+		log.Printf("[WARN] spapi: LWA issued a new refresh token; store it in SP_API_REFRESH_TOKEN before the old one stops working")
+		c.creds.RefreshToken = token.RefreshToken
+	}
+	c.token, c.lastRefresh, c.lastError = token, time.Now(), nil
+	return token, nil
+}
+
+// reload resolves the credentials again and drops the cached access token when they changed. A failed resolution keeps
+// the current credentials, since an unreachable secret store should not stop calls that still work.
+func (c *sellingPartnerClient) reload(ctx context.Context) bool {
+	c.mu.Lock()
+	c.resolvedAt = time.Now()
+	c.mu.Unlock()
+
+	creds, err := c.source(ctx)
+	if err != nil {
+		log.Printf("[WARN] spapi: resolving credentials failed, keeping the current ones: %v", err)
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if creds == c.creds {
+		return false
+	}
+	if !creds.IsComplete() {
+		log.Printf("[WARN] spapi: rotated credentials are incomplete, keeping the current ones")
+		return false
+	}
+
+	log.Printf("[INFO] spapi: credentials changed; using the rotated secrets")
+	c.creds, c.token = creds, Token{}
+	return true
 }
 
 func (c *sellingPartnerClient) Endpoint() string {
@@ -70,7 +244,20 @@ func (c *sellingPartnerClient) Endpoint() string {
 }
 
 func (c *sellingPartnerClient) Status() Status {
-	return Status{Ready: true}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := Status{Ready: true, TokenExpiresAt: c.token.ExpiresAt, LastRefresh: c.lastRefresh, LastErrorAt: c.lastErrorAt}
+	if c.lastError != nil {
+		status.LastError = c.lastError.Error()
+		var lwaErr *LWAError
+		status.Revoked = errors.As(c.lastError, &lwaErr) && lwaErr.Revoked()
+	}
+	if status.Revoked {
+		status.Ready = false
+		status.Message = "LWA rejected the refresh token; it was revoked or has expired. Authorize the app again, for example with auth.beginAuthorization and overwrite, or set a new SP_API_REFRESH_TOKEN."
+	}
+	return status
 }
 
 type noopClient struct {
@@ -89,3 +276,7 @@ func (c *noopClient) Endpoint() string {
 func (c *noopClient) Status() Status {
 	return Status{Ready: false, Message: c.reason}
 }
+
+func (c *noopClient) CheckCredentials(_ context.Context) error {
+	return errors.New(c.reason)
+}
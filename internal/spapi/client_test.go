@@ -0,0 +1,208 @@
+package spapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeLWA answers token exchanges for refreshToken and rejects any other token as revoked.
+func fakeLWA(t *testing.T, refreshToken string) (*httptest.Server, *int) {
+	t.Helper()
+	var exchanges int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != refreshToken {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"The request has an invalid grant parameter : refresh_token"}`)
+			return
+		}
+		exchanges++
+		fmt.Fprintf(w, `{"access_token":"Atza|%d","token_type":"bearer","expires_in":3600}`, exchanges)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &exchanges
+}
+
+func TestAuthorizeRequestCachesAccessToken(t *testing.T) {
+	lwa, exchanges := fakeLWA(t, "Atzr|valid")
+	client, err := NewClient(Config{
+		Credentials: Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|valid"},
+		LWAEndpoint: lwa.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/orders/v0/orders", nil)
+		if err := client.AuthorizeRequest(req); err != nil {
+			t.Fatalf("AuthorizeRequest: %v", err)
+		}
+		if got := req.Header.Get("X-Amz-Access-Token"); got != "Atza|1" {
+			t.Fatalf("expected the cached access token, got %q", got)
+		}
+	}
+	if *exchanges != 1 {
+		t.Fatalf("expected one exchange, got %d", *exchanges)
+	}
+
+	status := client.Status()
+	if status.LastRefresh.IsZero() || time.Until(status.TokenExpiresAt) < 59*time.Minute || status.LastError != "" {
+		t.Fatalf("unexpected status after a refresh: %+v", status)
+	}
+}
+
+func TestCheckCredentialsReportsRevocation(t *testing.T) {
+	lwa, _ := fakeLWA(t, "Atzr|valid")
+	client, err := NewClient(Config{
+		Credentials: Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|revoked"},
+		LWAEndpoint: lwa.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	err = client.CheckCredentials(context.Background())
+	var lwaErr *LWAError
+	if !errors.As(err, &lwaErr) || !lwaErr.Revoked() {
+		t.Fatalf("expected a revoked refresh token, got %v", err)
+	}
+	if status := client.Status(); !status.Revoked || status.LastError == "" || status.LastErrorAt.IsZero() {
+		t.Fatalf("status should carry the failure, got %+v", status)
+	}
+	if status := client.Status(); status.Ready || !strings.Contains(status.Message, "Authorize the app again") {
+		t.Fatalf("a revoked token should make the client not ready and say how to fix it, got %+v", status)
+	}
+
+	// Once the seller authorizes again, the next exchange clears the failure.
+	client.(*sellingPartnerClient).creds.RefreshToken = "Atzr|valid"
+	if err := client.CheckCredentials(context.Background()); err != nil {
+		t.Fatalf("CheckCredentials: %v", err)
+	}
+	if status := client.Status(); !status.Ready || status.Revoked || status.Message != "" {
+		t.Fatalf("expected a ready client after recovery, got %+v", status)
+	}
+}
+
+func TestReloadDropsTokenOnRotation(t *testing.T) {
+	current := Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|first"}
+	var failing bool
+	client, err := NewClient(Config{
+		Credentials: current,
+		Source: func(context.Context) (Credentials, error) {
+			if failing {
+				return Credentials{}, errors.New("secret store unreachable")
+			}
+			return current, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	spClient := client.(*sellingPartnerClient)
+	spClient.token = Token{AccessToken: "Atza|cached", ExpiresAt: time.Now().Add(time.Hour)}
+
+	if spClient.reload(context.Background()) || spClient.token.AccessToken == "" {
+		t.Fatalf("unchanged credentials should keep the access token")
+	}
+
+	current.RefreshToken = "Atzr|second"
+	if !spClient.reload(context.Background()) || spClient.token.AccessToken != "" || spClient.creds.RefreshToken != "Atzr|second" {
+		t.Fatalf("rotated credentials should drop the access token")
+	}
+
+	failing = true
+	if spClient.reload(context.Background()) || spClient.creds.RefreshToken != "Atzr|second" {
+		t.Fatalf("a failed resolution should keep the current credentials")
+	}
+}
+
+func TestConcurrentRequestsShareOneRefresh(t *testing.T) {
+	release := make(chan struct{})
+	var exchanges atomic.Int32
+	lwa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"Atza|shared","token_type":"bearer","expires_in":3600}`)
+	}))
+	t.Cleanup(lwa.Close)
+
+	client, err := NewClient(Config{
+		Credentials: Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|valid"},
+		LWAEndpoint: lwa.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/orders/v0/orders", nil)
+			if err := client.AuthorizeRequest(req); err != nil {
+				errs <- err
+			} else if got := req.Header.Get("X-Amz-Access-Token"); got != "Atza|shared" {
+				errs <- fmt.Errorf("unexpected access token %q", got)
+			}
+		}()
+	}
+
+	// Status must not wait behind the exchange.
+	for exchanges.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	client.Status()
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if got := exchanges.Load(); got != 1 {
+		t.Fatalf("expected one shared exchange, got %d", got)
+	}
+}
+
+func TestFailedRefreshReloadsAtMostOncePerGap(t *testing.T) {
+	lwa, _ := fakeLWA(t, "Atzr|valid")
+	var resolutions int
+	client, err := NewClient(Config{
+		Credentials: Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|revoked"},
+		LWAEndpoint: lwa.URL,
+		Source: func(context.Context) (Credentials, error) {
+			resolutions++
+			return Credentials{ClientID: "id", ClientSecret: "secret", RefreshToken: "Atzr|revoked"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	// Credentials resolved at start-up; the first failed refresh may look again once the gap has passed.
+	client.(*sellingPartnerClient).resolvedAt = time.Now().Add(-failedRefreshReloadGap)
+
+	for range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/orders/v0/orders", nil)
+		if err := client.AuthorizeRequest(req); err == nil {
+			t.Fatalf("expected a revoked refresh token to fail")
+		}
+	}
+	if resolutions != 1 {
+		t.Fatalf("expected one credential resolution, got %d", resolutions)
+	}
+}
@@ -0,0 +1,128 @@
+package spapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultLWAEndpoint is the Login with Amazon token endpoint shared by every SP-API region.
+const DefaultLWAEndpoint = "https://api.amazon.com/auth/o2/token"
+
+// lwaTimeout bounds one token exchange; LWA normally answers in well under a second.
+const lwaTimeout = 30 * time.Second
+
+// LWAError is an error response from the token endpoint.
+type LWAError struct {
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *LWAError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("LWA token exchange failed: %s (%s)", e.Code, e.Description)
+	}
+	return fmt.Sprintf("LWA token exchange failed: %s (HTTP %d)", e.Code, e.StatusCode)
+}
+
+// Revoked reports whether LWA rejected the refresh token or authorization code itself, which happens when the seller
+// revokes the app, the authorization lapses or the token is rotated away.
+func (e *LWAError) Revoked() bool {
+	return e.Code == "invalid_grant"
+}
+
+// ClientRejected reports whether LWA rejected the app's client ID or secret, as after a client secret rotation.
+func (e *LWAError) ClientRejected() bool {
+	return e.Code == "invalid_client" || e.Code == "unauthorized_client"
+}
+
+// Token is the result of an LWA exchange.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// exchangeRefreshToken trades a refresh token for an access token.
+func exchangeRefreshToken(ctx context.Context, httpClient *http.Client, endpoint string, creds Credentials) (Token, error) {
+	return postLWA(ctx, httpClient, endpoint, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {creds.RefreshToken},
+		"client_id":     {creds.ClientID},
+		"client_secret": {creds.ClientSecret},
+	})
+}
+
+// ExchangeAuthorizationCode trades the spapi_oauth_code Seller Central hands to an app's redirect URI for the seller's
+// refresh token. The code is valid for five minutes. A nil httpClient uses http.DefaultClient and an empty endpoint
+// DefaultLWAEndpoint.
+func ExchangeAuthorizationCode(ctx context.Context, httpClient *http.Client, endpoint, clientID, clientSecret, code string) (Token, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if endpoint == "" {
+		endpoint = DefaultLWAEndpoint
+	}
+	token, err := postLWA(ctx, httpClient, endpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	})
+	if err == nil && token.RefreshToken == "" {
+		return Token{}, fmt.Errorf("LWA token exchange: no refresh token in the response")
+	}
+	return token, err
+}
+
+func postLWA(ctx context.Context, httpClient *http.Client, endpoint string, form url.Values) (Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, lwaTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Token{}, fmt.Errorf("LWA token exchange: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Token{}, fmt.Errorf("LWA token exchange: %w", err)
+	}
+
+	var parsed struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return Token{}, fmt.Errorf("LWA token exchange: HTTP %d with an unreadable body", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || parsed.AccessToken == "" {
+		code := parsed.Error
+		if code == "" {
+			code = "unknown_error"
+		}
+		return Token{}, &LWAError{StatusCode: resp.StatusCode, Code: code, Description: parsed.ErrorDescription}
+	}
+
+	return Token{
+		AccessToken:  parsed.AccessToken,
+		RefreshToken: parsed.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second),
+	}, nil
+}
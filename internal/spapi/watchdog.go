@@ -0,0 +1,118 @@
+package spapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventKind classifies what the credential watchdog noticed.
+type EventKind string
+
+const (
+	// EventRevoked means LWA rejected the refresh token; the seller has to authorize the app again.
+	EventRevoked EventKind = "refresh_token_revoked"
+	// EventClientRejected means LWA rejected the client ID or secret.
+	EventClientRejected EventKind = "client_rejected"
+	// EventRefreshFailed covers other failed exchanges, such as LWA being unreachable.
+	EventRefreshFailed EventKind = "refresh_failed"
+	// EventRecovered follows a failure once an exchange succeeds again.
+	EventRecovered EventKind = "recovered"
+	// EventAuthorizationExpiring warns that the seller's authorization of the app lapses soon.
+	EventAuthorizationExpiring EventKind = "authorization_expiring"
+	// EventAuthorizationExpired reports that the configured authorization date has passed.
+	EventAuthorizationExpired EventKind = "authorization_expired"
+)
+
+// Event is one watchdog finding. Error events make SP-API calls fail; warnings need action before they do.
+type Event struct {
+	Kind    EventKind
+	Error   bool
+	Message string
+}
+
+// WatchConfig controls the credential watchdog.
+type WatchConfig struct {
+	// Interval between access token exchanges.
+	Interval time.Duration
+	// AuthorizationExpiresAt is when the seller's authorization of the app lapses (a year after self-authorization);
+	// zero skips the expiry warning.
+	AuthorizationExpiresAt time.Time
+	// WarnBefore is how early the expiry warning starts.
+	WarnBefore time.Duration
+}
+
+// authorizationReminder spaces out repeated expiry warnings.
+const authorizationReminder = 24 * time.Hour
+
+// Watch checks the credentials every interval until ctx ends, calling notify when their health changes: a failure
+// is reported once until it recovers, and an approaching authorization expiry once a day.
+func Watch(ctx context.Context, client Client, cfg WatchConfig, notify func(Event)) {
+	if cfg.Interval <= 0 {
+		return
+	}
+
+	w := &watchdog{client: client, cfg: cfg, notify: notify}
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.check(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type watchdog struct {
+	client Client
+	cfg    WatchConfig
+	notify func(Event)
+
+	failing    EventKind
+	lastWarned time.Time
+}
+
+func (w *watchdog) check(ctx context.Context, now time.Time) {
+	if err := w.client.CheckCredentials(ctx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		event := classifyCredentialError(err)
+		if event.Kind != w.failing {
+			w.failing = event.Kind
+			w.notify(event)
+		}
+	} else if w.failing != "" {
+		w.failing = ""
+		w.notify(Event{Kind: EventRecovered, Message: "SP-API access token exchange is working again"})
+	}
+
+	expires := w.cfg.AuthorizationExpiresAt
+	if expires.IsZero() || now.Sub(w.lastWarned) < authorizationReminder {
+		return
+	}
+	switch left := expires.Sub(now); {
+	case left <= 0:
+		w.lastWarned = now
+		w.notify(Event{Kind: EventAuthorizationExpired, Error: true, Message: fmt.Sprintf("the seller's authorization of this app expired on %s; ask the seller to authorize it again", expires.Format(time.DateOnly))})
+	case left <= w.cfg.WarnBefore:
+		w.lastWarned = now
+		w.notify(Event{Kind: EventAuthorizationExpiring, Message: fmt.Sprintf("the seller's authorization of this app expires on %s (%d days); ask the seller to authorize it again before then", expires.Format(time.DateOnly), int(left.Hours()/24))})
+	}
+}
+
+func classifyCredentialError(err error) Event {
+	var lwaErr *LWAError
+	switch {
+	case errors.As(err, &lwaErr) && lwaErr.Revoked():
+		return Event{Kind: EventRevoked, Error: true, Message: "LWA rejected the refresh token; it was revoked or has expired, so the seller must authorize the app again: " + err.Error()}
+	case errors.As(err, &lwaErr) && lwaErr.ClientRejected():
+		return Event{Kind: EventClientRejected, Error: true, Message: "LWA rejected the app's client credentials; check SP_API_CLIENT_ID and SP_API_CLIENT_SECRET: " + err.Error()}
+	default:
+		return Event{Kind: EventRefreshFailed, Error: true, Message: "SP-API access token exchange failed: " + err.Error()}
+	}
+}
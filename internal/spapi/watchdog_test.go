@@ -0,0 +1,60 @@
+package spapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// stubClient fails CheckCredentials with err.
+type stubClient struct {
+	err error
+}
+
+func (c *stubClient) AuthorizeRequest(*http.Request) error   { return c.err }
+func (c *stubClient) Endpoint() string                       { return "" }
+func (c *stubClient) Status() Status                         { return Status{Ready: true} }
+func (c *stubClient) CheckCredentials(context.Context) error { return c.err }
+
+func TestWatchdogReportsTransitions(t *testing.T) {
+	client := &stubClient{}
+	var events []EventKind
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	w := &watchdog{
+		client: client,
+		cfg: WatchConfig{
+			AuthorizationExpiresAt: now.Add(10 * 24 * time.Hour),
+			WarnBefore:             30 * 24 * time.Hour,
+		},
+		notify: func(event Event) { events = append(events, event.Kind) },
+	}
+
+	w.check(context.Background(), now)
+	client.err = &LWAError{StatusCode: http.StatusBadRequest, Code: "invalid_grant"}
+	w.check(context.Background(), now.Add(time.Hour))
+	w.check(context.Background(), now.Add(2*time.Hour))
+	client.err = errors.New("connection refused")
+	w.check(context.Background(), now.Add(3*time.Hour))
+	client.err = nil
+	w.check(context.Background(), now.Add(25*time.Hour))
+	w.check(context.Background(), now.Add(11*24*time.Hour))
+
+	want := []EventKind{
+		EventAuthorizationExpiring,
+		EventRevoked,
+		EventRefreshFailed,
+		EventRecovered,
+		EventAuthorizationExpiring,
+		EventAuthorizationExpired,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, events)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package spapitest
+
+import "fmt"
+
+// defaultPageSize is small so a handful of fixtures spans several pages.
+const defaultPageSize = 2
+
+// MarketplaceID is the marketplace the default fixtures belong to.
+const MarketplaceID = "ATVPDKIKX0DER"
+
+// Object is a JSON object in the shape SP-API returns it.
+type Object = map[string]any
+
+// Fixtures is the data the fake serves. Each list is returned in order and paged by PageSize unless the operation takes
+// its own page size parameter.
+type Fixtures struct {
+	PageSize int
+
+	// Orders are getOrders entries, keyed elsewhere by AmazonOrderId. ShippingAddress and BuyerInfo also answer
+	// getOrderAddress and getOrderBuyerInfo.
+	Orders     []Object
+	OrderItems map[string][]Object
+	Approvals  map[string][]Object
+
+	// Reports are getReports entries; ReportDocuments maps a reportDocumentId to the document's content.
+	Reports         []Object
+	ReportDocuments map[string]string
+
+	OrderMetrics       []Object
+	InventorySummaries []Object
+
+	// Prices and CompetitivePrices are getPricing and getCompetitivePricing payload entries, matched by ASIN or
+	// SellerSKU.
+	Prices            []Object
+	CompetitivePrices []Object
+}
+
+// DefaultFixtures returns a small seller account: five orders across statuses, three reports with one finished
+// document, a week of sales, three FBA SKUs and prices for two ASINs.
+func DefaultFixtures() Fixtures {
+	statuses := []string{"Unshipped", "Shipped", "Unshipped", "Pending", "Shipped"}
+	f := Fixtures{
+		OrderItems: make(map[string][]Object),
+		Approvals:  make(map[string][]Object),
+	}
+	for i, status := range statuses {
+		id := fmt.Sprintf("902-1845936-%07d", 5435065+i)
+		f.Orders = append(f.Orders, Object{
+			"AmazonOrderId":          id,
+			"PurchaseDate":           fmt.Sprintf("2025-01-0%dT10:00:00Z", i+1),
+			"LastUpdateDate":         fmt.Sprintf("2025-01-0%dT12:00:00Z", i+1),
+			"OrderStatus":            status,
+			"FulfillmentChannel":     "MFN",
+			"SalesChannel":           "Amazon.com",
+			"OrderTotal":             Object{"CurrencyCode": "USD", "Amount": fmt.Sprintf("%d.99", 10*(i+1))},
+			"NumberOfItemsShipped":   0,
+			"NumberOfItemsUnshipped": 3,
+			"PaymentMethod":          "Other",
+			"IsReplacementOrder":     "false",
+			"MarketplaceId":          MarketplaceID,
+			"OrderType":              "StandardOrder",
+			"IsBusinessOrder":        false,
+			"IsPrime":                i%2 == 0,
+			"ShippingAddress": Object{
+				"Name":          fmt.Sprintf("Buyer %d", i+1),
+				"AddressLine1":  "2201 WESTLAKE AVE",
+				"City":          "SEATTLE",
+				"StateOrRegion": "WA",
+				"PostalCode":    "98121-2778",
+				"CountryCode":   "US",
+			},
+			"BuyerInfo": Object{"BuyerEmail": fmt.Sprintf("buyer%d@marketplace.amazon.com", i+1), "BuyerName": fmt.Sprintf("Buyer %d", i+1)},
+		})
+		for n := range 3 {
+			f.OrderItems[id] = append(f.OrderItems[id], Object{
+				"ASIN":            []string{"B00V5DG6IQ", "B00551Q3CS", "B0020MLK00"}[n],
+				"OrderItemId":     fmt.Sprintf("%014d", 5015851154158+10*i+n),
+				"SellerSKU":       fmt.Sprintf("SKU-%d", n+1),
+				"Title":           fmt.Sprintf("Sample product %d", n+1),
+				"QuantityOrdered": 1,
+				"QuantityShipped": 0,
+				"ItemPrice":       Object{"CurrencyCode": "USD", "Amount": "10.00"},
+				"IsGift":          "false",
+				"GiftMessageText": "Enjoy!",
+			})
+		}
+		f.Approvals[id] = []Object{{
+			"orderItemId":    f.OrderItems[id][0]["OrderItemId"],
+			"approvalType":   "CUSTOMIZATION",
+			"approvalStatus": "PENDING_SELLING_PARTNER_APPROVAL",
+		}}
+	}
+
+	f.Reports = []Object{
+		{"reportId": "40001", "reportType": "GET_MERCHANT_LISTINGS_ALL_DATA", "marketplaceIds": []string{MarketplaceID}, "createdTime": "2025-01-05T10:00:00Z", "processingStatus": "DONE", "reportDocumentId": "amzn1.tortuga.4.na.listings"},
+		{"reportId": "40002", "reportType": "GET_FLAT_FILE_OPEN_LISTINGS_DATA", "marketplaceIds": []string{MarketplaceID}, "createdTime": "2025-01-05T11:00:00Z", "processingStatus": "IN_PROGRESS"},
+		{"reportId": "40003", "reportType": "GET_MERCHANT_LISTINGS_ALL_DATA", "marketplaceIds": []string{MarketplaceID}, "createdTime": "2025-01-05T12:00:00Z", "processingStatus": "FATAL"},
+	}
+	f.ReportDocuments = map[string]string{
+		"amzn1.tortuga.4.na.listings": "item-name\tseller-sku\tprice\tquantity\tasin1\n" +
+			"Sample product 1\tSKU-1\t10.00\t20\tB00V5DG6IQ\n" +
+			"Sample product 2\tSKU-2\t12.50\t0\tB00551Q3CS\n",
+	}
+
+	for day := range 7 {
+		f.OrderMetrics = append(f.OrderMetrics, Object{
+			"interval":         fmt.Sprintf("2025-01-0%dT00:00:00Z--2025-01-0%dT00:00:00Z", day+1, day+2),
+			"unitCount":        day + 2,
+			"orderItemCount":   day + 1,
+			"orderCount":       day + 1,
+			"averageUnitPrice": Object{"amount": "10.00", "currencyCode": "USD"},
+			"totalSales":       Object{"amount": fmt.Sprintf("%d.00", 10*(day+2)), "currencyCode": "USD"},
+		})
+	}
+
+	for n, asin := range []string{"B00V5DG6IQ", "B00551Q3CS", "B0020MLK00"} {
+		f.InventorySummaries = append(f.InventorySummaries, Object{
+			"asin":            asin,
+			"fnSku":           fmt.Sprintf("X00%d", n+1),
+			"sellerSku":       fmt.Sprintf("SKU-%d", n+1),
+			"condition":       "NewItem",
+			"productName":     fmt.Sprintf("Sample product %d", n+1),
+			"totalQuantity":   20 * n,
+			"lastUpdatedTime": "2025-01-05T10:00:00Z",
+			"inventoryDetails": Object{
+				"fulfillableQuantity":    20 * n,
+				"inboundShippedQuantity": 5,
+			},
+		})
+	}
+
+	for n, asin := range []string{"B00V5DG6IQ", "B00551Q3CS"} {
+		sku := fmt.Sprintf("SKU-%d", n+1)
+		amount := 10.0 + 2.5*float64(n)
+		f.Prices = append(f.Prices, Object{
+			"status":    "Success",
+			"ASIN":      asin,
+			"SellerSKU": sku,
+			"Product": Object{
+				"Identifiers": Object{
+					"MarketplaceASIN": Object{"MarketplaceId": MarketplaceID, "ASIN": asin},
+					"SKUIdentifier":   Object{"MarketplaceId": MarketplaceID, "SellerId": "A2SPAPITEST", "SellerSKU": sku},
+				},
+				"Offers": []Object{{
+					"BuyingPrice": Object{
+						"ListingPrice": Object{"CurrencyCode": "USD", "Amount": amount},
+						"LandedPrice":  Object{"CurrencyCode": "USD", "Amount": amount},
+						"Shipping":     Object{"CurrencyCode": "USD", "Amount": 0.0},
+					},
+					"RegularPrice":       Object{"CurrencyCode": "USD", "Amount": amount},
+					"FulfillmentChannel": "MERCHANT",
+					"ItemCondition":      "New",
+					"SellerSKU":          sku,
+				}},
+			},
+		})
+		f.CompetitivePrices = append(f.CompetitivePrices, Object{
+			"status":    "Success",
+			"ASIN":      asin,
+			"SellerSKU": sku,
+			"Product": Object{
+				"Identifiers": Object{"MarketplaceASIN": Object{"MarketplaceId": MarketplaceID, "ASIN": asin}},
+				"CompetitivePricing": Object{
+					"CompetitivePrices": []Object{{
+						"CompetitivePriceId": "1",
+						"Price": Object{
+							"LandedPrice":  Object{"CurrencyCode": "USD", "Amount": amount + 2.5},
+							"ListingPrice": Object{"CurrencyCode": "USD", "Amount": amount},
+							"Shipping":     Object{"CurrencyCode": "USD", "Amount": 2.5},
+						},
+						"condition":          "New",
+						"belongsToRequester": n == 0,
+					}},
+					"NumberOfOfferListings": []Object{{"Count": n + 2, "condition": "New"}},
+				},
+				"SalesRankings": []Object{{"ProductCategoryId": "toy_display_on_website", "Rank": 48602 + n}},
+			},
+		})
+	}
+	return f
+}
@@ -0,0 +1,111 @@
+package spapitest
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/app"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/config"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// Harness is an MCP client connected in process to the server app.NewServer builds, with its SP-API client pointed at
+// a fake.
+type Harness struct {
+	t      testing.TB
+	client *client.Client
+}
+
+// NewHarness builds the MCP server for cfg against srv and initializes a client session with it.
+func NewHarness(t testing.TB, srv *Server, cfg config.Config) *Harness {
+	t.Helper()
+	spClient, err := spapi.NewClient(spapi.Config{
+		Endpoint:    srv.URL,
+		LWAEndpoint: srv.URL + TokenPath,
+		Credentials: spapi.Credentials{ClientID: "amzn1.application-oa2-client.spapitest", ClientSecret: "spapitest", RefreshToken: "Atzr|spapitest"},
+	})
+	if err != nil {
+		t.Fatalf("spapi.NewClient: %v", err)
+	}
+	mcpServer, err := app.NewServer(cfg, app.Dependencies{SellingPartner: spClient})
+	if err != nil {
+		t.Fatalf("app.NewServer: %v", err)
+	}
+
+	c, err := client.NewInProcessClient(mcpServer)
+	if err != nil {
+		t.Fatalf("NewInProcessClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("starting MCP client: %v", err)
+	}
+	initialize := mcp.InitializeRequest{}
+	initialize.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initialize.Params.ClientInfo = mcp.Implementation{Name: "spapitest", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, initialize); err != nil {
+		t.Fatalf("initializing MCP session: %v", err)
+	}
+	return &Harness{t: t, client: c}
+}
+
+// Tools lists the tools the server registered.
+func (h *Harness) Tools() []mcp.Tool {
+	h.t.Helper()
+	result, err := h.client.ListTools(context.Background(), mcp.ListToolsRequest{})
+	if err != nil {
+		h.t.Fatalf("tools/list: %v", err)
+	}
+	return result.Tools
+}
+
+// Call invokes a tool. Tool failures come back as results with IsError set; only protocol errors fail the test.
+func (h *Harness) Call(ctx context.Context, name string, arguments map[string]any) *mcp.CallToolResult {
+	h.t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := h.client.CallTool(ctx, request)
+	if err != nil {
+		h.t.Fatalf("tools/call %s: %v", name, err)
+	}
+	return result
+}
+
+// Config returns the configuration the harness is meant for: the five domains the fixtures cover, writes enabled
+// without confirmation, and no cache or PII redaction so results show exactly what the fake served. flags override
+// or add settings, as command-line flags would. Settings in the environment are cleared for the rest of the test, so
+// an audit log, store or profile configured in the shell does not leak in; the test must not run in parallel.
+func Config(t testing.TB, flags map[string]string) config.Config {
+	t.Helper()
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		if strings.HasPrefix(key, "SP_API_") || strings.HasPrefix(key, "MCP_") || key == "HOST" || key == "PORT" || key == "VERBOSE" {
+			// The loader skips empty variables, and Setenv restores the old value when the test ends.
+			t.Setenv(key, "")
+		}
+	}
+
+	settings := map[string]string{
+		"SP_API_ENABLE_DOMAINS": "orders,reports,sales,fbaInventory,productPricing",
+		"SP_API_ENABLE_WRITES":  "true",
+		"SP_API_CONFIRM_WRITES": "false",
+		"SP_API_CACHE":          "off",
+		"SP_API_PII_POLICY":     "off",
+		"SP_API_SANDBOX":        "off",
+	}
+	for key, value := range flags {
+		settings[key] = value
+	}
+	cfg, err := config.LoadFrom(config.Options{Flags: settings})
+	if err != nil {
+		t.Fatalf("config.LoadFrom: %v", err)
+	}
+	return cfg
+}
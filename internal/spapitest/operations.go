@@ -0,0 +1,375 @@
+package spapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// downloadPath serves report documents, standing in for the pre-signed S3 URLs getReportDocument returns.
+const downloadPath = "/download/"
+
+// operation routes one SP-API operation, named by its operationId, to a handler.
+type operation struct {
+	name    string
+	pattern string
+	status  int
+	handle  handler
+}
+
+func (s *Server) operations() []operation {
+	return []operation{
+		{"getOrders", "GET /orders/v0/orders", http.StatusOK, s.getOrders},
+		{"getOrder", "GET /orders/v0/orders/{orderId}", http.StatusOK, s.getOrder},
+		{"getOrderAddress", "GET /orders/v0/orders/{orderId}/address", http.StatusOK, s.getOrderAddress},
+		{"getOrderBuyerInfo", "GET /orders/v0/orders/{orderId}/buyerInfo", http.StatusOK, s.getOrderBuyerInfo},
+		{"getOrderItems", "GET /orders/v0/orders/{orderId}/orderItems", http.StatusOK, s.getOrderItems},
+		{"getOrderItemsBuyerInfo", "GET /orders/v0/orders/{orderId}/orderItems/buyerInfo", http.StatusOK, s.getOrderItemsBuyerInfo},
+		{"updateShipmentStatus", "POST /orders/v0/orders/{orderId}/shipment", http.StatusNoContent, s.orderWrite},
+		{"confirmShipment", "POST /orders/v0/orders/{orderId}/shipmentConfirmation", http.StatusNoContent, s.orderWrite},
+		{"getOrderRegulatedInfo", "GET /orders/v0/orders/{orderId}/regulatedInfo", http.StatusOK, s.getOrderRegulatedInfo},
+		{"updateVerificationStatus", "PATCH /orders/v0/orders/{orderId}/regulatedInfo", http.StatusNoContent, s.orderWrite},
+		{"getOrderItemsApprovals", "GET /orders/v0/orders/{orderId}/approvals", http.StatusOK, s.getOrderItemsApprovals},
+
+		{"getReports", "GET /reports/2021-06-30/reports", http.StatusOK, s.getReports},
+		{"createReport", "POST /reports/2021-06-30/reports", http.StatusAccepted, s.createReport},
+		{"getReport", "GET /reports/2021-06-30/reports/{reportId}", http.StatusOK, s.getReport},
+		{"getReportDocument", "GET /reports/2021-06-30/documents/{reportDocumentId}", http.StatusOK, s.getReportDocument},
+
+		{"getOrderMetrics", "GET /sales/v1/orderMetrics", http.StatusOK, s.getOrderMetrics},
+
+		{"getInventorySummaries", "GET /fba/inventory/v1/summaries", http.StatusOK, s.getInventorySummaries},
+
+		{"getPricing", "GET /products/pricing/v0/price", http.StatusOK, s.getPricing},
+		{"getCompetitivePricing", "GET /products/pricing/v0/competitivePrice", http.StatusOK, s.getCompetitivePricing},
+	}
+}
+
+func (s *Server) getOrders(r *http.Request) (int, any) {
+	query := r.URL.Query()
+	token := query.Get("NextToken")
+	if token == "" && len(listParam(query["MarketplaceIds"])) == 0 {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "MarketplaceIds is required."})
+	}
+	// Like SP-API, a NextToken request carries no filters, so the token remembers them after the offset.
+	statuses := listParam(query["OrderStatuses"])
+	if offset, filter, ok := strings.Cut(token, "|"); ok {
+		token, statuses = offset, listParam([]string{filter})
+	}
+
+	s.mu.Lock()
+	var orders []Object
+	for _, order := range s.fixtures.Orders {
+		if len(statuses) == 0 || slices.Contains(statuses, fmt.Sprint(order["OrderStatus"])) {
+			orders = append(orders, order)
+		}
+	}
+	s.mu.Unlock()
+
+	orders, next, err := page(orders, token, s.pageSize(r, "MaxResultsPerPage"))
+	if err != nil {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: err.Error()})
+	}
+	payload := Object{"Orders": nonNil(orders)}
+	if next != "" {
+		payload["NextToken"] = next + "|" + strings.Join(statuses, ",")
+	}
+	return 0, Object{"payload": payload}
+}
+
+// order finds the order named in the path, or answers 404 as SP-API does.
+func (s *Server) order(r *http.Request) (Object, int, any) {
+	id := r.PathValue("orderId")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, order := range s.fixtures.Orders {
+		if order["AmazonOrderId"] == id {
+			return order, 0, nil
+		}
+	}
+	return nil, http.StatusNotFound, errorsBody(APIError{Code: "NotFound", Message: fmt.Sprintf("Order %s was not found.", id)})
+}
+
+func (s *Server) getOrder(r *http.Request) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	return 0, Object{"payload": order}
+}
+
+func (s *Server) getOrderAddress(r *http.Request) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	payload := Object{"AmazonOrderId": order["AmazonOrderId"]}
+	if address, ok := order["ShippingAddress"]; ok {
+		payload["ShippingAddress"] = address
+	}
+	return 0, Object{"payload": payload}
+}
+
+func (s *Server) getOrderBuyerInfo(r *http.Request) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	payload := Object{"AmazonOrderId": order["AmazonOrderId"]}
+	if info, ok := order["BuyerInfo"].(Object); ok {
+		for key, value := range info {
+			payload[key] = value
+		}
+	}
+	return 0, Object{"payload": payload}
+}
+
+func (s *Server) getOrderItems(r *http.Request) (int, any) {
+	return s.orderItems(r, func(item Object) Object { return item })
+}
+
+func (s *Server) getOrderItemsBuyerInfo(r *http.Request) (int, any) {
+	return s.orderItems(r, func(item Object) Object {
+		info := Object{"OrderItemId": item["OrderItemId"]}
+		if message, ok := item["GiftMessageText"]; ok {
+			info["GiftMessageText"] = message
+		}
+		return info
+	})
+}
+
+func (s *Server) orderItems(r *http.Request, view func(Object) Object) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	s.mu.Lock()
+	items := s.fixtures.OrderItems[fmt.Sprint(order["AmazonOrderId"])]
+	s.mu.Unlock()
+
+	items, next, err := page(items, r.URL.Query().Get("NextToken"), s.fixtures.PageSize)
+	if err != nil {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: err.Error()})
+	}
+	views := make([]Object, 0, len(items))
+	for _, item := range items {
+		views = append(views, view(item))
+	}
+	payload := Object{"AmazonOrderId": order["AmazonOrderId"], "OrderItems": views}
+	if next != "" {
+		payload["NextToken"] = next
+	}
+	return 0, Object{"payload": payload}
+}
+
+// orderWrite accepts shipment and verification updates for known orders; Calls shows what was sent.
+func (s *Server) orderWrite(r *http.Request) (int, any) {
+	if order, status, failure := s.order(r); order == nil {
+		return status, failure
+	}
+	return 0, nil
+}
+
+func (s *Server) getOrderRegulatedInfo(r *http.Request) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	return 0, Object{"payload": Object{
+		"AmazonOrderId":       order["AmazonOrderId"],
+		"RequiresDosageLabel": false,
+		"RegulatedOrderVerificationStatus": Object{
+			"Status":                 "Pending",
+			"RequiresMerchantAction": true,
+			"ValidRejectionReasons":  []Object{{"RejectionReasonId": "shield_pom_vps_reject_product", "RejectionReasonDescription": "This medicine is not suitable for your pet."}},
+		},
+	}}
+}
+
+func (s *Server) getOrderItemsApprovals(r *http.Request) (int, any) {
+	order, status, failure := s.order(r)
+	if order == nil {
+		return status, failure
+	}
+	s.mu.Lock()
+	approvals := s.fixtures.Approvals[fmt.Sprint(order["AmazonOrderId"])]
+	s.mu.Unlock()
+
+	approvals, next, err := page(approvals, r.URL.Query().Get("nextToken"), s.fixtures.PageSize)
+	if err != nil {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: err.Error()})
+	}
+	payload := Object{"orderItemsApprovals": nonNil(approvals)}
+	if next != "" {
+		payload["nextToken"] = next
+	}
+	return 0, Object{"payload": payload}
+}
+
+func (s *Server) getReports(r *http.Request) (int, any) {
+	query := r.URL.Query()
+	token := query.Get("nextToken")
+	types := listParam(query["reportTypes"])
+	if offset, filter, ok := strings.Cut(token, "|"); ok {
+		token, types = offset, listParam([]string{filter})
+	}
+
+	s.mu.Lock()
+	var reports []Object
+	for _, report := range s.fixtures.Reports {
+		if len(types) == 0 || slices.Contains(types, fmt.Sprint(report["reportType"])) {
+			reports = append(reports, report)
+		}
+	}
+	s.mu.Unlock()
+
+	reports, next, err := page(reports, token, s.pageSize(r, "pageSize"))
+	if err != nil {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: err.Error()})
+	}
+	body := Object{"reports": nonNil(reports)}
+	if next != "" {
+		body["nextToken"] = next + "|" + strings.Join(types, ",")
+	}
+	return 0, body
+}
+
+func (s *Server) createReport(r *http.Request) (int, any) {
+	var spec struct {
+		ReportType     string   `json:"reportType"`
+		MarketplaceIds []string `json:"marketplaceIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil || spec.ReportType == "" || len(spec.MarketplaceIds) == 0 {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "reportType and marketplaceIds are required."})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("%d", 50000+len(s.fixtures.Reports))
+	s.fixtures.Reports = append(s.fixtures.Reports, Object{
+		"reportId":         id,
+		"reportType":       spec.ReportType,
+		"marketplaceIds":   spec.MarketplaceIds,
+		"createdTime":      "2025-01-06T12:00:00Z",
+		"processingStatus": "IN_QUEUE",
+	})
+	return 0, Object{"reportId": id}
+}
+
+func (s *Server) getReport(r *http.Request) (int, any) {
+	id := r.PathValue("reportId")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, report := range s.fixtures.Reports {
+		if report["reportId"] == id {
+			return 0, report
+		}
+	}
+	return http.StatusNotFound, errorsBody(APIError{Code: "NotFound", Message: fmt.Sprintf("Report %s was not found.", id)})
+}
+
+func (s *Server) getReportDocument(r *http.Request) (int, any) {
+	id := r.PathValue("reportDocumentId")
+	s.mu.Lock()
+	_, ok := s.fixtures.ReportDocuments[id]
+	s.mu.Unlock()
+	if !ok {
+		return http.StatusNotFound, errorsBody(APIError{Code: "NotFound", Message: fmt.Sprintf("Report document %s was not found.", id)})
+	}
+	return 0, Object{"reportDocumentId": id, "url": s.URL + downloadPath + id}
+}
+
+func (s *Server) getOrderMetrics(r *http.Request) (int, any) {
+	query := r.URL.Query()
+	if query.Get("interval") == "" || query.Get("granularity") == "" || len(listParam(query["marketplaceIds"])) == 0 {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "marketplaceIds, interval and granularity are required."})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return 0, Object{"payload": nonNil(s.fixtures.OrderMetrics)}
+}
+
+func (s *Server) getInventorySummaries(r *http.Request) (int, any) {
+	query := r.URL.Query()
+	if query.Get("granularityType") == "" || query.Get("granularityId") == "" {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "granularityType and granularityId are required."})
+	}
+	s.mu.Lock()
+	summaries := s.fixtures.InventorySummaries
+	s.mu.Unlock()
+
+	summaries, next, err := page(summaries, query.Get("nextToken"), s.fixtures.PageSize)
+	if err != nil {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: err.Error()})
+	}
+	body := Object{
+		"payload": Object{
+			"granularity":        Object{"granularityType": query.Get("granularityType"), "granularityId": query.Get("granularityId")},
+			"inventorySummaries": nonNil(summaries),
+		},
+	}
+	if next != "" {
+		body["pagination"] = Object{"nextToken": next}
+	}
+	return 0, body
+}
+
+func (s *Server) getPricing(r *http.Request) (int, any) {
+	return s.pricing(r, s.fixtures.Prices)
+}
+
+func (s *Server) getCompetitivePricing(r *http.Request) (int, any) {
+	return s.pricing(r, s.fixtures.CompetitivePrices)
+}
+
+// pricing answers with the entries for the requested ASINs or SKUs, and a ClientError entry for unknown ones as
+// SP-API does.
+func (s *Server) pricing(r *http.Request, entries []Object) (int, any) {
+	query := r.URL.Query()
+	if query.Get("MarketplaceId") == "" {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "MarketplaceId is required."})
+	}
+	field, ids := "ASIN", listParam(query["Asins"])
+	if query.Get("ItemType") == "Sku" {
+		field, ids = "SellerSKU", listParam(query["Skus"])
+	}
+	if len(ids) == 0 {
+		return http.StatusBadRequest, errorsBody(APIError{Code: "InvalidInput", Message: "Asins or Skus are required for the ItemType."})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload := make([]Object, 0, len(ids))
+	for _, id := range ids {
+		index := slices.IndexFunc(entries, func(entry Object) bool { return entry[field] == id })
+		if index < 0 {
+			payload = append(payload, Object{"status": "ClientError", field: id, "Product": Object{}})
+			continue
+		}
+		payload = append(payload, entries[index])
+	}
+	return 0, Object{"payload": payload}
+}
+
+// listParam accepts array parameters both repeated and comma-separated.
+func listParam(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	}
+	return list
+}
+
+// nonNil keeps empty lists as [] rather than null in responses.
+func nonNil(items []Object) []Object {
+	if items == nil {
+		return []Object{}
+	}
+	return items
+}
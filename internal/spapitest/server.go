@@ -0,0 +1,232 @@
+// Package spapitest is a fake Selling Partner API for integration tests. It serves the Orders, Reports, Sales, FBA
+// Inventory and Product Pricing operations the tools call from seeded fixtures, pages results the way SP-API does, and
+// lets a test inject error lists, 429 throttling and slow responses per operation. Token exchanges and every other
+// operation fall through to the sandbox emulator's canned responses. Harness drives the MCP server built by
+// app.NewServer against it through an in-process MCP client.
+package spapitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/sandbox"
+)
+
+// TokenPath is the fake Login with Amazon token endpoint, served by the sandbox emulator.
+const TokenPath = sandbox.TokenPath
+
+// APIError is one entry of an SP-API errors list.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+// Fault replaces the normal answer to one request.
+type Fault struct {
+	// Status is the HTTP status to answer with; zero keeps the operation's normal status, which with Errors set gives
+	// the errors-with-200 responses some SP-API operations return.
+	Status int
+	// Errors is sent as the errors list. A fault with only a Delay serves the normal response after it.
+	Errors []APIError
+	// Header is added to the response, such as x-amzn-RateLimit-Limit.
+	Header http.Header
+	// Delay holds the response back, or until the client gives up.
+	Delay time.Duration
+}
+
+// Throttled is the 429 QuotaExceeded answer SP-API gives when an operation's rate limit is exhausted.
+func Throttled() Fault {
+	return Fault{
+		Status: http.StatusTooManyRequests,
+		Errors: []APIError{{Code: "QuotaExceeded", Message: "You exceeded your quota for the requested resource."}},
+		Header: http.Header{"X-Amzn-Ratelimit-Limit": {"0.0167"}},
+	}
+}
+
+// InvalidInput is the 400 answer SP-API gives to a request it rejects.
+func InvalidInput(message string) Fault {
+	return Fault{Status: http.StatusBadRequest, Errors: []APIError{{Code: "InvalidInput", Message: message}}}
+}
+
+// Slow delays the normal response by d.
+func Slow(d time.Duration) Fault {
+	return Fault{Delay: d}
+}
+
+// Call is a request the fake received.
+type Call struct {
+	Operation string
+	Method    string
+	Path      string
+	Query     url.Values
+	Body      []byte
+}
+
+// Server is a running fake. Its URL is the SP-API endpoint and URL+TokenPath the LWA endpoint.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	fixtures Fixtures
+	faults   map[string][]Fault
+	calls    []Call
+}
+
+// NewServer starts a fake serving fixtures, closed when the test ends.
+func NewServer(t testing.TB, fixtures Fixtures) *Server {
+	t.Helper()
+	if fixtures.PageSize <= 0 {
+		fixtures.PageSize = defaultPageSize
+	}
+	s := &Server{fixtures: fixtures, faults: make(map[string][]Fault)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+downloadPath+"{documentId}", s.download)
+	for _, op := range s.operations() {
+		mux.Handle(op.pattern, s.serve(op.name, op.status, op.handle))
+	}
+	// Calls answered by the emulator are not recorded and take no faults.
+	mux.Handle("/", sandbox.NewHandler())
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Inject queues faults for operation, such as "getOrders"; each request takes the next one until the queue is empty.
+func (s *Server) Inject(operation string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[operation] = append(s.faults[operation], faults...)
+}
+
+// Calls returns the requests made to operation so far.
+func (s *Server) Calls(operation string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []Call
+	for _, call := range s.calls {
+		if call.Operation == operation {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// handler answers a request with a status override (zero keeps the operation's status) and a JSON body; a nil body
+// sends none.
+type handler func(r *http.Request) (status int, body any)
+
+func (s *Server) serve(operation string, status int, handle handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.calls = append(s.calls, Call{Operation: operation, Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+		var fault *Fault
+		if queued := s.faults[operation]; len(queued) > 0 {
+			fault, s.faults[operation] = &queued[0], queued[1:]
+		}
+		s.mu.Unlock()
+
+		if r.Header.Get("X-Amz-Access-Token") == "" {
+			writeErrors(w, http.StatusForbidden, APIError{Code: "Unauthorized", Message: "Access to requested resource is denied."})
+			return
+		}
+		w.Header().Set("X-Amzn-Requestid", fmt.Sprintf("spapitest-%s-%d", operation, time.Now().UnixNano()))
+
+		if fault != nil {
+			if fault.Delay > 0 {
+				select {
+				case <-time.After(fault.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			for key, values := range fault.Header {
+				w.Header()[key] = values
+			}
+			if len(fault.Errors) > 0 {
+				code := fault.Status
+				if code == 0 {
+					code = status
+				}
+				writeErrors(w, code, fault.Errors...)
+				return
+			}
+		}
+
+		code, payload := handle(r)
+		if code == 0 {
+			code = status
+		}
+		if payload == nil {
+			w.WriteHeader(code)
+			return
+		}
+		writeJSON(w, code, payload)
+	})
+}
+
+func (s *Server) download(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, ok := s.fixtures.ReportDocuments[r.PathValue("documentId")]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	io.WriteString(w, content)
+}
+
+// page slices items from the offset in token, returning the next token or "" on the last page.
+func page[T any](items []T, token string, size int) ([]T, string, error) {
+	offset := 0
+	if token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 0 || n > len(items) {
+			return nil, "", fmt.Errorf("invalid nextToken %q", token)
+		}
+		offset = n
+	}
+	end := min(offset+size, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, nil
+}
+
+// pageSize reads a page size parameter, falling back to the fixtures' default.
+func (s *Server) pageSize(r *http.Request, param string) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get(param)); err == nil && n > 0 {
+		return n
+	}
+	return s.fixtures.PageSize
+}
+
+func errorsBody(errs ...APIError) map[string]any {
+	return map[string]any{"errors": errs}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...APIError) {
+	writeJSON(w, status, errorsBody(errs...))
+}
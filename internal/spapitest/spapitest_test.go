@@ -0,0 +1,213 @@
+package spapitest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const testOrderID = "902-1845936-5435065"
+
+// toolArguments holds a valid call for every tool the harness configuration registers.
+var toolArguments = map[string]map[string]any{
+	"orders.listOrders":             {"marketplaceIds": []any{MarketplaceID}, "createdAfter": "2025-01-01T00:00:00Z"},
+	"orders.getOrder":               {"amazonOrderId": testOrderID},
+	"orders.getOrderAddress":        {"amazonOrderId": testOrderID},
+	"orders.getOrderBuyerInfo":      {"amazonOrderId": testOrderID},
+	"orders.getOrderItems":          {"amazonOrderId": testOrderID},
+	"orders.getOrderItemsBuyerInfo": {"amazonOrderId": testOrderID},
+	"orders.confirmShipment": {"amazonOrderId": testOrderID, "marketplaceId": MarketplaceID, "packageReferenceId": "1",
+		"carrierCode": "UPS", "trackingNumber": "1Z6A34Y60369738804", "orderItems": []any{map[string]any{"orderItemId": "05015851154158", "quantity": 1}}},
+	"orders.updateShipmentStatus":     {"amazonOrderId": testOrderID, "marketplaceId": MarketplaceID, "shipmentStatus": "ReadyForPickup"},
+	"orders.getOrderRegulatedInfo":    {"amazonOrderId": testOrderID},
+	"orders.updateVerificationStatus": {"amazonOrderId": testOrderID, "status": "Approved"},
+	"orders.getOrderItemsApprovals":   {"amazonOrderId": testOrderID},
+
+	"reports.getReports":        {"reportTypes": []any{"GET_MERCHANT_LISTINGS_ALL_DATA"}},
+	"reports.createReport":      {"reportType": "GET_MERCHANT_LISTINGS_ALL_DATA", "marketplaceIds": []any{MarketplaceID}},
+	"reports.getReport":         {"reportId": "40001"},
+	"reports.getReportDocument": {"reportDocumentId": "amzn1.tortuga.4.na.listings"},
+
+	"sales.getOrderMetrics":                {"marketplaceIds": []any{MarketplaceID}, "interval": "2025-01-01T00:00:00Z--2025-01-08T00:00:00Z", "granularity": "Day", "granularityTimeZone": "UTC"},
+	"fbaInventory.getInventorySummaries":   {"granularityType": "Marketplace", "granularityId": MarketplaceID, "marketplaceIds": []any{MarketplaceID}},
+	"productPricing.getPricing":            {"marketplaceId": MarketplaceID, "itemType": "Asin", "asins": []any{"B00V5DG6IQ", "B00551Q3CS"}},
+	"productPricing.getCompetitivePricing": {"marketplaceId": MarketplaceID, "itemType": "Asin", "asins": []any{"B00V5DG6IQ"}},
+}
+
+func TestEveryToolRunsEndToEnd(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, nil))
+
+	tools := h.Tools()
+	if len(tools) != len(toolArguments) {
+		t.Errorf("registered %d tools, want %d", len(tools), len(toolArguments))
+	}
+	for _, tool := range tools {
+		t.Run(tool.Name, func(t *testing.T) {
+			arguments, ok := toolArguments[tool.Name]
+			if !ok {
+				t.Fatalf("no arguments for %s; add it to toolArguments", tool.Name)
+			}
+			result := h.Call(context.Background(), tool.Name, arguments)
+			if result.IsError {
+				t.Fatalf("%s failed: %s", tool.Name, resultText(result))
+			}
+			if result.StructuredContent == nil {
+				t.Errorf("%s returned no structured content", tool.Name)
+			}
+		})
+	}
+
+	if calls := srv.Calls("confirmShipment"); len(calls) != 1 || !strings.Contains(string(calls[0].Body), "1Z6A34Y60369738804") {
+		t.Errorf("confirmShipment calls = %+v, want one carrying the tracking number", calls)
+	}
+}
+
+func TestAllPagesFollowsNextToken(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, nil))
+
+	result := h.Call(context.Background(), "orders.listOrders", map[string]any{
+		"marketplaceIds": []any{MarketplaceID},
+		"createdAfter":   "2025-01-01T00:00:00Z",
+		"orderStatuses":  []any{"Unshipped", "Shipped"},
+		"allPages":       true,
+	})
+	if result.IsError {
+		t.Fatalf("listOrders failed: %s", resultText(result))
+	}
+	var output struct {
+		Orders []struct {
+			AmazonOrderID string `json:"AmazonOrderId"`
+		} `json:"orders"`
+	}
+	decodeStructured(t, result, &output)
+	if len(output.Orders) != 4 {
+		t.Errorf("listOrders returned %d orders, want the 4 shipped and unshipped ones", len(output.Orders))
+	}
+	calls := srv.Calls("getOrders")
+	if len(calls) != 2 {
+		t.Fatalf("getOrders called %d times, want 2 pages", len(calls))
+	}
+	if token := calls[1].Query.Get("NextToken"); !strings.HasPrefix(token, "2|") {
+		t.Errorf("second page requested with NextToken %q, want offset 2", token)
+	}
+}
+
+func TestErrorListsBecomeToolErrors(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, nil))
+
+	srv.Inject("getOrder", InvalidInput("Invalid AmazonOrderId."))
+	result := h.Call(context.Background(), "orders.getOrder", map[string]any{"amazonOrderId": testOrderID})
+	if !result.IsError || !strings.Contains(resultText(result), "400") {
+		t.Errorf("400 with an errors list: IsError=%v text=%q", result.IsError, resultText(result))
+	}
+
+	result = h.Call(context.Background(), "reports.getReport", map[string]any{"reportId": "missing"})
+	if !result.IsError || !strings.Contains(resultText(result), "404") {
+		t.Errorf("unknown report: IsError=%v text=%q", result.IsError, resultText(result))
+	}
+
+	srv.Inject("getOrderMetrics", Fault{Errors: []APIError{{Code: "InternalFailure", Message: "We encountered an internal error."}}})
+	result = h.Call(context.Background(), "sales.getOrderMetrics", toolArguments["sales.getOrderMetrics"])
+	if !result.IsError || !strings.Contains(resultText(result), "We encountered an internal error.") {
+		t.Errorf("200 with an errors list: IsError=%v text=%q", result.IsError, resultText(result))
+	}
+}
+
+func TestThrottlingSurfacesAsToolError(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, nil))
+
+	srv.Inject("getPricing", Throttled())
+	result := h.Call(context.Background(), "productPricing.getPricing", toolArguments["productPricing.getPricing"])
+	if !result.IsError || !strings.Contains(resultText(result), "429") {
+		t.Errorf("throttled getPricing: IsError=%v text=%q", result.IsError, resultText(result))
+	}
+
+	result = h.Call(context.Background(), "productPricing.getPricing", toolArguments["productPricing.getPricing"])
+	if result.IsError {
+		t.Errorf("getPricing after the throttle cleared failed: %s", resultText(result))
+	}
+}
+
+func TestSlowResponseHonoursDeadline(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, nil))
+
+	srv.Inject("getReport", Slow(10*time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	result := h.Call(ctx, "reports.getReport", map[string]any{"reportId": "40001"})
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("getReport took %v despite a 200ms deadline", elapsed)
+	}
+	if !result.IsError {
+		t.Errorf("getReport past its deadline succeeded: %s", resultText(result))
+	}
+}
+
+func TestFakeRequiresAccessToken(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	resp, err := http.Get(srv.URL + "/orders/v0/orders/" + testOrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("unauthenticated getOrder status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestOperationsWithoutFixturesUseTheSandbox(t *testing.T) {
+	srv := NewServer(t, DefaultFixtures())
+	h := NewHarness(t, srv, Config(t, map[string]string{"SP_API_ENABLE_DOMAINS": "orders,dataKiosk"}))
+
+	result := h.Call(context.Background(), "dataKiosk.getQueries", map[string]any{"processingStatuses": []any{"DONE"}})
+	if result.IsError || result.StructuredContent == nil {
+		t.Fatalf("getQueries against the sandbox responses failed: %s", resultText(result))
+	}
+	if calls := srv.Calls("getQueries"); len(calls) != 0 {
+		t.Errorf("sandbox answers should not be recorded, got %+v", calls)
+	}
+}
+
+func TestConfigIgnoresTheEnvironment(t *testing.T) {
+	t.Setenv("SP_API_AUDIT_LOG", "/var/log/sp-api/audit.log")
+	t.Setenv("SP_API_STORE_PATH", "/var/lib/sp-api/orders.db")
+	t.Setenv("SP_API_PROFILE", "production")
+
+	cfg := Config(t, nil)
+	if cfg.Audit.Path != "" || cfg.StorePath != "" || cfg.Profile == "production" {
+		t.Fatalf("environment settings leaked into the harness config: audit=%q store=%q profile=%q", cfg.Audit.Path, cfg.StorePath, cfg.Profile)
+	}
+}
+
+func decodeStructured(t *testing.T, result *mcp.CallToolResult, into any) {
+	t.Helper()
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		t.Fatalf("decoding structured content: %v", err)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FinancialEvent is one amount from a Finances API event, flattened so that each charge, fee or promotion on an
+// order item becomes its own row.
+type FinancialEvent struct {
+	EventType       string    `json:"eventType"`
+	PostedDate      time.Time `json:"postedDate"`
+	AmazonOrderID   string    `json:"amazonOrderId,omitempty"`
+	SellerSKU       string    `json:"sellerSku,omitempty"`
+	OrderItemID     string    `json:"orderItemId,omitempty"`
+	Quantity        int       `json:"quantity,omitempty"`
+	AmountKind      string    `json:"amountKind"`
+	AmountType      string    `json:"amountType,omitempty"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency,omitempty"`
+	MarketplaceName string    `json:"marketplaceName,omitempty"`
+}
+
+// ReplaceFinancialEvents replaces every event posted in [postedAfter, postedBefore) with events, so re-syncing a
+// range never double counts.
+func (s *Store) ReplaceFinancialEvents(ctx context.Context, postedAfter, postedBefore time.Time, events []FinancialEvent) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting financial event save: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM financial_events WHERE profile = ? AND posted_date >= ? AND posted_date < ?`, s.profile, formatTime(postedAfter), formatTime(postedBefore)); err != nil {
+		return fmt.Errorf("clearing financial events: %w", err)
+	}
+
+	for _, event := range events {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO financial_events (profile, event_type, posted_date, amazon_order_id, seller_sku, order_item_id,
+				quantity, amount_kind, amount_type, amount, currency, marketplace_name)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			s.profile, event.EventType, formatTime(event.PostedDate), event.AmazonOrderID, event.SellerSKU, event.OrderItemID,
+			event.Quantity, event.AmountKind, event.AmountType, event.Amount, event.Currency, event.MarketplaceName)
+		if err != nil {
+			return fmt.Errorf("saving %s event for order %s: %w", event.EventType, event.AmazonOrderID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing financial event save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// InventorySummary is the mirrored view of an FBA inventory summary for one SKU in one marketplace.
+type InventorySummary struct {
+	MarketplaceID            string          `json:"marketplaceId"`
+	SellerSKU                string          `json:"sellerSku"`
+	ASIN                     string          `json:"asin,omitempty"`
+	FnSKU                    string          `json:"fnSku,omitempty"`
+	ProductName              string          `json:"productName,omitempty"`
+	Condition                string          `json:"condition,omitempty"`
+	FulfillableQuantity      int             `json:"fulfillableQuantity"`
+	InboundWorkingQuantity   int             `json:"inboundWorkingQuantity"`
+	InboundShippedQuantity   int             `json:"inboundShippedQuantity"`
+	InboundReceivingQuantity int             `json:"inboundReceivingQuantity"`
+	ReservedQuantity         int             `json:"reservedQuantity"`
+	UnfulfillableQuantity    int             `json:"unfulfillableQuantity"`
+	TotalQuantity            int             `json:"totalQuantity"`
+	LastUpdatedTime          time.Time       `json:"lastUpdatedTime,omitzero"`
+	Raw                      json.RawMessage `json:"-"`
+}
+
+// ReplaceInventory swaps in a complete inventory snapshot for a marketplace, dropping SKUs the snapshot no longer
+// lists. Partial snapshots must use SaveInventory instead.
+func (s *Store) ReplaceInventory(ctx context.Context, marketplaceID string, summaries []InventorySummary, syncedAt time.Time) error {
+	return s.saveInventory(ctx, marketplaceID, summaries, syncedAt, true)
+}
+
+// SaveInventory upserts inventory summaries without touching other SKUs.
+func (s *Store) SaveInventory(ctx context.Context, marketplaceID string, summaries []InventorySummary, syncedAt time.Time) error {
+	return s.saveInventory(ctx, marketplaceID, summaries, syncedAt, false)
+}
+
+func (s *Store) saveInventory(ctx context.Context, marketplaceID string, summaries []InventorySummary, syncedAt time.Time, replace bool) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting inventory save: %w", err)
+	}
+	defer tx.Rollback()
+
+	if replace {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM inventory_summaries WHERE profile = ? AND marketplace_id = ?`, s.profile, marketplaceID); err != nil {
+			return fmt.Errorf("clearing inventory for %s: %w", marketplaceID, err)
+		}
+	}
+
+	for _, summary := range summaries {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO inventory_summaries (profile, marketplace_id, seller_sku, asin, fn_sku, product_name, condition,
+				fulfillable_quantity, inbound_working_quantity, inbound_shipped_quantity, inbound_receiving_quantity,
+				reserved_quantity, unfulfillable_quantity, total_quantity, last_updated_time, raw, synced_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (profile, marketplace_id, seller_sku) DO UPDATE SET
+				asin = excluded.asin,
+				fn_sku = excluded.fn_sku,
+				product_name = excluded.product_name,
+				condition = excluded.condition,
+				fulfillable_quantity = excluded.fulfillable_quantity,
+				inbound_working_quantity = excluded.inbound_working_quantity,
+				inbound_shipped_quantity = excluded.inbound_shipped_quantity,
+				inbound_receiving_quantity = excluded.inbound_receiving_quantity,
+				reserved_quantity = excluded.reserved_quantity,
+				unfulfillable_quantity = excluded.unfulfillable_quantity,
+				total_quantity = excluded.total_quantity,
+				last_updated_time = excluded.last_updated_time,
+				raw = excluded.raw,
+				synced_at = excluded.synced_at`,
+			s.profile, marketplaceID, summary.SellerSKU, summary.ASIN, summary.FnSKU, summary.ProductName, summary.Condition,
+			summary.FulfillableQuantity, summary.InboundWorkingQuantity, summary.InboundShippedQuantity,
+			summary.InboundReceivingQuantity, summary.ReservedQuantity, summary.UnfulfillableQuantity,
+			summary.TotalQuantity, formatTime(summary.LastUpdatedTime), rawText(summary.Raw), formatTime(syncedAt))
+		if err != nil {
+			return fmt.Errorf("saving inventory for %s: %w", summary.SellerSKU, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing inventory save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// Order is the mirrored view of an Orders API order. Raw keeps the full API object for fields without a column.
+type Order struct {
+	AmazonOrderID      string          `json:"amazonOrderId"`
+	MarketplaceID      string          `json:"marketplaceId"`
+	OrderStatus        string          `json:"orderStatus"`
+	FulfillmentChannel string          `json:"fulfillmentChannel,omitempty"`
+	SalesChannel       string          `json:"salesChannel,omitempty"`
+	PurchaseDate       time.Time       `json:"purchaseDate"`
+	LastUpdateDate     time.Time       `json:"lastUpdateDate"`
+	OrderTotal         *float64        `json:"orderTotal,omitempty"`
+	Currency           string          `json:"currency,omitempty"`
+	ShipCity           string          `json:"shipCity,omitempty"`
+	ShipState          string          `json:"shipState,omitempty"`
+	ShipPostalCode     string          `json:"shipPostalCode,omitempty"`
+	ShipCountry        string          `json:"shipCountry,omitempty"`
+	Items              []OrderItem     `json:"items,omitempty"`
+	Raw                json.RawMessage `json:"-"`
+}
+
+// OrderItem is the mirrored view of an order line item.
+type OrderItem struct {
+	OrderItemID     string          `json:"orderItemId"`
+	SellerSKU       string          `json:"sellerSku,omitempty"`
+	ASIN            string          `json:"asin,omitempty"`
+	Title           string          `json:"title,omitempty"`
+	QuantityOrdered int             `json:"quantityOrdered"`
+	QuantityShipped int             `json:"quantityShipped"`
+	ItemPrice       *float64        `json:"itemPrice,omitempty"`
+	Currency        string          `json:"currency,omitempty"`
+	Raw             json.RawMessage `json:"-"`
+}
+
+// SaveOrders upserts orders in one transaction. Items are replaced when an order carries them and left untouched when
+// Items is nil, so a sync that skips items does not erase earlier ones.
+func (s *Store) SaveOrders(ctx context.Context, orders []Order, syncedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting order save: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, order := range orders {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO orders (profile, amazon_order_id, marketplace_id, order_status, fulfillment_channel, sales_channel,
+				purchase_date, last_update_date, order_total, currency, ship_city, ship_state, ship_postal_code,
+				ship_country, raw, synced_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (profile, amazon_order_id) DO UPDATE SET
+				marketplace_id = excluded.marketplace_id,
+				order_status = excluded.order_status,
+				fulfillment_channel = excluded.fulfillment_channel,
+				sales_channel = excluded.sales_channel,
+				purchase_date = excluded.purchase_date,
+				last_update_date = excluded.last_update_date,
+				order_total = excluded.order_total,
+				currency = excluded.currency,
+				ship_city = excluded.ship_city,
+				ship_state = excluded.ship_state,
+				ship_postal_code = excluded.ship_postal_code,
+				ship_country = excluded.ship_country,
+				raw = excluded.raw,
+				synced_at = excluded.synced_at`,
+			s.profile, order.AmazonOrderID, order.MarketplaceID, order.OrderStatus, order.FulfillmentChannel, order.SalesChannel,
+			formatTime(order.PurchaseDate), formatTime(order.LastUpdateDate), order.OrderTotal, order.Currency,
+			order.ShipCity, order.ShipState, order.ShipPostalCode, order.ShipCountry, rawText(order.Raw), formatTime(syncedAt))
+		if err != nil {
+			return fmt.Errorf("saving order %s: %w", order.AmazonOrderID, err)
+		}
+
+		if order.Items == nil {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM order_items WHERE profile = ? AND amazon_order_id = ?`, s.profile, order.AmazonOrderID); err != nil {
+			return fmt.Errorf("replacing items for order %s: %w", order.AmazonOrderID, err)
+		}
+		for _, item := range order.Items {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO order_items (profile, amazon_order_id, order_item_id, seller_sku, asin, title, quantity_ordered,
+					quantity_shipped, item_price, currency, raw)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				s.profile, order.AmazonOrderID, item.OrderItemID, item.SellerSKU, item.ASIN, item.Title, item.QuantityOrdered,
+				item.QuantityShipped, item.ItemPrice, item.Currency, rawText(item.Raw))
+			if err != nil {
+				return fmt.Errorf("saving item %s for order %s: %w", item.OrderItemID, order.AmazonOrderID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing order save: %w", err)
+	}
+	return nil
+}
+
+// OrderQuery filters mirrored orders. Zero values leave a filter off.
+type OrderQuery struct {
+	MarketplaceIDs     []string
+	OrderStatuses      []string
+	FulfillmentChannel string
+	SellerSKU          string
+	PurchasedAfter     time.Time
+	PurchasedBefore    time.Time
+	ShipState          string
+	MinTotal           *float64
+	MaxTotal           *float64
+	Limit              int
+	IncludeItems       bool
+}
+
+// OrderQueryResult holds the newest matching orders up to the limit, plus the full match count and order totals per
+// currency so aggregate questions do not depend on the limit.
+type OrderQueryResult struct {
+	Orders           []Order            `json:"orders"`
+	Matched          int                `json:"matched"`
+	TotalsByCurrency map[string]float64 `json:"totalsByCurrency"`
+}
+
+// QueryOrders answers a filtered order query from the mirror.
+func (s *Store) QueryOrders(ctx context.Context, query OrderQuery) (OrderQueryResult, error) {
+	where, args := query.where(s.profile)
+
+	result := OrderQueryResult{TotalsByCurrency: make(map[string]float64)}
+
+	totals, err := s.db.QueryContext(ctx, `SELECT currency, COUNT(*), COALESCE(SUM(order_total), 0) FROM orders o`+where+` GROUP BY currency`, args...)
+	if err != nil {
+		return OrderQueryResult{}, fmt.Errorf("summarising orders: %w", err)
+	}
+	for totals.Next() {
+		var (
+			currency string
+			count    int
+			sum      float64
+		)
+		if err := totals.Scan(&currency, &count, &sum); err != nil {
+			totals.Close()
+			return OrderQueryResult{}, fmt.Errorf("summarising orders: %w", err)
+		}
+		result.Matched += count
+		if currency != "" {
+			result.TotalsByCurrency[currency] += sum
+		}
+	}
+	totals.Close()
+	if err := totals.Err(); err != nil {
+		return OrderQueryResult{}, fmt.Errorf("summarising orders: %w", err)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT amazon_order_id, marketplace_id, order_status, fulfillment_channel, sales_channel, purchase_date,
+			last_update_date, order_total, currency, ship_city, ship_state, ship_postal_code, ship_country
+		FROM orders o`+where+`
+		ORDER BY purchase_date DESC, amazon_order_id
+		LIMIT ?`, append(args, limit)...)
+	if err != nil {
+		return OrderQueryResult{}, fmt.Errorf("querying orders: %w", err)
+	}
+
+	result.Orders = make([]Order, 0)
+	for rows.Next() {
+		var (
+			order                  Order
+			purchased, lastUpdated string
+			total                  sql.NullFloat64
+		)
+		err := rows.Scan(&order.AmazonOrderID, &order.MarketplaceID, &order.OrderStatus, &order.FulfillmentChannel,
+			&order.SalesChannel, &purchased, &lastUpdated, &total, &order.Currency, &order.ShipCity, &order.ShipState,
+			&order.ShipPostalCode, &order.ShipCountry)
+		if err != nil {
+			rows.Close()
+			return OrderQueryResult{}, fmt.Errorf("querying orders: %w", err)
+		}
+		order.PurchaseDate = parseTime(purchased)
+		order.LastUpdateDate = parseTime(lastUpdated)
+		if total.Valid {
+			order.OrderTotal = &total.Float64
+		}
+		result.Orders = append(result.Orders, order)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return OrderQueryResult{}, fmt.Errorf("querying orders: %w", err)
+	}
+
+	if query.IncludeItems {
+		for i := range result.Orders {
+			items, err := s.orderItems(ctx, result.Orders[i].AmazonOrderID)
+			if err != nil {
+				return OrderQueryResult{}, err
+			}
+			result.Orders[i].Items = items
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Store) orderItems(ctx context.Context, orderID string) ([]OrderItem, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT order_item_id, seller_sku, asin, title, quantity_ordered, quantity_shipped, item_price, currency
+		FROM order_items WHERE profile = ? AND amazon_order_id = ? ORDER BY order_item_id`, s.profile, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("reading items for order %s: %w", orderID, err)
+	}
+	defer rows.Close()
+
+	var items []OrderItem
+	for rows.Next() {
+		var (
+			item  OrderItem
+			price sql.NullFloat64
+		)
+		if err := rows.Scan(&item.OrderItemID, &item.SellerSKU, &item.ASIN, &item.Title, &item.QuantityOrdered, &item.QuantityShipped, &price, &item.Currency); err != nil {
+			return nil, fmt.Errorf("reading items for order %s: %w", orderID, err)
+		}
+		if price.Valid {
+			item.ItemPrice = &price.Float64
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (q OrderQuery) where(profile string) (string, []any) {
+	clauses := []string{"o.profile = ?"}
+	args := []any{profile}
+
+	if len(q.MarketplaceIDs) > 0 {
+		clauses = append(clauses, "o.marketplace_id IN ("+placeholders(len(q.MarketplaceIDs))+")")
+		for _, id := range q.MarketplaceIDs {
+			args = append(args, id)
+		}
+	}
+	if len(q.OrderStatuses) > 0 {
+		clauses = append(clauses, "o.order_status IN ("+placeholders(len(q.OrderStatuses))+")")
+		for _, status := range q.OrderStatuses {
+			args = append(args, status)
+		}
+	}
+	if q.FulfillmentChannel != "" {
+		clauses = append(clauses, "o.fulfillment_channel = ?")
+		args = append(args, q.FulfillmentChannel)
+	}
+	if q.SellerSKU != "" {
+		clauses = append(clauses, "EXISTS (SELECT 1 FROM order_items i WHERE i.profile = o.profile AND i.amazon_order_id = o.amazon_order_id AND i.seller_sku = ?)")
+		args = append(args, q.SellerSKU)
+	}
+	if !q.PurchasedAfter.IsZero() {
+		clauses = append(clauses, "o.purchase_date >= ?")
+		args = append(args, formatTime(q.PurchasedAfter))
+	}
+	if !q.PurchasedBefore.IsZero() {
+		clauses = append(clauses, "o.purchase_date < ?")
+		args = append(args, formatTime(q.PurchasedBefore))
+	}
+	if q.ShipState != "" {
+		clauses = append(clauses, "UPPER(o.ship_state) = UPPER(?)")
+		args = append(args, q.ShipState)
+	}
+	if q.MinTotal != nil {
+		clauses = append(clauses, "o.order_total >= ?")
+		args = append(args, *q.MinTotal)
+	}
+	if q.MaxTotal != nil {
+		clauses = append(clauses, "o.order_total <= ?")
+		args = append(args, *q.MaxTotal)
+	}
+
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func rawText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "{}"
+	}
+	return string(raw)
+}
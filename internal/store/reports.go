@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ImportReportRows stores the rows of a report document, replacing any earlier import of the same document. Each row
+// is kept as a JSON object keyed by column header, so it can be read with json_extract.
+func (s *Store) ImportReportRows(ctx context.Context, reportDocumentID, reportType string, columns []string, rows [][]string, importedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting report import: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM report_rows WHERE profile = ? AND report_document_id = ?`, s.profile, reportDocumentID); err != nil {
+		return fmt.Errorf("clearing report %s: %w", reportDocumentID, err)
+	}
+
+	for i, row := range rows {
+		record := make(map[string]string, len(columns))
+		for j, column := range columns {
+			if j < len(row) {
+				record[column] = row[j]
+			}
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("encoding report row %d: %w", i+1, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO report_rows (profile, report_document_id, report_type, row_number, data, imported_at)
+			VALUES (?, ?, ?, ?, ?, ?)`, s.profile, reportDocumentID, reportType, i+1, string(data), formatTime(importedAt))
+		if err != nil {
+			return fmt.Errorf("saving report row %d: %w", i+1, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing report import: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSQLMaxRows = 200
+	maxSQLMaxRows     = 5000
+	defaultSQLTimeout = 10 * time.Second
+	maxSQLTimeout     = 60 * time.Second
+)
+
+// SchemaDocumentation describes the tables available to ad-hoc SQL. Keep it in step with schema.
+const SchemaDocumentation = `# Local store schema
+
+The local store is a SQLite database. Timestamps are RFC 3339 UTC strings, so they compare correctly as text and
+work with SQLite date functions. Money columns are REAL in the listed currency. Tables fill only when their sync tool
+runs; check synced_at, imported_at or sync_state before trusting a result. Every table has a profile column naming
+the SP_API_PROFILE that synced the row ('' for the default profile); join tables on it as well as on their keys.
+
+## orders (sync.orders)
+One row per order and seller profile.
+- profile TEXT: the SP_API_PROFILE that synced the order ('' for the default profile)
+- amazon_order_id TEXT, unique within a profile
+- marketplace_id TEXT
+- order_status TEXT: Pending, Unshipped, PartiallyShipped, Shipped, Canceled, ...
+- fulfillment_channel TEXT: AFN (FBA) or MFN
+- sales_channel TEXT
+- purchase_date TEXT, last_update_date TEXT
+- order_total REAL, currency TEXT
+- ship_city, ship_state, ship_postal_code, ship_country TEXT
+- raw TEXT: the full Orders API object as JSON
+- synced_at TEXT
+
+## order_items (sync.orders with includeItems)
+One row per order line. Join to orders on profile and amazon_order_id.
+- profile TEXT, amazon_order_id TEXT, order_item_id TEXT
+- seller_sku TEXT, asin TEXT, title TEXT
+- quantity_ordered INTEGER, quantity_shipped INTEGER
+- item_price REAL: the line total, not the unit price
+- currency TEXT
+- raw TEXT
+
+## sync_state
+Order sync progress per profile and marketplace: profile, marketplace_id, watermark (orders updated before it are
+mirrored), pending_next_token, pending_before, last_synced_at.
+
+## inventory_summaries (sync.inventory)
+Latest FBA inventory snapshot, one row per profile, SKU and marketplace.
+- profile TEXT, marketplace_id TEXT, seller_sku TEXT
+- asin, fn_sku, product_name, condition TEXT
+- fulfillable_quantity, inbound_working_quantity, inbound_shipped_quantity, inbound_receiving_quantity,
+  reserved_quantity, unfulfillable_quantity, total_quantity INTEGER
+- last_updated_time TEXT
+- raw TEXT, synced_at TEXT
+
+## financial_events (sync.financialEvents)
+One row per amount in a Finances API event.
+- profile TEXT
+- event_type TEXT: Shipment, Refund, ServiceFee, Adjustment
+- posted_date TEXT
+- amazon_order_id, seller_sku, order_item_id TEXT (empty when the event has none)
+- quantity INTEGER
+- amount_kind TEXT: charge, fee, promotion, withheldTax or adjustment
+- amount_type TEXT: e.g. Principal, Tax, Commission, FBAPerUnitFulfillmentFee
+- amount REAL: signed as Amazon reports it (fees are negative), currency TEXT
+- marketplace_name TEXT
+
+## report_rows (store.importReport)
+Rows of imported report documents, per profile. data is a JSON object keyed by the report's column headers.
+- profile TEXT, report_document_id TEXT, report_type TEXT, row_number INTEGER
+- data TEXT: read columns with json_extract(data, '$."column-name"')
+- imported_at TEXT
+
+## dataset_state
+Watermarks per profile for syncs that are not per marketplace: profile, dataset (financialEvents), watermark,
+last_synced_at.
+
+## Example
+
+Units sold per SKU in the last 30 days against fulfillable FBA stock:
+
+    SELECT i.seller_sku, SUM(i.quantity_ordered) AS units_sold, inv.fulfillable_quantity
+    FROM order_items i
+    JOIN orders o ON o.profile = i.profile AND o.amazon_order_id = i.amazon_order_id
+    LEFT JOIN inventory_summaries inv
+      ON inv.profile = o.profile AND inv.seller_sku = i.seller_sku AND inv.marketplace_id = o.marketplace_id
+    WHERE o.purchase_date >= strftime('%Y-%m-%dT%H:%M:%SZ', 'now', '-30 days') AND o.order_status != 'Canceled'
+    GROUP BY i.seller_sku, inv.fulfillable_quantity
+    ORDER BY units_sold DESC
+`
+
+// SQLQuery is an ad-hoc read-only statement. Zero limits take their defaults.
+type SQLQuery struct {
+	Statement string
+	Args      []any
+	MaxRows   int
+	Timeout   time.Duration
+}
+
+// SQLResult holds the columns and rows of an ad-hoc statement. Truncated is set when more rows than MaxRows matched.
+type SQLResult struct {
+	Columns   []string `json:"columns"`
+	Rows      [][]any  `json:"rows"`
+	Truncated bool     `json:"truncated"`
+}
+
+// ErrNotReadOnly is returned for statements other than a single SELECT (or WITH ... SELECT).
+var ErrNotReadOnly = errors.New("only a single SELECT or WITH statement is allowed")
+
+// Query runs a read-only statement on a connection SQLite opens read-only, so writes fail even if the keyword check
+// misses one. The statement is interrupted when the timeout passes.
+func (s *Store) Query(ctx context.Context, query SQLQuery) (SQLResult, error) {
+	statement, err := readOnlyStatement(query.Statement)
+	if err != nil {
+		return SQLResult{}, err
+	}
+
+	maxRows := query.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultSQLMaxRows
+	}
+	if maxRows > maxSQLMaxRows {
+		maxRows = maxSQLMaxRows
+	}
+
+	timeout := query.Timeout
+	if timeout <= 0 {
+		timeout = defaultSQLTimeout
+	}
+	if timeout > maxSQLTimeout {
+		timeout = maxSQLTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rows, err := s.readOnly.QueryContext(ctx, statement, query.Args...)
+	if err != nil {
+		return SQLResult{}, queryError(ctx, timeout, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return SQLResult{}, fmt.Errorf("reading columns: %w", err)
+	}
+
+	result := SQLResult{Columns: columns, Rows: make([][]any, 0)}
+	for rows.Next() {
+		if len(result.Rows) == maxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]any, len(columns))
+		targets := make([]any, len(columns))
+		for i := range values {
+			targets[i] = &values[i]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return SQLResult{}, fmt.Errorf("reading row: %w", err)
+		}
+		for i, value := range values {
+			// TEXT can come back as []byte; return it as a string so it encodes as text, not base64.
+			if raw, ok := value.([]byte); ok {
+				values[i] = string(raw)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return SQLResult{}, queryError(ctx, timeout, err)
+	}
+
+	return result, nil
+}
+
+func queryError(ctx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("query exceeded the %s timeout", timeout)
+	}
+	return err
+}
+
+// readOnlyStatement trims a trailing semicolon and rejects anything but one SELECT or WITH statement. Semicolons inside
+// string literals are rejected too; the check errs on the side of refusing.
+func readOnlyStatement(statement string) (string, error) {
+	trimmed := strings.TrimSpace(statement)
+	trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, ";"))
+	if trimmed == "" {
+		return "", fmt.Errorf("statement is empty")
+	}
+	if strings.Contains(trimmed, ";") {
+		return "", ErrNotReadOnly
+	}
+
+	keyword := strings.ToUpper(strings.Fields(trimmed)[0])
+	if keyword != "SELECT" && keyword != "WITH" {
+		return "", ErrNotReadOnly
+	}
+	return trimmed, nil
+}
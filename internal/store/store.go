@@ -0,0 +1,399 @@
+// Package store mirrors Selling Partner data in an embedded SQLite database so repeated questions can be answered
+// locally instead of through throttled SP-API calls.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	// Registers the pure Go "sqlite" driver, so the binary keeps building without cgo.
+	_ "modernc.org/sqlite"
+)
+
+// schema is applied on every open; statements must stay idempotent.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS orders (
+		profile TEXT NOT NULL DEFAULT '',
+		amazon_order_id TEXT NOT NULL,
+		marketplace_id TEXT NOT NULL,
+		order_status TEXT NOT NULL,
+		fulfillment_channel TEXT NOT NULL DEFAULT '',
+		sales_channel TEXT NOT NULL DEFAULT '',
+		purchase_date TEXT NOT NULL,
+		last_update_date TEXT NOT NULL,
+		order_total REAL,
+		currency TEXT NOT NULL DEFAULT '',
+		ship_city TEXT NOT NULL DEFAULT '',
+		ship_state TEXT NOT NULL DEFAULT '',
+		ship_postal_code TEXT NOT NULL DEFAULT '',
+		ship_country TEXT NOT NULL DEFAULT '',
+		raw TEXT NOT NULL,
+		synced_at TEXT NOT NULL,
+		PRIMARY KEY (profile, amazon_order_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS orders_purchase_date ON orders (purchase_date)`,
+	`CREATE INDEX IF NOT EXISTS orders_marketplace_status ON orders (marketplace_id, order_status)`,
+	`CREATE TABLE IF NOT EXISTS order_items (
+		profile TEXT NOT NULL DEFAULT '',
+		amazon_order_id TEXT NOT NULL,
+		order_item_id TEXT NOT NULL,
+		seller_sku TEXT NOT NULL DEFAULT '',
+		asin TEXT NOT NULL DEFAULT '',
+		title TEXT NOT NULL DEFAULT '',
+		quantity_ordered INTEGER NOT NULL DEFAULT 0,
+		quantity_shipped INTEGER NOT NULL DEFAULT 0,
+		item_price REAL,
+		currency TEXT NOT NULL DEFAULT '',
+		raw TEXT NOT NULL,
+		PRIMARY KEY (profile, amazon_order_id, order_item_id),
+		FOREIGN KEY (profile, amazon_order_id) REFERENCES orders (profile, amazon_order_id) ON DELETE CASCADE
+	)`,
+	`CREATE INDEX IF NOT EXISTS order_items_sku ON order_items (seller_sku)`,
+	`CREATE TABLE IF NOT EXISTS sync_state (
+		profile TEXT NOT NULL DEFAULT '',
+		marketplace_id TEXT NOT NULL,
+		watermark TEXT NOT NULL DEFAULT '',
+		pending_next_token TEXT NOT NULL DEFAULT '',
+		pending_before TEXT NOT NULL DEFAULT '',
+		last_synced_at TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (profile, marketplace_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS inventory_summaries (
+		profile TEXT NOT NULL DEFAULT '',
+		marketplace_id TEXT NOT NULL,
+		seller_sku TEXT NOT NULL,
+		asin TEXT NOT NULL DEFAULT '',
+		fn_sku TEXT NOT NULL DEFAULT '',
+		product_name TEXT NOT NULL DEFAULT '',
+		condition TEXT NOT NULL DEFAULT '',
+		fulfillable_quantity INTEGER NOT NULL DEFAULT 0,
+		inbound_working_quantity INTEGER NOT NULL DEFAULT 0,
+		inbound_shipped_quantity INTEGER NOT NULL DEFAULT 0,
+		inbound_receiving_quantity INTEGER NOT NULL DEFAULT 0,
+		reserved_quantity INTEGER NOT NULL DEFAULT 0,
+		unfulfillable_quantity INTEGER NOT NULL DEFAULT 0,
+		total_quantity INTEGER NOT NULL DEFAULT 0,
+		last_updated_time TEXT NOT NULL DEFAULT '',
+		raw TEXT NOT NULL,
+		synced_at TEXT NOT NULL,
+		PRIMARY KEY (profile, marketplace_id, seller_sku)
+	)`,
+	`CREATE TABLE IF NOT EXISTS financial_events (
+		profile TEXT NOT NULL DEFAULT '',
+		event_type TEXT NOT NULL,
+		posted_date TEXT NOT NULL,
+		amazon_order_id TEXT NOT NULL DEFAULT '',
+		seller_sku TEXT NOT NULL DEFAULT '',
+		order_item_id TEXT NOT NULL DEFAULT '',
+		quantity INTEGER NOT NULL DEFAULT 0,
+		amount_kind TEXT NOT NULL,
+		amount_type TEXT NOT NULL DEFAULT '',
+		amount REAL NOT NULL DEFAULT 0,
+		currency TEXT NOT NULL DEFAULT '',
+		marketplace_name TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS financial_events_posted_date ON financial_events (profile, posted_date)`,
+	`CREATE INDEX IF NOT EXISTS financial_events_order ON financial_events (profile, amazon_order_id)`,
+	`CREATE TABLE IF NOT EXISTS report_rows (
+		profile TEXT NOT NULL DEFAULT '',
+		report_document_id TEXT NOT NULL,
+		report_type TEXT NOT NULL DEFAULT '',
+		row_number INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		imported_at TEXT NOT NULL,
+		PRIMARY KEY (profile, report_document_id, row_number)
+	)`,
+	`CREATE INDEX IF NOT EXISTS report_rows_type ON report_rows (profile, report_type)`,
+	`CREATE TABLE IF NOT EXISTS dataset_state (
+		profile TEXT NOT NULL DEFAULT '',
+		dataset TEXT NOT NULL,
+		watermark TEXT NOT NULL DEFAULT '',
+		last_synced_at TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (profile, dataset)
+	)`,
+}
+
+// unscopedTables lists the tables that predate the profile column with the columns and indexes they had then, items
+// first so they are dropped before the orders they reference.
+var unscopedTables = []struct {
+	name    string
+	columns string
+	indexes []string
+}{
+	{"order_items", "amazon_order_id, order_item_id, seller_sku, asin, title, quantity_ordered, quantity_shipped, item_price, currency, raw", []string{"order_items_sku"}},
+	{"orders", "amazon_order_id, marketplace_id, order_status, fulfillment_channel, sales_channel, purchase_date, last_update_date, order_total, currency, ship_city, ship_state, ship_postal_code, ship_country, raw, synced_at", []string{"orders_purchase_date", "orders_marketplace_status"}},
+	{"sync_state", "marketplace_id, watermark, pending_next_token, pending_before, last_synced_at", nil},
+	{"inventory_summaries", "marketplace_id, seller_sku, asin, fn_sku, product_name, condition, fulfillable_quantity, inbound_working_quantity, inbound_shipped_quantity, inbound_receiving_quantity, reserved_quantity, unfulfillable_quantity, total_quantity, last_updated_time, raw, synced_at", nil},
+	{"financial_events", "event_type, posted_date, amazon_order_id, seller_sku, order_item_id, quantity, amount_kind, amount_type, amount, currency, marketplace_name", []string{"financial_events_posted_date", "financial_events_order"}},
+	{"report_rows", "report_document_id, report_type, row_number, data, imported_at", []string{"report_rows_type"}},
+	{"dataset_state", "dataset, watermark, last_synced_at", nil},
+}
+
+// Store is a handle on the local mirror. It is safe for concurrent use.
+type Store struct {
+	db *sql.DB
+	// readOnly serves ad-hoc SQL; SQLite itself rejects writes on it, whatever the statement.
+	readOnly *sql.DB
+	// profile scopes every mirrored table, so sellers sharing a database never overwrite each other.
+	profile string
+}
+
+// Open opens (creating if needed) the database at path and applies the schema.
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("creating store directory: %w", err)
+		}
+	}
+
+	query := url.Values{}
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "foreign_keys(1)")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("opening store: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids busy errors between our own goroutines.
+	db.SetMaxOpenConns(1)
+
+	if err := migrateUnscopedTables(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	for _, statement := range schema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("applying store schema: %w", err)
+		}
+	}
+
+	readQuery := url.Values{}
+	readQuery.Add("mode", "ro")
+	readQuery.Add("_pragma", "busy_timeout(5000)")
+	readQuery.Add("_pragma", "query_only(1)")
+
+	readOnly, err := sql.Open("sqlite", "file:"+path+"?"+readQuery.Encode())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening read-only store: %w", err)
+	}
+	readOnly.SetMaxOpenConns(4)
+
+	return &Store{db: db, readOnly: readOnly}, nil
+}
+
+// migrateUnscopedTables moves tables created before the profile column into the current schema. Existing rows keep
+// the default profile, which is the one a server without SP_API_PROFILE uses.
+func migrateUnscopedTables(db *sql.DB) error {
+	var pending []int
+	for i, table := range unscopedTables {
+		var exists, scoped int
+		err := db.QueryRow(`SELECT
+			(SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?),
+			(SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'profile')`, table.name, table.name).Scan(&exists, &scoped)
+		if err != nil {
+			return fmt.Errorf("checking store schema: %w", err)
+		}
+		if exists > 0 && scoped == 0 {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("migrating store tables: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Renamed tables keep their indexes, which would stop the schema from creating them on the new tables.
+	var statements []string
+	for _, i := range pending {
+		for _, index := range unscopedTables[i].indexes {
+			statements = append(statements, `DROP INDEX IF EXISTS `+index)
+		}
+	}
+	for _, i := range pending {
+		table := unscopedTables[i]
+		statements = append(statements, `ALTER TABLE `+table.name+` RENAME TO `+table.name+`_unscoped`)
+	}
+	statements = append(statements, schema...)
+	for j := len(pending) - 1; j >= 0; j-- {
+		table := unscopedTables[pending[j]]
+		statements = append(statements, `INSERT INTO `+table.name+` (`+table.columns+`) SELECT `+table.columns+` FROM `+table.name+`_unscoped`)
+	}
+	for _, i := range pending {
+		statements = append(statements, `DROP TABLE `+unscopedTables[i].name+`_unscoped`)
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("migrating store tables: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migrating store tables: %w", err)
+	}
+	return nil
+}
+
+// ForProfile returns a handle on the same database whose mirrored data and sync state belong to profile. Close only
+// one of the handles.
+func (s *Store) ForProfile(profile string) *Store {
+	scoped := *s
+	scoped.profile = profile
+	return &scoped
+}
+
+// Close releases the database.
+func (s *Store) Close() error {
+	readErr := s.readOnly.Close()
+	if err := s.db.Close(); err != nil {
+		return err
+	}
+	return readErr
+}
+
+// SyncState records how far the mirror has caught up for one marketplace. A pending next token means the last sync
+// stopped early; the next sync resumes from it instead of starting over.
+type SyncState struct {
+	MarketplaceID    string    `json:"marketplaceId"`
+	Watermark        time.Time `json:"watermark"`
+	PendingNextToken string    `json:"-"`
+	PendingBefore    time.Time `json:"pendingBefore,omitzero"`
+	LastSyncedAt     time.Time `json:"lastSyncedAt,omitzero"`
+	Orders           int       `json:"orders"`
+}
+
+// SyncState returns the state for a marketplace; the zero value (with MarketplaceID set) when it has never synced.
+func (s *Store) SyncState(ctx context.Context, marketplaceID string) (SyncState, error) {
+	state := SyncState{MarketplaceID: marketplaceID}
+
+	var watermark, pendingBefore, lastSynced string
+	err := s.db.QueryRowContext(ctx, `SELECT watermark, pending_next_token, pending_before, last_synced_at FROM sync_state WHERE profile = ? AND marketplace_id = ?`, s.profile, marketplaceID).
+		Scan(&watermark, &state.PendingNextToken, &pendingBefore, &lastSynced)
+	if err != nil && err != sql.ErrNoRows {
+		return SyncState{}, fmt.Errorf("reading sync state: %w", err)
+	}
+
+	state.Watermark = parseTime(watermark)
+	state.PendingBefore = parseTime(pendingBefore)
+	state.LastSyncedAt = parseTime(lastSynced)
+
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders WHERE profile = ? AND marketplace_id = ?`, s.profile, marketplaceID).Scan(&state.Orders); err != nil {
+		return SyncState{}, fmt.Errorf("counting orders: %w", err)
+	}
+
+	return state, nil
+}
+
+// SyncStates lists every marketplace that has synced at least once for the store's profile.
+func (s *Store) SyncStates(ctx context.Context) ([]SyncState, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT marketplace_id FROM sync_state WHERE profile = ? ORDER BY marketplace_id`, s.profile)
+	if err != nil {
+		return nil, fmt.Errorf("listing sync state: %w", err)
+	}
+
+	var marketplaces []string
+	for rows.Next() {
+		var marketplaceID string
+		if err := rows.Scan(&marketplaceID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("listing sync state: %w", err)
+		}
+		marketplaces = append(marketplaces, marketplaceID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing sync state: %w", err)
+	}
+
+	states := make([]SyncState, 0, len(marketplaces))
+	for _, marketplaceID := range marketplaces {
+		state, err := s.SyncState(ctx, marketplaceID)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// SaveSyncState stores the watermark and any pending resume token for a marketplace.
+func (s *Store) SaveSyncState(ctx context.Context, state SyncState) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO sync_state (profile, marketplace_id, watermark, pending_next_token, pending_before, last_synced_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (profile, marketplace_id) DO UPDATE SET
+			watermark = excluded.watermark,
+			pending_next_token = excluded.pending_next_token,
+			pending_before = excluded.pending_before,
+			last_synced_at = excluded.last_synced_at`,
+		s.profile, state.MarketplaceID, formatTime(state.Watermark), state.PendingNextToken, formatTime(state.PendingBefore), formatTime(state.LastSyncedAt))
+	if err != nil {
+		return fmt.Errorf("saving sync state: %w", err)
+	}
+	return nil
+}
+
+// DatasetState tracks incremental syncs that are not per marketplace, such as financial events.
+type DatasetState struct {
+	Dataset      string    `json:"dataset"`
+	Watermark    time.Time `json:"watermark"`
+	LastSyncedAt time.Time `json:"lastSyncedAt,omitzero"`
+}
+
+// DatasetState returns the state for a dataset; the zero value (with Dataset set) when it has never synced.
+func (s *Store) DatasetState(ctx context.Context, dataset string) (DatasetState, error) {
+	state := DatasetState{Dataset: dataset}
+
+	var watermark, lastSynced string
+	err := s.db.QueryRowContext(ctx, `SELECT watermark, last_synced_at FROM dataset_state WHERE profile = ? AND dataset = ?`, s.profile, dataset).Scan(&watermark, &lastSynced)
+	if err != nil && err != sql.ErrNoRows {
+		return DatasetState{}, fmt.Errorf("reading %s state: %w", dataset, err)
+	}
+
+	state.Watermark = parseTime(watermark)
+	state.LastSyncedAt = parseTime(lastSynced)
+	return state, nil
+}
+
+// SaveDatasetState stores the watermark for a dataset.
+func (s *Store) SaveDatasetState(ctx context.Context, state DatasetState) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO dataset_state (profile, dataset, watermark, last_synced_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (profile, dataset) DO UPDATE SET watermark = excluded.watermark, last_synced_at = excluded.last_synced_at`,
+		s.profile, state.Dataset, formatTime(state.Watermark), formatTime(state.LastSyncedAt))
+	if err != nil {
+		return fmt.Errorf("saving %s state: %w", state.Dataset, err)
+	}
+	return nil
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
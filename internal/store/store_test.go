@@ -0,0 +1,309 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestSaveAndQueryOrders(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	synced := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := []Order{
+		{
+			AmazonOrderID: "111", MarketplaceID: "ATVPDKIKX0DER", OrderStatus: "Shipped",
+			PurchaseDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), LastUpdateDate: synced,
+			OrderTotal: floatPtr(25), Currency: "USD", ShipState: "WA",
+			Items: []OrderItem{{OrderItemID: "a", SellerSKU: "SKU-1", QuantityOrdered: 1}},
+		},
+		{
+			AmazonOrderID: "222", MarketplaceID: "ATVPDKIKX0DER", OrderStatus: "Unshipped",
+			PurchaseDate: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), LastUpdateDate: synced,
+			OrderTotal: floatPtr(40), Currency: "USD", ShipState: "CA",
+			Items: []OrderItem{{OrderItemID: "b", SellerSKU: "SKU-2", QuantityOrdered: 2}},
+		},
+	}
+	if err := s.SaveOrders(ctx, orders, synced); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// Saving again without items keeps the earlier items.
+	orders[0].OrderStatus = "Canceled"
+	orders[0].Items = nil
+	if err := s.SaveOrders(ctx, orders[:1], synced); err != nil {
+		t.Fatalf("resave: %v", err)
+	}
+
+	result, err := s.QueryOrders(ctx, OrderQuery{SellerSKU: "SKU-1", IncludeItems: true})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if result.Matched != 1 || result.Orders[0].OrderStatus != "Canceled" || len(result.Orders[0].Items) != 1 {
+		t.Fatalf("unexpected SKU query result %+v", result)
+	}
+
+	result, err = s.QueryOrders(ctx, OrderQuery{
+		PurchasedAfter:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PurchasedBefore: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		MinTotal:        floatPtr(10),
+		Limit:           1,
+	})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if result.Matched != 2 || len(result.Orders) != 1 || result.Orders[0].AmazonOrderID != "222" || result.TotalsByCurrency["USD"] != 65 {
+		t.Fatalf("unexpected date query result %+v", result)
+	}
+
+	result, err = s.QueryOrders(ctx, OrderQuery{ShipState: "ca", OrderStatuses: []string{"Unshipped"}})
+	if err != nil || result.Matched != 1 {
+		t.Fatalf("unexpected state query result %+v (%v)", result, err)
+	}
+}
+
+func TestSyncState(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+
+	state, err := s.SyncState(ctx, "ATVPDKIKX0DER")
+	if err != nil || !state.Watermark.IsZero() {
+		t.Fatalf("expected empty state, got %+v (%v)", state, err)
+	}
+
+	state.Watermark = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	state.PendingNextToken = "token"
+	if err := s.SaveSyncState(ctx, state); err != nil {
+		t.Fatalf("save state: %v", err)
+	}
+
+	states, err := s.SyncStates(ctx)
+	if err != nil || len(states) != 1 || states[0].PendingNextToken != "token" || !states[0].Watermark.Equal(state.Watermark) {
+		t.Fatalf("unexpected states %+v (%v)", states, err)
+	}
+}
+
+func TestProfilesKeepSeparateOrders(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	us, eu := s.ForProfile("us"), s.ForProfile("eu")
+	synced := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, scoped := range []*Store{us, eu} {
+		order := Order{
+			AmazonOrderID: "111", MarketplaceID: "ATVPDKIKX0DER", OrderStatus: "Shipped", ShipState: scoped.profile,
+			PurchaseDate: synced, LastUpdateDate: synced,
+			Items: []OrderItem{{OrderItemID: "a", SellerSKU: "SKU-" + scoped.profile}},
+		}
+		if err := scoped.SaveOrders(ctx, []Order{order}, synced); err != nil {
+			t.Fatalf("save %s: %v", scoped.profile, err)
+		}
+		if err := scoped.SaveSyncState(ctx, SyncState{MarketplaceID: "ATVPDKIKX0DER", PendingNextToken: scoped.profile}); err != nil {
+			t.Fatalf("save %s state: %v", scoped.profile, err)
+		}
+	}
+
+	result, err := us.QueryOrders(ctx, OrderQuery{IncludeItems: true})
+	if err != nil || result.Matched != 1 || result.Orders[0].ShipState != "us" || result.Orders[0].Items[0].SellerSKU != "SKU-us" {
+		t.Fatalf("unexpected us orders %+v (%v)", result, err)
+	}
+	if result, err := eu.QueryOrders(ctx, OrderQuery{SellerSKU: "SKU-us"}); err != nil || result.Matched != 0 {
+		t.Fatalf("eu should not see us items, got %+v (%v)", result, err)
+	}
+	state, err := eu.SyncState(ctx, "ATVPDKIKX0DER")
+	if err != nil || state.PendingNextToken != "eu" || state.Orders != 1 {
+		t.Fatalf("unexpected eu state %+v (%v)", state, err)
+	}
+	if states, err := s.SyncStates(ctx); err != nil || len(states) != 0 {
+		t.Fatalf("the default profile has never synced, got %+v (%v)", states, err)
+	}
+}
+
+func TestProfilesKeepSeparateAnalytics(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+	us, eu := s.ForProfile("us"), s.ForProfile("eu")
+	synced := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	after, before := synced.AddDate(0, 0, -7), synced
+
+	for _, scoped := range []*Store{us, eu} {
+		inventory := []InventorySummary{{SellerSKU: "SKU-" + scoped.profile, FulfillableQuantity: 3}}
+		if err := scoped.ReplaceInventory(ctx, "ATVPDKIKX0DER", inventory, synced); err != nil {
+			t.Fatalf("save %s inventory: %v", scoped.profile, err)
+		}
+		events := []FinancialEvent{{EventType: "Shipment", PostedDate: after, AmazonOrderID: "111", AmountKind: "charge", Amount: 10}}
+		if err := scoped.ReplaceFinancialEvents(ctx, after, before, events); err != nil {
+			t.Fatalf("save %s events: %v", scoped.profile, err)
+		}
+		if err := scoped.ImportReportRows(ctx, "doc-1", "GET_FLAT_FILE", []string{"sku"}, [][]string{{scoped.profile}}, synced); err != nil {
+			t.Fatalf("import %s report: %v", scoped.profile, err)
+		}
+		if err := scoped.SaveDatasetState(ctx, DatasetState{Dataset: "financialEvents", Watermark: before, LastSyncedAt: synced}); err != nil {
+			t.Fatalf("save %s dataset state: %v", scoped.profile, err)
+		}
+	}
+
+	// A fresh snapshot, an empty re-sync and a re-import for one profile must leave the other profile's rows alone.
+	if err := us.ReplaceInventory(ctx, "ATVPDKIKX0DER", nil, synced); err != nil {
+		t.Fatalf("replace us inventory: %v", err)
+	}
+	if err := us.ReplaceFinancialEvents(ctx, after, before, nil); err != nil {
+		t.Fatalf("replace us events: %v", err)
+	}
+	if err := us.ImportReportRows(ctx, "doc-1", "GET_FLAT_FILE", []string{"sku"}, [][]string{{"us"}, {"us"}}, synced); err != nil {
+		t.Fatalf("reimport us report: %v", err)
+	}
+
+	for statement, want := range map[string]string{
+		"SELECT group_concat(profile || ':' || seller_sku) FROM inventory_summaries":                           "eu:SKU-eu",
+		"SELECT group_concat(profile) FROM financial_events":                                                   "eu",
+		"SELECT group_concat(profile || ':' || json_extract(data, '$.sku') ORDER BY profile) FROM report_rows": "eu:eu,us:us,us:us",
+	} {
+		result, err := s.Query(ctx, SQLQuery{Statement: statement})
+		if err != nil || len(result.Rows) != 1 || result.Rows[0][0] != want {
+			t.Fatalf("%s: expected %q, got %+v (%v)", statement, want, result, err)
+		}
+	}
+
+	state, err := eu.DatasetState(ctx, "financialEvents")
+	if err != nil || !state.Watermark.Equal(before) {
+		t.Fatalf("unexpected eu dataset state %+v (%v)", state, err)
+	}
+	if state, err := s.DatasetState(ctx, "financialEvents"); err != nil || !state.Watermark.IsZero() {
+		t.Fatalf("the default profile has never synced, got %+v (%v)", state, err)
+	}
+}
+
+func TestOpenMigratesUnscopedTables(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orders.db")
+
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	for _, statement := range []string{
+		`CREATE TABLE orders (amazon_order_id TEXT PRIMARY KEY, marketplace_id TEXT NOT NULL, order_status TEXT NOT NULL,
+			fulfillment_channel TEXT NOT NULL DEFAULT '', sales_channel TEXT NOT NULL DEFAULT '', purchase_date TEXT NOT NULL,
+			last_update_date TEXT NOT NULL, order_total REAL, currency TEXT NOT NULL DEFAULT '', ship_city TEXT NOT NULL DEFAULT '',
+			ship_state TEXT NOT NULL DEFAULT '', ship_postal_code TEXT NOT NULL DEFAULT '', ship_country TEXT NOT NULL DEFAULT '',
+			raw TEXT NOT NULL, synced_at TEXT NOT NULL)`,
+		`CREATE INDEX orders_purchase_date ON orders (purchase_date)`,
+		`CREATE TABLE order_items (amazon_order_id TEXT NOT NULL REFERENCES orders (amazon_order_id) ON DELETE CASCADE,
+			order_item_id TEXT NOT NULL, seller_sku TEXT NOT NULL DEFAULT '', asin TEXT NOT NULL DEFAULT '',
+			title TEXT NOT NULL DEFAULT '', quantity_ordered INTEGER NOT NULL DEFAULT 0, quantity_shipped INTEGER NOT NULL DEFAULT 0,
+			item_price REAL, currency TEXT NOT NULL DEFAULT '', raw TEXT NOT NULL, PRIMARY KEY (amazon_order_id, order_item_id))`,
+		`CREATE TABLE sync_state (marketplace_id TEXT PRIMARY KEY, watermark TEXT NOT NULL DEFAULT '',
+			pending_next_token TEXT NOT NULL DEFAULT '', pending_before TEXT NOT NULL DEFAULT '', last_synced_at TEXT NOT NULL DEFAULT '')`,
+		`INSERT INTO orders (amazon_order_id, marketplace_id, order_status, purchase_date, last_update_date, raw, synced_at)
+			VALUES ('111', 'ATVPDKIKX0DER', 'Shipped', '2026-01-05T00:00:00Z', '2026-01-05T00:00:00Z', '{}', '2026-02-01T00:00:00Z')`,
+		`INSERT INTO order_items (amazon_order_id, order_item_id, seller_sku, raw) VALUES ('111', 'a', 'SKU-1', '{}')`,
+		`INSERT INTO sync_state (marketplace_id, watermark) VALUES ('ATVPDKIKX0DER', '2026-01-31T00:00:00Z')`,
+		`CREATE TABLE inventory_summaries (marketplace_id TEXT NOT NULL, seller_sku TEXT NOT NULL, asin TEXT NOT NULL DEFAULT '',
+			fn_sku TEXT NOT NULL DEFAULT '', product_name TEXT NOT NULL DEFAULT '', condition TEXT NOT NULL DEFAULT '',
+			fulfillable_quantity INTEGER NOT NULL DEFAULT 0, inbound_working_quantity INTEGER NOT NULL DEFAULT 0,
+			inbound_shipped_quantity INTEGER NOT NULL DEFAULT 0, inbound_receiving_quantity INTEGER NOT NULL DEFAULT 0,
+			reserved_quantity INTEGER NOT NULL DEFAULT 0, unfulfillable_quantity INTEGER NOT NULL DEFAULT 0,
+			total_quantity INTEGER NOT NULL DEFAULT 0, last_updated_time TEXT NOT NULL DEFAULT '', raw TEXT NOT NULL,
+			synced_at TEXT NOT NULL, PRIMARY KEY (marketplace_id, seller_sku))`,
+		`CREATE TABLE financial_events (event_type TEXT NOT NULL, posted_date TEXT NOT NULL, amazon_order_id TEXT NOT NULL DEFAULT '',
+			seller_sku TEXT NOT NULL DEFAULT '', order_item_id TEXT NOT NULL DEFAULT '', quantity INTEGER NOT NULL DEFAULT 0,
+			amount_kind TEXT NOT NULL, amount_type TEXT NOT NULL DEFAULT '', amount REAL NOT NULL DEFAULT 0,
+			currency TEXT NOT NULL DEFAULT '', marketplace_name TEXT NOT NULL DEFAULT '')`,
+		`CREATE INDEX financial_events_posted_date ON financial_events (posted_date)`,
+		`CREATE INDEX financial_events_order ON financial_events (amazon_order_id)`,
+		`CREATE TABLE report_rows (report_document_id TEXT NOT NULL, report_type TEXT NOT NULL DEFAULT '', row_number INTEGER NOT NULL,
+			data TEXT NOT NULL, imported_at TEXT NOT NULL, PRIMARY KEY (report_document_id, row_number))`,
+		`CREATE INDEX report_rows_type ON report_rows (report_type)`,
+		`CREATE TABLE dataset_state (dataset TEXT PRIMARY KEY, watermark TEXT NOT NULL DEFAULT '', last_synced_at TEXT NOT NULL DEFAULT '')`,
+		`INSERT INTO inventory_summaries (marketplace_id, seller_sku, raw, synced_at) VALUES ('ATVPDKIKX0DER', 'SKU-1', '{}', '2026-02-01T00:00:00Z')`,
+		`INSERT INTO financial_events (event_type, posted_date, amount_kind) VALUES ('Shipment', '2026-01-05T00:00:00Z', 'charge')`,
+		`INSERT INTO report_rows (report_document_id, row_number, data, imported_at) VALUES ('doc-1', 1, '{}', '2026-02-01T00:00:00Z')`,
+		`INSERT INTO dataset_state (dataset, watermark) VALUES ('financialEvents', '2026-01-31T00:00:00Z')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("creating old schema: %v", err)
+		}
+	}
+	db.Close()
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("open migrated store: %v", err)
+	}
+	defer s.Close()
+
+	result, err := s.QueryOrders(ctx, OrderQuery{SellerSKU: "SKU-1", IncludeItems: true})
+	if err != nil || result.Matched != 1 || len(result.Orders[0].Items) != 1 {
+		t.Fatalf("expected the old order under the default profile, got %+v (%v)", result, err)
+	}
+	state, err := s.SyncState(ctx, "ATVPDKIKX0DER")
+	if err != nil || state.Watermark.IsZero() {
+		t.Fatalf("expected the old watermark, got %+v (%v)", state, err)
+	}
+	for _, table := range []string{"inventory_summaries", "financial_events", "report_rows"} {
+		result, err := s.Query(ctx, SQLQuery{Statement: "SELECT COUNT(*) FROM " + table + " WHERE profile = ''"})
+		if err != nil || result.Rows[0][0] != int64(1) {
+			t.Fatalf("expected the old %s row under the default profile, got %+v (%v)", table, result, err)
+		}
+	}
+	if dataset, err := s.DatasetState(ctx, "financialEvents"); err != nil || dataset.Watermark.IsZero() {
+		t.Fatalf("expected the old dataset watermark, got %+v (%v)", dataset, err)
+	}
+	if err := s.ForProfile("eu").SaveOrders(ctx, []Order{{AmazonOrderID: "111", MarketplaceID: "A1F83G8C2ARO7P", OrderStatus: "Shipped"}}, time.Now()); err != nil {
+		t.Fatalf("the migrated key should admit the same order id for another profile: %v", err)
+	}
+}
+
+func TestQueryIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	s := openTestStore(t)
+
+	if err := s.SaveInventory(ctx, "ATVPDKIKX0DER", []InventorySummary{
+		{SellerSKU: "SKU-1", FulfillableQuantity: 7},
+		{SellerSKU: "SKU-2", FulfillableQuantity: 3},
+	}, time.Now()); err != nil {
+		t.Fatalf("save inventory: %v", err)
+	}
+
+	result, err := s.Query(ctx, SQLQuery{Statement: "SELECT seller_sku, fulfillable_quantity FROM inventory_summaries ORDER BY seller_sku;", MaxRows: 1})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if !result.Truncated || len(result.Rows) != 1 || result.Rows[0][0] != "SKU-1" || len(result.Columns) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	for _, statement := range []string{
+		"DELETE FROM inventory_summaries",
+		"SELECT 1; DELETE FROM inventory_summaries",
+	} {
+		if _, err := s.Query(ctx, SQLQuery{Statement: statement}); err != ErrNotReadOnly {
+			t.Fatalf("expected %q to be rejected, got %v", statement, err)
+		}
+	}
+
+	// The keyword check passes this one; the read-only connection must still refuse it.
+	if _, err := s.Query(ctx, SQLQuery{Statement: "WITH gone AS (SELECT 1) DELETE FROM inventory_summaries"}); err == nil {
+		t.Fatalf("expected a write through WITH to fail")
+	}
+	result, _ = s.Query(ctx, SQLQuery{Statement: "SELECT COUNT(*) FROM inventory_summaries"})
+	if result.Rows[0][0] != int64(2) {
+		t.Fatalf("expected inventory to survive, got %+v", result.Rows)
+	}
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+type analyticsSQLArgs struct {
+	Query          string `json:"query"`
+	MaxRows        *int   `json:"maxRows"`
+	TimeoutSeconds *int   `json:"timeoutSeconds"`
+}
+
+type analyticsSQLResult struct {
+	Columns     []string  `json:"columns"`
+	Rows        [][]any   `json:"rows"`
+	RowCount    int       `json:"rowCount"`
+	Truncated   bool      `json:"truncated"`
+	RetrievedAt time.Time `json:"retrievedAt"`
+}
+
+func newAnalyticsTools(deps Dependencies) []registeredTool {
+	localStore, redactor := deps.Store, deps.Redactor
+
+	sqlHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args analyticsSQLArgs) (*mcp.CallToolResult, error) {
+		return executeAnalyticsSQL(ctx, args, localStore, redactor)
+	})
+
+	return []registeredTool{
+		toolFromSpec(analyticsSQLSpec, sqlHandler),
+	}
+}
+
+func executeAnalyticsSQL(ctx context.Context, args analyticsSQLArgs, localStore *store.Store, redactor *redact.Redactor) (*mcp.CallToolResult, error) {
+	if localStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	statement := strings.TrimSpace(args.Query)
+	if statement == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+
+	query := store.SQLQuery{Statement: statement}
+	if args.MaxRows != nil {
+		query.MaxRows = *args.MaxRows
+	}
+	if args.TimeoutSeconds != nil {
+		query.Timeout = time.Duration(*args.TimeoutSeconds) * time.Second
+	}
+
+	found, err := localStore.Query(ctx, query)
+	if err != nil {
+		if errors.Is(err, store.ErrNotReadOnly) {
+			return mcp.NewToolResultError("analytics.sql " + err.Error() + "; the store is read-only here"), nil
+		}
+		return mcp.NewToolResultErrorFromErr("analytics.sql failed", err), nil
+	}
+	if redactor != nil {
+		paths, err := redactAnalyticsRows(found.Columns, found.Rows, redactor)
+		if err != nil {
+			return mcp.NewToolResultError("analytics.sql result withheld: " + err.Error()), nil
+		}
+		if len(paths) > 0 {
+			log.Printf("[INFO] analytics.sql: redacted PII fields %s", strings.Join(uniqueStrings(paths), ", "))
+		}
+	}
+
+	result := analyticsSQLResult{
+		Columns:     found.Columns,
+		Rows:        found.Rows,
+		RowCount:    len(found.Rows),
+		Truncated:   found.Truncated,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Query returned %d rows", result.RowCount)
+	if result.Truncated {
+		fallback += " (truncated at maxRows)"
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// redactAnalyticsRows redacts rows in place. Positional rows give the result redactor no field names, so each cell is
+// redacted under its column's name instead. Text cells holding JSON, such as orders.raw and report_rows.data, are
+// decoded, redacted and encoded again; a cell that starts like JSON but does not parse, such as a substr of raw, cannot
+// be inspected and withholds the whole result.
+func redactAnalyticsRows(columns []string, rows [][]any, redactor *redact.Redactor) ([]string, error) {
+	var paths []string
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(columns) {
+				break
+			}
+
+			value, jsonText := cell, false
+			if text, ok := cell.(string); ok {
+				trimmed := strings.TrimSpace(text)
+				if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+					var decoded any
+					if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+						return nil, fmt.Errorf("column %s holds JSON that could not be parsed for PII redaction; select its fields with json_extract instead", columns[i])
+					}
+					value, jsonText = decoded, true
+				}
+			}
+
+			field := analyticsColumnField(columns[i])
+			redacted, err := redactor.Value(map[string]any{field: value})
+			if err != nil {
+				return nil, err
+			}
+			if !redacted.Changed() {
+				continue
+			}
+			paths = append(paths, redacted.Paths()...)
+
+			replacement := redacted.Value.(map[string]any)[field]
+			if jsonText && replacement != nil {
+				encoded, err := json.Marshal(replacement)
+				if err != nil {
+					return nil, err
+				}
+				replacement = string(encoded)
+			}
+			row[i] = replacement
+		}
+	}
+	return paths, nil
+}
+
+// analyticsColumnField names the field a column holds. Unaliased JSON extractions are named after the whole
+// expression, so json_extract(data, '$."buyer-email"') and data->>'buyer-email' are reduced to their last path element.
+func analyticsColumnField(column string) string {
+	if !strings.ContainsAny(column, "$>") {
+		return column
+	}
+	last := column[strings.LastIndexAny(column, "$.>")+1:]
+	return strings.Trim(last, `'"()[] `)
+}
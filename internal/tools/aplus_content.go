@@ -0,0 +1,569 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	aplusBasePath            = "/aplus/2020-11-01"
+	aplusMaxContentModules   = 7
+	aplusIssueSeverityError  = "ERROR"
+	aplusIssueSeverityWarn   = "WARNING"
+	aplusIssueSourceLocal    = "local"
+	aplusIssueSourceAmazon   = "amazon"
+	aplusDefaultIncludedData = "CONTENTS,METADATA"
+)
+
+type aplusSearchContentDocumentsArgs struct {
+	MarketplaceID string `json:"marketplaceId"`
+	PageToken     string `json:"pageToken"`
+	pagingArgs
+}
+
+type aplusSearchContentDocumentsResult struct {
+	MarketplaceID string                       `json:"marketplaceId"`
+	Documents     []aplusContentMetadataRecord `json:"documents"`
+	NextPageToken string                       `json:"nextPageToken,omitempty"`
+	Warnings      []spAPIError                 `json:"warnings,omitempty"`
+	Paging        *pagingSummary               `json:"paging,omitempty"`
+	RetrievedAt   time.Time                    `json:"retrievedAt"`
+}
+
+type aplusGetContentDocumentArgs struct {
+	ContentReferenceKey string   `json:"contentReferenceKey"`
+	MarketplaceID       string   `json:"marketplaceId"`
+	IncludedDataSet     []string `json:"includedDataSet"`
+}
+
+type aplusGetContentDocumentResult struct {
+	MarketplaceID string             `json:"marketplaceId"`
+	ContentRecord aplusContentRecord `json:"contentRecord"`
+	Warnings      []spAPIError       `json:"warnings,omitempty"`
+	RetrievedAt   time.Time          `json:"retrievedAt"`
+}
+
+type aplusValidateContentDocumentArgs struct {
+	MarketplaceID   string         `json:"marketplaceId"`
+	ContentDocument map[string]any `json:"contentDocument"`
+	ASINs           []string       `json:"asins"`
+}
+
+type aplusWriteContentDocumentArgs struct {
+	ContentReferenceKey string         `json:"contentReferenceKey"`
+	MarketplaceID       string         `json:"marketplaceId"`
+	ContentDocument     map[string]any `json:"contentDocument"`
+	ASINs               []string       `json:"asins"`
+	ValidateOnly        bool           `json:"validateOnly"`
+}
+
+type aplusWriteContentDocumentResult struct {
+	ContentReferenceKey string       `json:"contentReferenceKey"`
+	MarketplaceID       string       `json:"marketplaceId"`
+	Warnings            []spAPIError `json:"warnings,omitempty"`
+	RetrievedAt         time.Time    `json:"retrievedAt"`
+}
+
+// aplusValidationIssue is a single problem found while validating a content document, either by the local pre-checks
+// or by Amazon's validateContentDocumentAsinRelations operation.
+type aplusValidationIssue struct {
+	Severity string `json:"severity"`
+	Source   string `json:"source"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Details  string `json:"details,omitempty"`
+}
+
+type aplusValidationResult struct {
+	MarketplaceID string                 `json:"marketplaceId"`
+	ASINs         []string               `json:"asins,omitempty"`
+	Valid         bool                   `json:"valid"`
+	Issues        []aplusValidationIssue `json:"issues"`
+	RetrievedAt   time.Time              `json:"retrievedAt"`
+}
+
+type aplusPostAsinRelationsArgs struct {
+	ContentReferenceKey string   `json:"contentReferenceKey"`
+	MarketplaceID       string   `json:"marketplaceId"`
+	ASINs               []string `json:"asins"`
+}
+
+type aplusPostAsinRelationsResult struct {
+	ContentReferenceKey string       `json:"contentReferenceKey"`
+	MarketplaceID       string       `json:"marketplaceId"`
+	ASINs               []string     `json:"asins"`
+	Warnings            []spAPIError `json:"warnings,omitempty"`
+	RetrievedAt         time.Time    `json:"retrievedAt"`
+}
+
+func newAplusContentTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	searchHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args aplusSearchContentDocumentsArgs) (*mcp.CallToolResult, error) {
+		return executeAplusSearchContentDocuments(withProgressToken(ctx, req), args, spClient)
+	})
+
+	getHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args aplusGetContentDocumentArgs) (*mcp.CallToolResult, error) {
+		return executeAplusGetContentDocument(ctx, args, spClient)
+	})
+
+	validateHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args aplusValidateContentDocumentArgs) (*mcp.CallToolResult, error) {
+		return executeAplusValidateContentDocument(ctx, args, spClient)
+	})
+
+	createHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args aplusWriteContentDocumentArgs) (*mcp.CallToolResult, error) {
+		return executeAplusWriteContentDocument(ctx, aplusCreateContentDocumentSpec.Name, args, deps)
+	})
+
+	updateHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args aplusWriteContentDocumentArgs) (*mcp.CallToolResult, error) {
+		return executeAplusWriteContentDocument(ctx, aplusUpdateContentDocumentSpec.Name, args, deps)
+	})
+
+	postAsinRelationsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args aplusPostAsinRelationsArgs) (*mcp.CallToolResult, error) {
+		return executeAplusPostContentDocumentAsinRelations(ctx, args, spClient)
+	})
+
+	return []registeredTool{
+		toolFromSpec(aplusSearchContentDocumentsSpec, searchHandler),
+		toolFromSpec(aplusGetContentDocumentSpec, getHandler),
+		toolFromSpec(aplusValidateContentDocumentSpec, validateHandler),
+		// Create and update keep a validation-only mode, so they apply the write gate themselves.
+		newDryRunTool(aplusCreateContentDocumentSpec, deps, createHandler),
+		newDryRunTool(aplusUpdateContentDocumentSpec, deps, updateHandler),
+		newWriteTool(aplusPostContentDocumentAsinRelationsSpec, deps, postAsinRelationsHandler),
+	}
+}
+
+func executeAplusSearchContentDocuments(ctx context.Context, args aplusSearchContentDocumentsArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	var warnings []spAPIError
+	fetch := func(ctx context.Context, token string) ([]aplusContentMetadataRecord, string, *mcp.CallToolResult) {
+		query := url.Values{"marketplaceId": {marketplaceID}}
+		setQueryValue(query, "pageToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   aplusBasePath + "/contentDocuments",
+			Query:  query,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("aplus.searchContentDocuments request failed", err)
+		}
+
+		decoded, decodeErr := decodeAplusSearchContentDocuments(body)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("searchContentDocuments", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode aplus.searchContentDocuments response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("searchContentDocuments", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("aplus.searchContentDocuments response payload is empty")
+		}
+
+		warnings = append(warnings, decoded.warnings...)
+		return decoded.records, decoded.nextPageToken, nil
+	}
+
+	documents, next, paging, failure := collectPages(ctx, pagedOperation{tool: aplusSearchContentDocumentsSpec.Name, operation: aplusSearchContentDocumentsSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.PageToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := aplusSearchContentDocumentsResult{
+		MarketplaceID: marketplaceID,
+		Documents:     documents,
+		NextPageToken: next,
+		Warnings:      warnings,
+		Paging:        paging,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d A+ content documents for %s", len(result.Documents), marketplaceID)
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextPageToken != "" {
+		fallback = fmt.Sprintf("%s, more available via pageToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeAplusGetContentDocument(ctx context.Context, args aplusGetContentDocumentArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	contentReferenceKey := strings.TrimSpace(args.ContentReferenceKey)
+	if contentReferenceKey == "" {
+		return mcp.NewToolResultError("contentReferenceKey is required"), nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	includedDataSet := aplusDefaultIncludedData
+	if dataSets := trimStringSlice(args.IncludedDataSet); len(dataSets) > 0 {
+		includedDataSet = strings.Join(dataSets, ",")
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   aplusBasePath + "/contentDocuments/" + url.PathEscape(contentReferenceKey),
+		Query:  url.Values{"marketplaceId": {marketplaceID}, "includedDataSet": {includedDataSet}},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("aplus.getContentDocument request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeAplusGetContentDocument(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getContentDocument", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode aplus.getContentDocument response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getContentDocument", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("aplus.getContentDocument response payload is empty"), nil
+	}
+
+	result := aplusGetContentDocumentResult{
+		MarketplaceID: marketplaceID,
+		ContentRecord: decoded.record,
+		Warnings:      decoded.warnings,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved A+ content document %s", contentReferenceKey)
+	if metadata := result.ContentRecord.ContentMetadata; metadata != nil {
+		fallback = fmt.Sprintf("%s (%s, status: %s)", fallback, metadata.Name, metadata.Status)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeAplusValidateContentDocument(ctx context.Context, args aplusValidateContentDocumentArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	if len(args.ContentDocument) == 0 {
+		return mcp.NewToolResultError("contentDocument is required"), nil
+	}
+
+	return validateAplusContentDocument(ctx, spClient, marketplaceID, args.ContentDocument, trimStringSlice(args.ASINs))
+}
+
+func executeAplusWriteContentDocument(ctx context.Context, toolName string, args aplusWriteContentDocumentArgs, deps Dependencies) (*mcp.CallToolResult, error) {
+	spClient := deps.SellingPartner
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	isUpdate := toolName == aplusUpdateContentDocumentSpec.Name
+
+	contentReferenceKey := strings.TrimSpace(args.ContentReferenceKey)
+	if isUpdate && contentReferenceKey == "" {
+		return mcp.NewToolResultError("contentReferenceKey is required"), nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	if len(args.ContentDocument) == 0 {
+		return mcp.NewToolResultError("contentDocument is required"), nil
+	}
+
+	if args.ValidateOnly {
+		return validateAplusContentDocument(withoutDryRun(ctx), spClient, marketplaceID, args.ContentDocument, trimStringSlice(args.ASINs))
+	}
+
+	if !inDryRun(ctx) {
+		if failure := ensureWritesAllowed(deps, toolName); failure != nil {
+			return failure, nil
+		}
+	}
+
+	if issues := checkAplusContentDocument(args.ContentDocument); hasAplusErrors(issues) {
+		return mcp.NewToolResultStructured(aplusValidationResult{
+			MarketplaceID: marketplaceID,
+			Valid:         false,
+			Issues:        issues,
+			RetrievedAt:   time.Now().UTC(),
+		}, fmt.Sprintf("%s was not sent: %s", toolName, summariseAplusIssues(issues))), nil
+	}
+
+	operation := "createContentDocument"
+	path := aplusBasePath + "/contentDocuments"
+	if isUpdate {
+		operation = "updateContentDocument"
+		path = aplusBasePath + "/contentDocuments/" + url.PathEscape(contentReferenceKey)
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodPost,
+		Path:   path,
+		Query:  url.Values{"marketplaceId": {marketplaceID}},
+		Body:   map[string]any{"contentDocument": args.ContentDocument},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(toolName+" request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeAplusContentReference(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse(operation, httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode "+toolName+" response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse(operation, httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError(toolName + " response payload is empty"), nil
+	}
+
+	result := aplusWriteContentDocumentResult{
+		ContentReferenceKey: decoded.contentReferenceKey,
+		MarketplaceID:       marketplaceID,
+		Warnings:            decoded.warnings,
+		RetrievedAt:         time.Now().UTC(),
+	}
+
+	verb := "Created"
+	if isUpdate {
+		verb = "Updated"
+	}
+	fallback := fmt.Sprintf("%s A+ content document %s; attach ASINs with aplus.postContentDocumentAsinRelations", verb, result.ContentReferenceKey)
+	if len(result.Warnings) > 0 {
+		fallback = fmt.Sprintf("%s (warnings: %s)", fallback, formatSellingPartnerErrors(result.Warnings))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeAplusPostContentDocumentAsinRelations(ctx context.Context, args aplusPostAsinRelationsArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	contentReferenceKey := strings.TrimSpace(args.ContentReferenceKey)
+	if contentReferenceKey == "" {
+		return mcp.NewToolResultError("contentReferenceKey is required"), nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	// An empty ASIN set is meaningful: it detaches the document from every ASIN.
+	asins := trimStringSlice(args.ASINs)
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodPost,
+		Path:   aplusBasePath + "/contentDocuments/" + url.PathEscape(contentReferenceKey) + "/asins",
+		Query:  url.Values{"marketplaceId": {marketplaceID}},
+		Body:   map[string]any{"asinSet": asins},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("aplus.postContentDocumentAsinRelations request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeAplusMessages(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("postContentDocumentAsinRelations", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode aplus.postContentDocumentAsinRelations response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("postContentDocumentAsinRelations", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := aplusPostAsinRelationsResult{
+		ContentReferenceKey: contentReferenceKey,
+		MarketplaceID:       marketplaceID,
+		ASINs:               asins,
+		Warnings:            decoded.warnings,
+		RetrievedAt:         time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Set %d ASIN relations on A+ content document %s", len(asins), contentReferenceKey)
+	if len(asins) == 0 {
+		fallback = fmt.Sprintf("Removed all ASIN relations from A+ content document %s", contentReferenceKey)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// validateAplusContentDocument runs the local pre-checks and, when they pass, asks Amazon to validate the document.
+// Problems are returned as a structured issue list rather than a tool error so the assistant can fix and retry.
+func validateAplusContentDocument(ctx context.Context, spClient spapi.Client, marketplaceID string, document map[string]any, asins []string) (*mcp.CallToolResult, error) {
+	result := aplusValidationResult{
+		MarketplaceID: marketplaceID,
+		ASINs:         asins,
+		Issues:        checkAplusContentDocument(document),
+	}
+
+	if !hasAplusErrors(result.Issues) {
+		query := url.Values{"marketplaceId": {marketplaceID}}
+		if len(asins) > 0 {
+			query.Set("asinSet", strings.Join(asins, ","))
+		}
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodPost,
+			Path:   aplusBasePath + "/contentAsinValidations",
+			Query:  query,
+			Body:   map[string]any{"contentDocument": document},
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("aplus.validateContentDocumentAsinRelations request failed", err), nil
+		}
+
+		decoded, decodeErr := decodeAplusMessages(body)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("validateContentDocumentAsinRelations", httpResp, body, nil); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultErrorFromErr("failed to decode aplus.validateContentDocumentAsinRelations response", decodeErr), nil
+		}
+
+		// Schema violations come back as a 400 with an error list; those are validation findings, not call failures.
+		if len(decoded.apiErrors) == 0 {
+			if err := ensureSellingPartnerAPIResponse("validateContentDocumentAsinRelations", httpResp, body, nil); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		result.Issues = append(result.Issues, aplusIssuesFromAPI(aplusIssueSeverityError, decoded.apiErrors)...)
+		result.Issues = append(result.Issues, aplusIssuesFromAPI(aplusIssueSeverityWarn, decoded.warnings)...)
+	}
+
+	result.Valid = !hasAplusErrors(result.Issues)
+	result.RetrievedAt = time.Now().UTC()
+
+	fallback := "A+ content document is valid"
+	if len(asins) > 0 {
+		fallback = fmt.Sprintf("%s for ASINs %s", fallback, strings.Join(asins, ", "))
+	}
+	if len(result.Issues) > 0 {
+		fallback = fmt.Sprintf("A+ content document has %d issue(s): %s", len(result.Issues), summariseAplusIssues(result.Issues))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// checkAplusContentDocument catches the structural mistakes that would otherwise cost a round trip to Amazon.
+func checkAplusContentDocument(document map[string]any) []aplusValidationIssue {
+	issues := make([]aplusValidationIssue, 0)
+	addError := func(message string) {
+		issues = append(issues, aplusValidationIssue{Severity: aplusIssueSeverityError, Source: aplusIssueSourceLocal, Message: message})
+	}
+
+	for _, field := range []string{"name", "contentType", "locale"} {
+		if value, _ := document[field].(string); strings.TrimSpace(value) == "" {
+			addError(fmt.Sprintf("contentDocument.%s is required", field))
+		}
+	}
+
+	if contentType, _ := document["contentType"].(string); contentType != "" && contentType != "EBC" && contentType != "EMC" {
+		addError("contentDocument.contentType must be EBC or EMC")
+	}
+
+	modules, ok := document["contentModuleList"].([]any)
+	switch {
+	case !ok || len(modules) == 0:
+		addError("contentDocument.contentModuleList must contain at least one module")
+	case len(modules) > aplusMaxContentModules:
+		addError(fmt.Sprintf("contentDocument.contentModuleList allows at most %d modules", aplusMaxContentModules))
+	}
+
+	for i, raw := range modules {
+		module, ok := raw.(map[string]any)
+		if !ok {
+			addError(fmt.Sprintf("contentDocument.contentModuleList[%d] must be an object", i))
+			continue
+		}
+		if moduleType, _ := module["contentModuleType"].(string); strings.TrimSpace(moduleType) == "" {
+			addError(fmt.Sprintf("contentDocument.contentModuleList[%d].contentModuleType is required", i))
+		}
+	}
+
+	return issues
+}
+
+func aplusIssuesFromAPI(severity string, list []spAPIError) []aplusValidationIssue {
+	issues := make([]aplusValidationIssue, 0, len(list))
+	for _, apiErr := range list {
+		issues = append(issues, aplusValidationIssue{
+			Severity: severity,
+			Source:   aplusIssueSourceAmazon,
+			Code:     apiErr.Code,
+			Message:  strings.TrimSpace(apiErr.Message),
+			Details:  valueOrEmpty(apiErr.Details),
+		})
+	}
+	return issues
+}
+
+func hasAplusErrors(issues []aplusValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == aplusIssueSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func summariseAplusIssues(issues []aplusValidationIssue) string {
+	segments := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		segment := fmt.Sprintf("[%s] %s", issue.Severity, issue.Message)
+		if issue.Code != "" {
+			segment = fmt.Sprintf("%s (%s)", segment, issue.Code)
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "; ")
+}
@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// aplusContentMetadataRecord mirrors the A+ Content ContentMetadataRecord model.
+type aplusContentMetadataRecord struct {
+	ContentReferenceKey string               `json:"contentReferenceKey"`
+	ContentMetadata     aplusContentMetadata `json:"contentMetadata"`
+}
+
+type aplusContentMetadata struct {
+	Name          string   `json:"name"`
+	MarketplaceID string   `json:"marketplaceId"`
+	Status        string   `json:"status"`
+	BadgeSet      []string `json:"badgeSet,omitempty"`
+	UpdateTime    string   `json:"updateTime,omitempty"`
+}
+
+// aplusContentRecord keeps the content document as raw JSON; its module schema is large and passed through untouched.
+type aplusContentRecord struct {
+	ContentReferenceKey string                `json:"contentReferenceKey"`
+	ContentMetadata     *aplusContentMetadata `json:"contentMetadata,omitempty"`
+	ContentDocument     json.RawMessage       `json:"contentDocument,omitempty"`
+}
+
+type aplusSearchContentDocumentsDecoded struct {
+	records        []aplusContentMetadataRecord
+	nextPageToken  string
+	warnings       []spAPIError
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type aplusGetContentDocumentDecoded struct {
+	record         aplusContentRecord
+	warnings       []spAPIError
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type aplusContentReferenceDecoded struct {
+	contentReferenceKey string
+	warnings            []spAPIError
+	apiErrors           []spAPIError
+	payloadPresent      bool
+}
+
+type aplusMessagesDecoded struct {
+	warnings  []spAPIError
+	apiErrors []spAPIError
+}
+
+func decodeAplusSearchContentDocuments(body []byte) (aplusSearchContentDocumentsDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return aplusSearchContentDocumentsDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto aplusSearchContentDocumentsResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return aplusSearchContentDocumentsDecoded{}, err
+	}
+
+	decoded := aplusSearchContentDocumentsDecoded{
+		warnings:  dto.Warnings,
+		apiErrors: dto.Errors,
+	}
+
+	if dto.ContentMetadataRecords != nil {
+		decoded.payloadPresent = true
+		decoded.records = *dto.ContentMetadataRecords
+		decoded.nextPageToken = valueOrEmpty(dto.NextPageToken)
+	}
+
+	return decoded, nil
+}
+
+func decodeAplusGetContentDocument(body []byte) (aplusGetContentDocumentDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return aplusGetContentDocumentDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto aplusGetContentDocumentResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return aplusGetContentDocumentDecoded{}, err
+	}
+
+	decoded := aplusGetContentDocumentDecoded{
+		warnings:  dto.Warnings,
+		apiErrors: dto.Errors,
+	}
+
+	if dto.ContentRecord != nil {
+		decoded.payloadPresent = true
+		decoded.record = *dto.ContentRecord
+	}
+
+	return decoded, nil
+}
+
+func decodeAplusContentReference(body []byte) (aplusContentReferenceDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return aplusContentReferenceDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto aplusContentReferenceResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return aplusContentReferenceDecoded{}, err
+	}
+
+	decoded := aplusContentReferenceDecoded{
+		warnings:  dto.Warnings,
+		apiErrors: dto.Errors,
+	}
+
+	if key := strings.TrimSpace(dto.ContentReferenceKey); key != "" {
+		decoded.payloadPresent = true
+		decoded.contentReferenceKey = key
+	}
+
+	return decoded, nil
+}
+
+// decodeAplusMessages reads the warnings and errors lists shared by validation and relation responses. An empty body is
+// valid here because postContentDocumentAsinRelations may return no content.
+func decodeAplusMessages(body []byte) (aplusMessagesDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return aplusMessagesDecoded{}, nil
+	}
+
+	var dto aplusMessagesResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return aplusMessagesDecoded{}, err
+	}
+
+	return aplusMessagesDecoded{warnings: dto.Warnings, apiErrors: dto.Errors}, nil
+}
+
+type aplusSearchContentDocumentsResponseDTO struct {
+	Warnings               []spAPIError                  `json:"warnings,omitempty"`
+	Errors                 []spAPIError                  `json:"errors,omitempty"`
+	NextPageToken          *string                       `json:"nextPageToken,omitempty"`
+	ContentMetadataRecords *[]aplusContentMetadataRecord `json:"contentMetadataRecords,omitempty"`
+}
+
+type aplusGetContentDocumentResponseDTO struct {
+	Warnings      []spAPIError        `json:"warnings,omitempty"`
+	Errors        []spAPIError        `json:"errors,omitempty"`
+	ContentRecord *aplusContentRecord `json:"contentRecord,omitempty"`
+}
+
+type aplusContentReferenceResponseDTO struct {
+	Warnings            []spAPIError `json:"warnings,omitempty"`
+	Errors              []spAPIError `json:"errors,omitempty"`
+	ContentReferenceKey string       `json:"contentReferenceKey"`
+}
+
+type aplusMessagesResponseDTO struct {
+	Warnings []spAPIError `json:"warnings,omitempty"`
+	Errors   []spAPIError `json:"errors,omitempty"`
+}
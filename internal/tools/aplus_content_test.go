@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCheckAplusContentDocument(t *testing.T) {
+	valid := map[string]any{
+		"name":        "Spring launch",
+		"contentType": "EBC",
+		"locale":      "en-US",
+		"contentModuleList": []any{
+			map[string]any{"contentModuleType": "STANDARD_TEXT"},
+		},
+	}
+	if issues := checkAplusContentDocument(valid); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+
+	invalid := map[string]any{
+		"name":              "Spring launch",
+		"contentType":       "VIDEO",
+		"contentModuleList": []any{map[string]any{}},
+	}
+	issues := checkAplusContentDocument(invalid)
+	if !hasAplusErrors(issues) {
+		t.Fatalf("expected errors for invalid document")
+	}
+
+	summary := summariseAplusIssues(issues)
+	for _, want := range []string{"locale is required", "must be EBC or EMC", "contentModuleList[0].contentModuleType is required"} {
+		if !strings.Contains(summary, want) {
+			t.Fatalf("expected %q in %q", want, summary)
+		}
+	}
+}
+
+func TestAplusAsinRelationsRequireWrites(t *testing.T) {
+	tools := newAplusContentTools(Dependencies{})
+
+	for _, tool := range tools {
+		if tool.Tool.Name != aplusPostContentDocumentAsinRelationsSpec.Name {
+			continue
+		}
+		if tool.Tool.Annotations.ReadOnlyHint == nil || *tool.Tool.Annotations.ReadOnlyHint {
+			t.Fatalf("expected write tool to be annotated as not read-only")
+		}
+
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatalf("expected write tool to be refused when writes are disabled")
+		}
+		return
+	}
+
+	t.Fatalf("%s not registered", aplusPostContentDocumentAsinRelationsSpec.Name)
+}
+
+func TestContentMD5Base64(t *testing.T) {
+	if got := contentMD5Base64([]byte("hello")); got != "XUFAKrxLKna5cZ2REBfFkg==" {
+		t.Fatalf("unexpected digest %q", got)
+	}
+}
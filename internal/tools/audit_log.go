@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/audit"
+)
+
+const (
+	defaultAuditQueryLimit = 100
+	maxAuditQueryLimit     = 1000
+)
+
+type adminQueryAuditLogArgs struct {
+	Tool         string `json:"tool"`
+	Principal    string `json:"principal"`
+	Outcome      string `json:"outcome"`
+	Since        string `json:"since"`
+	Until        string `json:"until"`
+	MutatingOnly bool   `json:"mutatingOnly"`
+	Limit        *int   `json:"limit"`
+}
+
+type adminQueryAuditLogResult struct {
+	Records     []audit.Record `json:"records"`
+	Count       int            `json:"count"`
+	RetrievedAt time.Time      `json:"retrievedAt"`
+}
+
+func newAuditLogTools(deps Dependencies) []registeredTool {
+	auditLog := deps.AuditLog
+
+	queryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args adminQueryAuditLogArgs) (*mcp.CallToolResult, error) {
+		return executeAdminQueryAuditLog(ctx, args, auditLog)
+	})
+
+	return []registeredTool{
+		toolFromSpec(adminQueryAuditLogSpec, queryHandler),
+	}
+}
+
+func executeAdminQueryAuditLog(ctx context.Context, args adminQueryAuditLogArgs, auditLog audit.Querier) (*mcp.CallToolResult, error) {
+	if auditLog == nil {
+		return mcp.NewToolResultError("the audit log is not queryable; set SP_API_AUDIT_LOG to a file path to enable admin.queryAuditLog"), nil
+	}
+
+	filter := audit.Filter{
+		Tool:         strings.TrimSpace(args.Tool),
+		Principal:    strings.TrimSpace(args.Principal),
+		Outcome:      strings.TrimSpace(args.Outcome),
+		MutatingOnly: args.MutatingOnly,
+		Limit:        defaultAuditQueryLimit,
+	}
+	if args.Limit != nil {
+		if *args.Limit < 1 || *args.Limit > maxAuditQueryLimit {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxAuditQueryLimit)), nil
+		}
+		filter.Limit = *args.Limit
+	}
+
+	for _, bound := range []struct {
+		name   string
+		raw    string
+		target *time.Time
+	}{
+		{"since", args.Since, &filter.Since},
+		{"until", args.Until, &filter.Until},
+	} {
+		raw := strings.TrimSpace(bound.raw)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return mcp.NewToolResultError(bound.name + " must be an ISO 8601 timestamp"), nil
+		}
+		*bound.target = parsed
+	}
+
+	records, err := auditLog.Query(ctx, filter)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("admin.queryAuditLog failed", err), nil
+	}
+
+	result := adminQueryAuditLogResult{
+		Records:     records,
+		Count:       len(records),
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Found %d audit records", result.Count)
+	if result.Count == filter.Limit {
+		fallback += " (limit reached; narrow the filter or raise limit)"
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
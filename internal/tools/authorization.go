@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/onboarding"
+)
+
+type authBeginAuthorizationArgs struct {
+	Profile       string `json:"profile"`
+	MarketplaceID string `json:"marketplaceId"`
+	Draft         bool   `json:"draft"`
+	Overwrite     bool   `json:"overwrite"`
+}
+
+type authGetAuthorizationStatusArgs struct {
+	State string `json:"state"`
+}
+
+func newAuthorizationTools(deps Dependencies) []registeredTool {
+	flow := deps.Onboarding
+
+	beginHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args authBeginAuthorizationArgs) (*mcp.CallToolResult, error) {
+		return executeAuthBeginAuthorization(ctx, args, flow)
+	})
+	statusHandler := mcp.NewTypedToolHandler(func(_ context.Context, _ mcp.CallToolRequest, args authGetAuthorizationStatusArgs) (*mcp.CallToolResult, error) {
+		return executeAuthGetAuthorizationStatus(args, flow)
+	})
+
+	return []registeredTool{
+		toolFromSpec(authBeginAuthorizationSpec, beginHandler),
+		toolFromSpec(authGetAuthorizationStatusSpec, statusHandler),
+	}
+}
+
+func executeAuthBeginAuthorization(ctx context.Context, args authBeginAuthorizationArgs, flow *onboarding.Flow) (*mcp.CallToolResult, error) {
+	authorization, err := flow.Begin(ctx, onboarding.Request{
+		Profile:       args.Profile,
+		MarketplaceID: strings.TrimSpace(args.MarketplaceID),
+		Draft:         args.Draft,
+		Overwrite:     args.Overwrite,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	fallback := fmt.Sprintf("Ask the seller to open %s and approve the app before %s. Profile %q is saved once they do.",
+		authorization.ConsentURL, authorization.ExpiresAt.Format("15:04 MST"), authorization.Profile)
+	return mcp.NewToolResultStructured(authorization, fallback), nil
+}
+
+func executeAuthGetAuthorizationStatus(args authGetAuthorizationStatusArgs, flow *onboarding.Flow) (*mcp.CallToolResult, error) {
+	authorization, ok := flow.Lookup(strings.TrimSpace(args.State))
+	if !ok {
+		return mcp.NewToolResultError("no authorization with that state; it may be older than a day or from before a restart"), nil
+	}
+
+	fallback := fmt.Sprintf("Authorization for profile %q is %s", authorization.Profile, authorization.Status)
+	switch authorization.Status {
+	case onboarding.StatusCompleted:
+		fallback += fmt.Sprintf("; selling partner %s can be used with SP_API_PROFILE=%s", authorization.SellingPartnerID, authorization.Profile)
+	case onboarding.StatusFailed:
+		fallback += ": " + authorization.Error
+	}
+	return mcp.NewToolResultStructured(authorization, fallback), nil
+}
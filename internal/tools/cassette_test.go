@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// cassetteClient signs nothing; replayed cassettes match on method, path and query alone.
+type cassetteClient struct{}
+
+func (cassetteClient) AuthorizeRequest(req *http.Request) error {
+	req.Header.Set("X-Amz-Access-Token", "Atza|cassette")
+	return nil
+}
+func (cassetteClient) Endpoint() string                       { return "https://sellingpartnerapi-na.amazon.com" }
+func (cassetteClient) Status() spapi.Status                   { return spapi.Status{Ready: true} }
+func (cassetteClient) CheckCredentials(context.Context) error { return nil }
+
+func loadCassette(t *testing.T, name string) *spapi.Cassette {
+	t.Helper()
+	cassette, err := spapi.LoadCassette(filepath.Join("testdata", "cassettes", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cassette
+}
+
+// replayTool runs a tool end to end with its SP-API calls answered from cassette.
+func replayTool(t *testing.T, cassette *spapi.Cassette, name string, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	domain, _, _ := strings.Cut(name, ".")
+	tools, err := BuildAll(Dependencies{SellingPartner: cassetteClient{}, Transport: cassette, EnabledDomains: []string{domain}})
+	if err != nil {
+		t.Fatalf("BuildAll: %v", err)
+	}
+	for _, tool := range tools {
+		if tool.Tool.Name != name {
+			continue
+		}
+		req := mcp.CallToolRequest{}
+		req.Params.Name = name
+		req.Params.Arguments = arguments
+		result, err := tool.Handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return result
+	}
+	t.Fatalf("tool %s is not registered", name)
+	return nil
+}
+
+func TestDecodeReportsGetReportsFromCassette(t *testing.T) {
+	cassette := loadCassette(t, "reports_getReports.json")
+
+	decoded, err := decodeReportsGetReports(cassette.Interactions()[0].Response.Body)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded.reports) != 2 || decoded.nextToken == "" {
+		t.Fatalf("first page decoded to %d reports, nextToken %q", len(decoded.reports), decoded.nextToken)
+	}
+	if report := decoded.reports[1]; report.ReportDocumentId != nil || report.ProcessingStatus != "CANCELLED" {
+		t.Errorf("cancelled report decoded as %+v", report)
+	}
+
+	result := replayTool(t, cassette, "reports.getReports", map[string]any{
+		"reportTypes": []any{"GET_MERCHANT_LISTINGS_ALL_DATA"},
+		"allPages":    true,
+	})
+	output, ok := result.StructuredContent.(reportsGetReportsResult)
+	if result.IsError || !ok {
+		t.Fatalf("getReports: %+v", result)
+	}
+	if len(output.Reports) != 3 || output.NextToken != "" || output.Reports[2].ProcessingStatus != "FATAL" {
+		t.Errorf("getReports across both pages returned %+v", output)
+	}
+}
+
+func TestDecodeSalesOrderMetricsFromCassette(t *testing.T) {
+	cassette := loadCassette(t, "sales_getOrderMetrics.json")
+
+	decoded, err := decodeSalesOrderMetrics(cassette.Interactions()[0].Response.Body)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded.metrics) != 2 || decoded.metrics[0].AverageUnitPrice.Amount != "24.9879" || decoded.metrics[1].TotalSales.Amount != "0" {
+		t.Fatalf("decoded metrics %+v", decoded.metrics)
+	}
+
+	result := replayTool(t, cassette, "sales.getOrderMetrics", map[string]any{
+		"marketplaceIds":      []any{"ATVPDKIKX0DER"},
+		"interval":            "2025-01-01T00:00:00-08:00--2025-01-03T00:00:00-08:00",
+		"granularity":         "Day",
+		"granularityTimeZone": "America/Los_Angeles",
+	})
+	output, ok := result.StructuredContent.(salesGetOrderMetricsResult)
+	if result.IsError || !ok {
+		t.Fatalf("getOrderMetrics: %+v", result)
+	}
+	if len(output.Metrics) != 2 || output.Metrics[0].UnitCount != 14 || output.Metrics[0].TotalSales.Amount != "349.83" {
+		t.Errorf("getOrderMetrics returned %+v", output.Metrics)
+	}
+
+	// SP-API sometimes reports a rejected request as a 200 that carries only an errors list.
+	result = replayTool(t, cassette, "sales.getOrderMetrics", map[string]any{
+		"marketplaceIds": []any{"ATVPDKIKX0DER"},
+		"interval":       "2024-01-01T00:00:00Z--2025-02-01T00:00:00Z",
+		"granularity":    "Total",
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "The interval must not exceed 2 years.") {
+		t.Errorf("errors-with-200 returned %+v", result)
+	}
+}
+
+func TestDecodeProductPricingBodyFromCassette(t *testing.T) {
+	cassette := loadCassette(t, "productPricing_getPricing.json")
+
+	decoded, err := decodeProductPricingBody(cassette.Interactions()[0].Response.Body)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded.pricing) != 2 {
+		t.Fatalf("decoded %d price entries, want 2", len(decoded.pricing))
+	}
+
+	result := replayTool(t, cassette, "productPricing.getPricing", map[string]any{
+		"marketplaceId": "ATVPDKIKX0DER",
+		"itemType":      "Asin",
+		"asins":         []any{"B0000NOTFND", "B00V5DG6IQ"},
+	})
+	output, ok := result.StructuredContent.(productPricingGetPricingResult)
+	if result.IsError || !ok {
+		t.Fatalf("getPricing: %+v", result)
+	}
+	points, _ := json.Marshal(output.PricePoints)
+	if output.ItemCount != 2 || !strings.Contains(string(points), `"Amount":19.99`) || !strings.Contains(string(points), `"status":"ClientError"`) {
+		t.Errorf("getPricing returned %d items: %s", output.ItemCount, points)
+	}
+	if text := resultText(result); !strings.Contains(text, "B00V5DG6IQ") || !strings.Contains(text, "B0000NOTFND") {
+		t.Errorf("getPricing summary %q should name both ASINs", text)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
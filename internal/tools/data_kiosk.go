@@ -0,0 +1,618 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	dataKioskBasePath           = "/dataKiosk/2023-11-15"
+	dataKioskDefaultMaxRecords  = 500
+	dataKioskDefaultWaitSeconds = 60
+	dataKioskMaxWaitSeconds     = 300
+	dataKioskPollInterval       = 5 * time.Second
+	dataKioskStatusDone         = "DONE"
+	dataKioskStatusFatal        = "FATAL"
+	dataKioskStatusCancelled    = "CANCELLED"
+)
+
+type dataKioskCreateQueryArgs struct {
+	Query           string `json:"query"`
+	PaginationToken string `json:"paginationToken"`
+}
+
+type dataKioskCreateQueryResult struct {
+	QueryID     string    `json:"queryId"`
+	RetrievedAt time.Time `json:"retrievedAt"`
+}
+
+type dataKioskGetQueriesArgs struct {
+	ProcessingStatuses []string `json:"processingStatuses"`
+	PageSize           *int     `json:"pageSize"`
+	CreatedSince       string   `json:"createdSince"`
+	CreatedUntil       string   `json:"createdUntil"`
+	PaginationToken    string   `json:"paginationToken"`
+	pagingArgs
+}
+
+type dataKioskGetQueriesResult struct {
+	Queries     []dataKioskQuery `json:"queries"`
+	NextToken   string           `json:"nextToken,omitempty"`
+	Paging      *pagingSummary   `json:"paging,omitempty"`
+	RetrievedAt time.Time        `json:"retrievedAt"`
+}
+
+type dataKioskQueryIDArgs struct {
+	QueryID string `json:"queryId"`
+}
+
+type dataKioskGetQueryResult struct {
+	Query       dataKioskQuery `json:"query"`
+	RetrievedAt time.Time      `json:"retrievedAt"`
+}
+
+type dataKioskCancelQueryResult struct {
+	QueryID     string    `json:"queryId"`
+	Cancelled   bool      `json:"cancelled"`
+	RetrievedAt time.Time `json:"retrievedAt"`
+}
+
+type dataKioskGetDocumentArgs struct {
+	DocumentID string `json:"documentId"`
+	Download   *bool  `json:"download"`
+	MaxRecords *int   `json:"maxRecords"`
+}
+
+type dataKioskGetDocumentResult struct {
+	DocumentID  string            `json:"documentId"`
+	DocumentURL string            `json:"documentUrl"`
+	Records     []json.RawMessage `json:"records,omitempty"`
+	RecordCount int               `json:"recordCount"`
+	Truncated   bool              `json:"truncated,omitempty"`
+	RetrievedAt time.Time         `json:"retrievedAt"`
+}
+
+type dataKioskSavedQuerySummary struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	AggregateBy []string `json:"aggregateBy"`
+}
+
+type dataKioskListSavedQueriesResult struct {
+	SavedQueries []dataKioskSavedQuerySummary `json:"savedQueries"`
+}
+
+type dataKioskRunSavedQueryArgs struct {
+	Name           string   `json:"name"`
+	StartDate      string   `json:"startDate"`
+	EndDate        string   `json:"endDate"`
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	AggregateBy    string   `json:"aggregateBy"`
+	Wait           *bool    `json:"wait"`
+	WaitSeconds    *int     `json:"waitSeconds"`
+	MaxRecords     *int     `json:"maxRecords"`
+}
+
+type dataKioskRunSavedQueryResult struct {
+	Name             string            `json:"name"`
+	QueryID          string            `json:"queryId"`
+	Query            string            `json:"query"`
+	ProcessingStatus string            `json:"processingStatus,omitempty"`
+	DataDocumentID   string            `json:"dataDocumentId,omitempty"`
+	ErrorDocumentID  string            `json:"errorDocumentId,omitempty"`
+	Records          []json.RawMessage `json:"records,omitempty"`
+	RecordCount      int               `json:"recordCount"`
+	Truncated        bool              `json:"truncated,omitempty"`
+	RetrievedAt      time.Time         `json:"retrievedAt"`
+}
+
+func newDataKioskTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	createQueryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args dataKioskCreateQueryArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskCreateQuery(ctx, args, spClient)
+	})
+
+	getQueriesHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args dataKioskGetQueriesArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskGetQueries(withProgressToken(ctx, req), args, spClient)
+	})
+
+	getQueryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args dataKioskQueryIDArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskGetQuery(ctx, strings.TrimSpace(args.QueryID), spClient)
+	})
+
+	cancelQueryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args dataKioskQueryIDArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskCancelQuery(ctx, strings.TrimSpace(args.QueryID), spClient)
+	})
+
+	getDocumentHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args dataKioskGetDocumentArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskGetDocument(ctx, args, spClient)
+	})
+
+	listSavedQueriesHandler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return executeDataKioskListSavedQueries(), nil
+	}
+
+	runSavedQueryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args dataKioskRunSavedQueryArgs) (*mcp.CallToolResult, error) {
+		return executeDataKioskRunSavedQuery(ctx, args, spClient)
+	})
+
+	return []registeredTool{
+		newDryRunTool(dataKioskCreateQuerySpec, deps, createQueryHandler),
+		toolFromSpec(dataKioskGetQueriesSpec, getQueriesHandler),
+		toolFromSpec(dataKioskGetQuerySpec, getQueryHandler),
+		newDryRunTool(dataKioskCancelQuerySpec, deps, cancelQueryHandler),
+		toolFromSpec(dataKioskGetDocumentSpec, getDocumentHandler),
+		toolFromSpec(dataKioskListSavedQueriesSpec, listSavedQueriesHandler),
+		newDryRunTool(dataKioskRunSavedQuerySpec, deps, runSavedQueryHandler),
+	}
+}
+
+func executeDataKioskCreateQuery(ctx context.Context, args dataKioskCreateQueryArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	query := strings.TrimSpace(args.Query)
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+
+	queryID, err := createDataKioskQuery(ctx, spClient, query, strings.TrimSpace(args.PaginationToken))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := dataKioskCreateQueryResult{
+		QueryID:     queryID,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Created Data Kiosk query %s; poll dataKiosk.getQuery until processingStatus is DONE", result.QueryID)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeDataKioskGetQueries(ctx context.Context, args dataKioskGetQueriesArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	query := url.Values{}
+
+	if statuses := trimStringSlice(args.ProcessingStatuses); len(statuses) > 0 {
+		query.Set("processingStatuses", strings.Join(statuses, ","))
+	}
+
+	if args.PageSize != nil && *args.PageSize > 0 && *args.PageSize <= 100 {
+		query.Set("pageSize", strconv.Itoa(*args.PageSize))
+	}
+
+	if createdSince := strings.TrimSpace(args.CreatedSince); createdSince != "" {
+		if _, err := time.Parse(time.RFC3339, createdSince); err != nil {
+			return mcp.NewToolResultError("createdSince must be in ISO 8601 format"), nil
+		}
+		query.Set("createdSince", createdSince)
+	}
+
+	if createdUntil := strings.TrimSpace(args.CreatedUntil); createdUntil != "" {
+		if _, err := time.Parse(time.RFC3339, createdUntil); err != nil {
+			return mcp.NewToolResultError("createdUntil must be in ISO 8601 format"), nil
+		}
+		query.Set("createdUntil", createdUntil)
+	}
+
+	fetch := func(ctx context.Context, token string) ([]dataKioskQuery, string, *mcp.CallToolResult) {
+		pageQuery := maps.Clone(query)
+		setQueryValue(pageQuery, "paginationToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   dataKioskBasePath + "/queries",
+			Query:  pageQuery,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("dataKiosk.getQueries request failed", err)
+		}
+
+		decoded, decodeErr := decodeDataKioskGetQueries(body)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("getQueries", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode dataKiosk.getQueries response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("getQueries", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("dataKiosk.getQueries response payload is empty")
+		}
+
+		return decoded.queries, decoded.nextToken, nil
+	}
+
+	queries, next, paging, failure := collectPages(ctx, pagedOperation{tool: dataKioskGetQueriesSpec.Name, operation: dataKioskGetQueriesSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.PaginationToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := dataKioskGetQueriesResult{
+		Queries:     queries,
+		NextToken:   next,
+		Paging:      paging,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d Data Kiosk queries", len(result.Queries))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via paginationToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeDataKioskGetQuery(ctx context.Context, queryID string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if queryID == "" {
+		return mcp.NewToolResultError("queryId is required"), nil
+	}
+
+	query, err := getDataKioskQuery(ctx, spClient, queryID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := dataKioskGetQueryResult{
+		Query:       query,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Data Kiosk query %s - Status: %s", query.QueryID, query.ProcessingStatus)
+	if documentID := valueOrEmpty(query.DataDocumentID); documentID != "" {
+		fallback = fmt.Sprintf("%s, data document %s", fallback, documentID)
+	}
+	if documentID := valueOrEmpty(query.ErrorDocumentID); documentID != "" {
+		fallback = fmt.Sprintf("%s, error document %s", fallback, documentID)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeDataKioskCancelQuery(ctx context.Context, queryID string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if queryID == "" {
+		return mcp.NewToolResultError("queryId is required"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodDelete,
+		Path:   dataKioskBasePath + "/queries/" + url.PathEscape(queryID),
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("dataKiosk.cancelQuery request failed", err), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("cancelQuery", httpResp, body, decodeSellingPartnerErrors(body)); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := dataKioskCancelQueryResult{
+		QueryID:     queryID,
+		Cancelled:   true,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Cancelled Data Kiosk query %s", queryID)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeDataKioskGetDocument(ctx context.Context, args dataKioskGetDocumentArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	documentID := strings.TrimSpace(args.DocumentID)
+	if documentID == "" {
+		return mcp.NewToolResultError("documentId is required"), nil
+	}
+
+	document, err := getDataKioskDocument(ctx, spClient, documentID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := dataKioskGetDocumentResult{
+		DocumentID:  document.documentID,
+		DocumentURL: document.documentURL,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	if args.Download == nil || *args.Download {
+		records, truncated, err := downloadDataKioskRecords(ctx, document.documentURL, dataKioskMaxRecords(args.MaxRecords))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to download Data Kiosk document", err), nil
+		}
+		result.Records = records
+		result.RecordCount = len(records)
+		result.Truncated = truncated
+	}
+
+	fallback := fmt.Sprintf("Retrieved Data Kiosk document %s", result.DocumentID)
+	if result.Records != nil {
+		fallback = fmt.Sprintf("%s with %d records", fallback, result.RecordCount)
+		if result.Truncated {
+			fallback = fmt.Sprintf("%s (truncated; raise maxRecords or download documentUrl directly)", fallback)
+		}
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeDataKioskListSavedQueries() *mcp.CallToolResult {
+	result := dataKioskListSavedQueriesResult{
+		SavedQueries: make([]dataKioskSavedQuerySummary, 0, len(dataKioskSavedQueries)),
+	}
+
+	for _, saved := range dataKioskSavedQueries {
+		result.SavedQueries = append(result.SavedQueries, dataKioskSavedQuerySummary{
+			Name:        saved.Name,
+			Description: saved.Description,
+			AggregateBy: saved.AggregateBy,
+		})
+	}
+
+	fallback := fmt.Sprintf("Available saved Data Kiosk queries: %s", strings.Join(dataKioskSavedQueryNames(), ", "))
+
+	return mcp.NewToolResultStructured(result, fallback)
+}
+
+func executeDataKioskRunSavedQuery(ctx context.Context, args dataKioskRunSavedQueryArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	saved, ok := findDataKioskSavedQuery(args.Name)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown saved query %q; available: %s", args.Name, strings.Join(dataKioskSavedQueryNames(), ", "))), nil
+	}
+
+	query, err := renderDataKioskSavedQuery(saved, dataKioskSavedQueryParams{
+		StartDate:      args.StartDate,
+		EndDate:        args.EndDate,
+		MarketplaceIDs: args.MarketplaceIDs,
+		AggregateBy:    args.AggregateBy,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	queryID, err := createDataKioskQuery(ctx, spClient, query, "")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := dataKioskRunSavedQueryResult{
+		Name:    saved.Name,
+		QueryID: queryID,
+		Query:   query,
+	}
+
+	if args.Wait != nil && *args.Wait {
+		finished, err := waitForDataKioskQuery(ctx, spClient, queryID, dataKioskWaitDuration(args.WaitSeconds))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		result.ProcessingStatus = finished.ProcessingStatus
+		result.DataDocumentID = valueOrEmpty(finished.DataDocumentID)
+		result.ErrorDocumentID = valueOrEmpty(finished.ErrorDocumentID)
+
+		if result.ProcessingStatus == dataKioskStatusDone && result.DataDocumentID != "" {
+			document, err := getDataKioskDocument(ctx, spClient, result.DataDocumentID)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			records, truncated, err := downloadDataKioskRecords(ctx, document.documentURL, dataKioskMaxRecords(args.MaxRecords))
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to download Data Kiosk document", err), nil
+			}
+			result.Records = records
+			result.RecordCount = len(records)
+			result.Truncated = truncated
+		}
+	}
+
+	result.RetrievedAt = time.Now().UTC()
+
+	return mcp.NewToolResultStructured(result, buildDataKioskRunSavedQueryFallback(result)), nil
+}
+
+func buildDataKioskRunSavedQueryFallback(result dataKioskRunSavedQueryResult) string {
+	fallback := fmt.Sprintf("Submitted saved query %s as Data Kiosk query %s", result.Name, result.QueryID)
+
+	switch result.ProcessingStatus {
+	case "":
+		return fallback + "; poll dataKiosk.getQuery until processingStatus is DONE"
+	case dataKioskStatusDone:
+		if result.DataDocumentID == "" {
+			return fallback + "; completed with no data for the requested range"
+		}
+		fallback = fmt.Sprintf("%s; retrieved %d records", fallback, result.RecordCount)
+		if result.Truncated {
+			fallback += " (truncated)"
+		}
+		return fallback
+	case dataKioskStatusFatal:
+		return fmt.Sprintf("%s; query failed, inspect error document %s with dataKiosk.getDocument", fallback, result.ErrorDocumentID)
+	default:
+		return fmt.Sprintf("%s; still %s, poll dataKiosk.getQuery to continue", fallback, result.ProcessingStatus)
+	}
+}
+
+func createDataKioskQuery(ctx context.Context, spClient spapi.Client, query, paginationToken string) (string, error) {
+	requestBody := map[string]string{"query": query}
+	if paginationToken != "" {
+		requestBody["paginationToken"] = paginationToken
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodPost,
+		Path:   dataKioskBasePath + "/queries",
+		Body:   requestBody,
+	})
+	if err != nil {
+		return "", fmt.Errorf("dataKiosk.createQuery request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeDataKioskCreateQuery(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("createQuery", httpResp, body, nil); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to decode dataKiosk.createQuery response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("createQuery", httpResp, body, decoded.apiErrors); err != nil {
+		return "", err
+	}
+
+	if !decoded.payloadPresent {
+		return "", fmt.Errorf("dataKiosk.createQuery response payload is empty")
+	}
+
+	return decoded.queryID, nil
+}
+
+func getDataKioskQuery(ctx context.Context, spClient spapi.Client, queryID string) (dataKioskQuery, error) {
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   dataKioskBasePath + "/queries/" + url.PathEscape(queryID),
+	})
+	if err != nil {
+		return dataKioskQuery{}, fmt.Errorf("dataKiosk.getQuery request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeDataKioskGetQuery(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getQuery", httpResp, body, nil); err != nil {
+			return dataKioskQuery{}, err
+		}
+		return dataKioskQuery{}, fmt.Errorf("failed to decode dataKiosk.getQuery response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getQuery", httpResp, body, decoded.apiErrors); err != nil {
+		return dataKioskQuery{}, err
+	}
+
+	if !decoded.payloadPresent {
+		return dataKioskQuery{}, fmt.Errorf("dataKiosk.getQuery response payload is empty")
+	}
+
+	return decoded.query, nil
+}
+
+func getDataKioskDocument(ctx context.Context, spClient spapi.Client, documentID string) (dataKioskGetDocumentDecoded, error) {
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   dataKioskBasePath + "/documents/" + url.PathEscape(documentID),
+	})
+	if err != nil {
+		return dataKioskGetDocumentDecoded{}, fmt.Errorf("dataKiosk.getDocument request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeDataKioskGetDocument(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getDocument", httpResp, body, nil); err != nil {
+			return dataKioskGetDocumentDecoded{}, err
+		}
+		return dataKioskGetDocumentDecoded{}, fmt.Errorf("failed to decode dataKiosk.getDocument response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getDocument", httpResp, body, decoded.apiErrors); err != nil {
+		return dataKioskGetDocumentDecoded{}, err
+	}
+
+	if !decoded.payloadPresent {
+		return dataKioskGetDocumentDecoded{}, fmt.Errorf("dataKiosk.getDocument response payload is empty")
+	}
+
+	return decoded, nil
+}
+
+// waitForDataKioskQuery polls getQuery until the query leaves the queue or the wait budget is exhausted. Running out of
+// time is not an error: the latest status is returned so the caller can resume polling.
+func waitForDataKioskQuery(ctx context.Context, spClient spapi.Client, queryID string, wait time.Duration) (dataKioskQuery, error) {
+	deadline := time.Now().Add(wait)
+
+	for {
+		query, err := getDataKioskQuery(ctx, spClient, queryID)
+		if err != nil {
+			return dataKioskQuery{}, err
+		}
+
+		switch query.ProcessingStatus {
+		case dataKioskStatusDone, dataKioskStatusFatal, dataKioskStatusCancelled:
+			return query, nil
+		}
+
+		if time.Now().Add(dataKioskPollInterval).After(deadline) {
+			return query, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return dataKioskQuery{}, ctx.Err()
+		case <-time.After(dataKioskPollInterval):
+		}
+	}
+}
+
+func downloadDataKioskRecords(ctx context.Context, documentURL string, maxRecords int) ([]json.RawMessage, bool, error) {
+	data, err := downloadDocument(ctx, documentURL)
+	if err != nil {
+		return nil, false, err
+	}
+	return parseJSONLines(data, maxRecords)
+}
+
+func dataKioskMaxRecords(value *int) int {
+	if value == nil || *value <= 0 {
+		return dataKioskDefaultMaxRecords
+	}
+	return *value
+}
+
+func dataKioskWaitDuration(value *int) time.Duration {
+	seconds := dataKioskDefaultWaitSeconds
+	if value != nil && *value > 0 {
+		seconds = *value
+	}
+	if seconds > dataKioskMaxWaitSeconds {
+		seconds = dataKioskMaxWaitSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dataKioskQuery mirrors the Data Kiosk Query model. The SDK predates Data Kiosk, so the model is declared locally.
+type dataKioskQuery struct {
+	QueryID             string                    `json:"queryId"`
+	Query               string                    `json:"query"`
+	CreatedTime         *time.Time                `json:"createdTime,omitempty"`
+	ProcessingStatus    string                    `json:"processingStatus"`
+	ProcessingStartTime *time.Time                `json:"processingStartTime,omitempty"`
+	ProcessingEndTime   *time.Time                `json:"processingEndTime,omitempty"`
+	DataDocumentID      *string                   `json:"dataDocumentId,omitempty"`
+	ErrorDocumentID     *string                   `json:"errorDocumentId,omitempty"`
+	Pagination          *dataKioskQueryPagination `json:"pagination,omitempty"`
+}
+
+type dataKioskQueryPagination struct {
+	NextToken *string `json:"nextToken,omitempty"`
+}
+
+type dataKioskCreateQueryDecoded struct {
+	queryID        string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type dataKioskGetQueriesDecoded struct {
+	queries        []dataKioskQuery
+	nextToken      string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type dataKioskGetQueryDecoded struct {
+	query          dataKioskQuery
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type dataKioskGetDocumentDecoded struct {
+	documentID     string
+	documentURL    string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+func decodeDataKioskCreateQuery(body []byte) (dataKioskCreateQueryDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return dataKioskCreateQueryDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto dataKioskCreateQueryResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return dataKioskCreateQueryDecoded{}, err
+	}
+
+	decoded := dataKioskCreateQueryDecoded{
+		apiErrors: dto.Errors,
+	}
+
+	if queryID := valueOrEmpty(dto.QueryID); queryID != "" {
+		decoded.payloadPresent = true
+		decoded.queryID = queryID
+	}
+
+	return decoded, nil
+}
+
+func decodeDataKioskGetQueries(body []byte) (dataKioskGetQueriesDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return dataKioskGetQueriesDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto dataKioskGetQueriesResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return dataKioskGetQueriesDecoded{}, err
+	}
+
+	decoded := dataKioskGetQueriesDecoded{
+		apiErrors: dto.Errors,
+	}
+
+	if dto.Queries != nil {
+		decoded.payloadPresent = true
+		decoded.queries = *dto.Queries
+		if dto.Pagination != nil {
+			decoded.nextToken = valueOrEmpty(dto.Pagination.NextToken)
+		}
+	}
+
+	return decoded, nil
+}
+
+func decodeDataKioskGetQuery(body []byte) (dataKioskGetQueryDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return dataKioskGetQueryDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto dataKioskGetQueryResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return dataKioskGetQueryDecoded{}, err
+	}
+
+	decoded := dataKioskGetQueryDecoded{
+		apiErrors: dto.Errors,
+	}
+
+	if strings.TrimSpace(dto.QueryID) != "" {
+		decoded.payloadPresent = true
+		decoded.query = dto.dataKioskQuery
+	}
+
+	return decoded, nil
+}
+
+func decodeDataKioskGetDocument(body []byte) (dataKioskGetDocumentDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return dataKioskGetDocumentDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto dataKioskGetDocumentResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return dataKioskGetDocumentDecoded{}, err
+	}
+
+	decoded := dataKioskGetDocumentDecoded{
+		apiErrors: dto.Errors,
+	}
+
+	if url := valueOrEmpty(dto.DocumentURL); url != "" {
+		decoded.payloadPresent = true
+		decoded.documentID = valueOrEmpty(dto.DocumentID)
+		decoded.documentURL = url
+	}
+
+	return decoded, nil
+}
+
+type dataKioskCreateQueryResponseDTO struct {
+	Errors  []spAPIError `json:"errors,omitempty"`
+	QueryID *string      `json:"queryId,omitempty"`
+}
+
+type dataKioskGetQueriesResponseDTO struct {
+	Errors     []spAPIError              `json:"errors,omitempty"`
+	Queries    *[]dataKioskQuery         `json:"queries,omitempty"`
+	Pagination *dataKioskQueryPagination `json:"pagination,omitempty"`
+}
+
+type dataKioskGetQueryResponseDTO struct {
+	Errors []spAPIError `json:"errors,omitempty"`
+	dataKioskQuery
+}
+
+type dataKioskGetDocumentResponseDTO struct {
+	Errors      []spAPIError `json:"errors,omitempty"`
+	DocumentID  *string      `json:"documentId,omitempty"`
+	DocumentURL *string      `json:"documentUrl,omitempty"`
+}
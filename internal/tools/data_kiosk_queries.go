@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// dataKioskSavedQuery is a parameterised GraphQL query that can be run by name through dataKiosk.runSavedQuery.
+type dataKioskSavedQuery struct {
+	Name        string
+	Description string
+	// AggregateBy lists the accepted aggregation values; the first entry is the default.
+	AggregateBy []string
+	Template    string
+}
+
+// dataKioskSavedQueryParams are the values substituted into a saved query template.
+type dataKioskSavedQueryParams struct {
+	StartDate      string
+	EndDate        string
+	MarketplaceIDs []string
+	AggregateBy    string
+}
+
+const dataKioskMoneySelection = `{ amount currencyCode }`
+
+var dataKioskSavedQueries = []dataKioskSavedQuery{
+	{
+		Name:        "salesAndTrafficByAsin",
+		Description: "Sales and traffic metrics per ASIN or SKU for a date range (Seller Central Business Reports equivalent).",
+		AggregateBy: []string{"CHILD", "PARENT", "SKU"},
+		Template: `query {
+  analytics_salesAndTraffic_2023_11_15 {
+    salesAndTrafficByAsin(startDate: {{quote .StartDate}}, endDate: {{quote .EndDate}}, aggregateBy: {{.AggregateBy}}, marketplaceIds: {{list .MarketplaceIDs}}) {
+      startDate
+      endDate
+      marketplaceId
+      parentAsin
+      childAsin
+      sku
+      sales {
+        orderedProductSales ` + dataKioskMoneySelection + `
+        orderedProductSalesB2B ` + dataKioskMoneySelection + `
+        totalOrderItems
+        totalOrderItemsB2B
+        unitsOrdered
+        unitsOrderedB2B
+      }
+      traffic {
+        buyBoxPercentage
+        pageViews
+        pageViewsPercentage
+        sessions
+        sessionPercentage
+        unitSessionPercentage
+      }
+    }
+  }
+}`,
+	},
+	{
+		Name:        "salesAndTrafficByDate",
+		Description: "Account-level sales and traffic metrics bucketed by day, week, or month.",
+		AggregateBy: []string{"DAY", "WEEK", "MONTH"},
+		Template: `query {
+  analytics_salesAndTraffic_2023_11_15 {
+    salesAndTrafficByDate(startDate: {{quote .StartDate}}, endDate: {{quote .EndDate}}, aggregateBy: {{.AggregateBy}}, marketplaceIds: {{list .MarketplaceIDs}}) {
+      startDate
+      endDate
+      marketplaceId
+      sales {
+        averageSalesPerOrderItem ` + dataKioskMoneySelection + `
+        orderedProductSales ` + dataKioskMoneySelection + `
+        refundRate
+        shippedProductSales ` + dataKioskMoneySelection + `
+        totalOrderItems
+        unitsOrdered
+        unitsRefunded
+        unitsShipped
+      }
+      traffic {
+        buyBoxPercentage
+        orderItemSessionPercentage
+        pageViews
+        sessions
+        unitSessionPercentage
+      }
+    }
+  }
+}`,
+	},
+	{
+		Name:        "economicsBySku",
+		Description: "Per-SKU sales, fees, and net proceeds for a date range from the Seller Economics dataset.",
+		AggregateBy: []string{"DAY", "WEEK", "MONTH"},
+		Template: `query {
+  analytics_economics_2024_03_15 {
+    economics(startDate: {{quote .StartDate}}, endDate: {{quote .EndDate}}, aggregateBy: {date: {{.AggregateBy}}, productId: MSKU}, marketplaceIds: {{list .MarketplaceIDs}}) {
+      startDate
+      endDate
+      marketplaceId
+      msku
+      fnsku
+      parentAsin
+      childAsin
+      sales {
+        orderedProductSales ` + dataKioskMoneySelection + `
+        netProductSales ` + dataKioskMoneySelection + `
+        unitsOrdered
+        unitsRefunded
+        netUnitsSold
+      }
+      netProceeds {
+        total ` + dataKioskMoneySelection + `
+        perUnit ` + dataKioskMoneySelection + `
+      }
+    }
+  }
+}`,
+	},
+}
+
+var dataKioskTemplateFuncs = template.FuncMap{
+	"quote": func(value string) string {
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	},
+	"list": func(values []string) string {
+		encoded, _ := json.Marshal(values)
+		return string(encoded)
+	},
+}
+
+func findDataKioskSavedQuery(name string) (dataKioskSavedQuery, bool) {
+	for _, saved := range dataKioskSavedQueries {
+		if strings.EqualFold(saved.Name, strings.TrimSpace(name)) {
+			return saved, true
+		}
+	}
+	return dataKioskSavedQuery{}, false
+}
+
+func dataKioskSavedQueryNames() []string {
+	names := make([]string, 0, len(dataKioskSavedQueries))
+	for _, saved := range dataKioskSavedQueries {
+		names = append(names, saved.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renderDataKioskSavedQuery validates the parameters and expands the saved query template into a GraphQL document.
+func renderDataKioskSavedQuery(saved dataKioskSavedQuery, params dataKioskSavedQueryParams) (string, error) {
+	startDate := strings.TrimSpace(params.StartDate)
+	endDate := strings.TrimSpace(params.EndDate)
+
+	start, err := time.Parse(time.DateOnly, startDate)
+	if err != nil {
+		return "", fmt.Errorf("startDate must be formatted as YYYY-MM-DD")
+	}
+	end, err := time.Parse(time.DateOnly, endDate)
+	if err != nil {
+		return "", fmt.Errorf("endDate must be formatted as YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return "", fmt.Errorf("endDate must not be before startDate")
+	}
+
+	marketplaces := trimStringSlice(params.MarketplaceIDs)
+	if len(marketplaces) == 0 {
+		return "", fmt.Errorf("marketplaceIds is required")
+	}
+
+	aggregateBy := strings.ToUpper(strings.TrimSpace(params.AggregateBy))
+	if aggregateBy == "" {
+		aggregateBy = saved.AggregateBy[0]
+	}
+	if !slices.Contains(saved.AggregateBy, aggregateBy) {
+		return "", fmt.Errorf("aggregateBy for %s must be one of %s", saved.Name, strings.Join(saved.AggregateBy, ", "))
+	}
+
+	tmpl, err := template.New(saved.Name).Funcs(dataKioskTemplateFuncs).Parse(saved.Template)
+	if err != nil {
+		return "", fmt.Errorf("parsing saved query %s: %w", saved.Name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, dataKioskSavedQueryParams{
+		StartDate:      startDate,
+		EndDate:        endDate,
+		MarketplaceIDs: marketplaces,
+		AggregateBy:    aggregateBy,
+	}); err != nil {
+		return "", fmt.Errorf("rendering saved query %s: %w", saved.Name, err)
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderDataKioskSavedQuery(t *testing.T) {
+	saved, ok := findDataKioskSavedQuery("salesAndTrafficByAsin")
+	if !ok {
+		t.Fatalf("expected salesAndTrafficByAsin to be a saved query")
+	}
+
+	query, err := renderDataKioskSavedQuery(saved, dataKioskSavedQueryParams{
+		StartDate:      " 2025-01-01 ",
+		EndDate:        "2025-01-31",
+		MarketplaceIDs: []string{" ATVPDKIKX0DER "},
+		AggregateBy:    "sku",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `salesAndTrafficByAsin(startDate: "2025-01-01", endDate: "2025-01-31", aggregateBy: SKU, marketplaceIds: ["ATVPDKIKX0DER"])`
+	if !strings.Contains(query, expected) {
+		t.Fatalf("rendered query missing arguments %q:\n%s", expected, query)
+	}
+}
+
+func TestRenderDataKioskSavedQueryDefaultsAggregateBy(t *testing.T) {
+	saved, _ := findDataKioskSavedQuery("economicsBySku")
+
+	query, err := renderDataKioskSavedQuery(saved, dataKioskSavedQueryParams{
+		StartDate:      "2025-01-01",
+		EndDate:        "2025-01-01",
+		MarketplaceIDs: []string{"ATVPDKIKX0DER"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(query, "aggregateBy: {date: DAY, productId: MSKU}") {
+		t.Fatalf("expected default DAY aggregation:\n%s", query)
+	}
+}
+
+func TestRenderDataKioskSavedQueryValidation(t *testing.T) {
+	saved, _ := findDataKioskSavedQuery("salesAndTrafficByDate")
+
+	tests := []struct {
+		name   string
+		params dataKioskSavedQueryParams
+		msg    string
+	}{
+		{
+			name:   "bad start date",
+			params: dataKioskSavedQueryParams{StartDate: "01/01/2025", EndDate: "2025-01-31", MarketplaceIDs: []string{"ATVPDKIKX0DER"}},
+			msg:    "startDate must be formatted as YYYY-MM-DD",
+		},
+		{
+			name:   "end before start",
+			params: dataKioskSavedQueryParams{StartDate: "2025-02-01", EndDate: "2025-01-31", MarketplaceIDs: []string{"ATVPDKIKX0DER"}},
+			msg:    "endDate must not be before startDate",
+		},
+		{
+			name:   "missing marketplaces",
+			params: dataKioskSavedQueryParams{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+			msg:    "marketplaceIds is required",
+		},
+		{
+			name:   "unsupported aggregation",
+			params: dataKioskSavedQueryParams{StartDate: "2025-01-01", EndDate: "2025-01-31", MarketplaceIDs: []string{"ATVPDKIKX0DER"}, AggregateBy: "SKU"},
+			msg:    "aggregateBy for salesAndTrafficByDate must be one of DAY, WEEK, MONTH",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := renderDataKioskSavedQuery(saved, tc.params)
+			if err == nil || !strings.Contains(err.Error(), tc.msg) {
+				t.Fatalf("expected error %q, got %v", tc.msg, err)
+			}
+		})
+	}
+}
+
+func TestParseJSONLinesHonoursLimit(t *testing.T) {
+	data := []byte("{\"sku\":\"A\"}\n\n{\"sku\":\"B\"}\n{\"sku\":\"C\"}\n")
+
+	records, truncated, err := parseJSONLines(data, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 || !truncated {
+		t.Fatalf("expected 2 truncated records, got %d (truncated=%t)", len(records), truncated)
+	}
+	if string(records[1]) != `{"sku":"B"}` {
+		t.Fatalf("unexpected second record: %s", records[1])
+	}
+
+	records, truncated, err = parseJSONLines(data, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 || truncated {
+		t.Fatalf("expected all 3 records, got %d (truncated=%t)", len(records), truncated)
+	}
+}
+
+func TestParseJSONLinesRejectsInvalidLine(t *testing.T) {
+	_, _, err := parseJSONLines([]byte("{\"sku\":\"A\"}\nnot-json\n"), 0)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected line 2 error, got %v", err)
+	}
+}
+
+func TestDecodeDataKioskGetQuery(t *testing.T) {
+	body := []byte(`{
+		"queryId": "12345",
+		"query": "query { ... }",
+		"createdTime": "2025-01-01T00:00:00Z",
+		"processingStatus": "DONE",
+		"dataDocumentId": "DOC-1",
+		"pagination": {"nextToken": "next"}
+	}`)
+
+	decoded, err := decodeDataKioskGetQuery(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.payloadPresent {
+		t.Fatalf("expected payload to be present")
+	}
+	if decoded.query.ProcessingStatus != "DONE" || valueOrEmpty(decoded.query.DataDocumentID) != "DOC-1" {
+		t.Fatalf("unexpected query: %+v", decoded.query)
+	}
+	if decoded.query.Pagination == nil || valueOrEmpty(decoded.query.Pagination.NextToken) != "next" {
+		t.Fatalf("expected pagination token, got %+v", decoded.query.Pagination)
+	}
+}
+
+func TestDecodeDataKioskGetQueryErrorsOnly(t *testing.T) {
+	decoded, err := decodeDataKioskGetQuery([]byte(`{"errors": [{"code": "NotFound", "message": "missing"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.payloadPresent {
+		t.Fatalf("expected payload to be absent")
+	}
+	if len(decoded.apiErrors) != 1 || decoded.apiErrors[0].Code != "NotFound" {
+		t.Fatalf("unexpected errors: %+v", decoded.apiErrors)
+	}
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The fuzz targets feed arbitrary bodies through each hand-written decoder and the result and fallback builders that
+// consume it. Beyond not panicking, each checks the invariants the handlers rely on. Run one with, for example,
+// go test ./internal/tools -run '^$' -fuzz FuzzDecimalString -fuzztime 30s.
+
+// decoderSeeds are real-shaped bodies plus the edge cases every decoder must survive.
+var decoderSeeds = []string{
+	``,
+	`null`,
+	`{}`,
+	`[]`,
+	`{"payload":null}`,
+	`{"payload":{}}`,
+	`{"payload":[]}`,
+	`{"errors":[{"code":"InvalidInput","message":"Invalid input","details":"x"}]}`,
+	`{"errors":[],"payload":{}}`,
+	`{"errors":null}`,
+	`{"payload":"unexpected"}`,
+	`{"payload":{"offers":"x","pricing":[1,"two",null]}}`,
+}
+
+func addSeeds(f *testing.F, extra ...string) {
+	for _, seed := range append(append([]string(nil), decoderSeeds...), extra...) {
+		f.Add([]byte(seed))
+	}
+}
+
+func FuzzDecimalString(f *testing.F) {
+	for _, seed := range []string{`"12.50"`, `12.50`, `" 3 "`, `0`, `-0.01`, `1e400`, `null`, `""`, `true`, `{}`, `" "`, `123456789012345678901234567890.123`} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d decimalString
+		if err := d.UnmarshalJSON(data); err != nil {
+			return
+		}
+		trimmed := bytes.TrimSpace(data)
+		switch {
+		case len(trimmed) == 0 || string(trimmed) == "null":
+			if d != "" {
+				t.Fatalf("%q decoded to %q, want empty", data, d)
+			}
+		case trimmed[0] == '"':
+			if string(d) != strings.TrimSpace(string(d)) {
+				t.Fatalf("%q decoded to %q with surrounding space", data, d)
+			}
+		default:
+			// Numbers keep their exact text, so amounts are never rounded through float64.
+			if string(d) != string(trimmed) {
+				t.Fatalf("%q decoded to %q, want the literal unchanged", data, d)
+			}
+		}
+
+		// Inside an amount object, as SP-API sends it, the result must be the same.
+		var amount struct {
+			Amount decimalString `json:"amount"`
+		}
+		if json.Valid(trimmed) && json.Unmarshal([]byte(`{"amount":`+string(trimmed)+`}`), &amount) == nil && amount.Amount != d {
+			t.Fatalf("%q decoded to %q alone but %q in an object", data, d, amount.Amount)
+		}
+	})
+}
+
+func FuzzDecodeFBAInventoryGetInventorySummaries(f *testing.F) {
+	addSeeds(f,
+		`{"payload":{"granularity":{"granularityType":"Marketplace","granularityId":"ATVPDKIKX0DER"},"inventorySummaries":[{"asin":"B0020MLK00","sellerSku":"SKU-1","condition":"NewItem","totalQuantity":20,"inventoryDetails":{"fulfillableQuantity":20,"reservedQuantity":{"totalReservedQuantity":0}}}]},"pagination":{"nextToken":"abc"}}`,
+		`{"payload":{"granularity":{},"inventorySummaries":null},"pagination":{}}`,
+		`{"payload":{"granularity":{"granularityType":null},"inventorySummaries":[{}]},"pagination":null}`,
+		`{"payload":{"inventorySummaries":[{"sellerSku":"SKU-2","lastUpdatedTime":""},{"lastUpdatedTime":"2025-01-02T08:15:00Z"}]}}`,
+		`{"pagination":{"nextToken":"orphan"}}`,
+	)
+	f.Fuzz(func(t *testing.T, body []byte) {
+		decoded, err := decodeFBAInventoryGetInventorySummaries(body)
+		if err != nil {
+			return
+		}
+		if !decoded.payloadPresent && (len(decoded.inventorySummaries) > 0 || decoded.nextToken != "" || decoded.granularityType != "") {
+			t.Fatalf("no payload but decoded %+v", decoded)
+		}
+		if _, err := json.Marshal(fbaInventoryGetInventorySummariesResult{InventorySummaries: decoded.inventorySummaries, NextToken: decoded.nextToken}); err != nil {
+			t.Fatalf("decoded summaries do not encode: %v", err)
+		}
+	})
+}
+
+func FuzzDecodeReportsGetReportDocument(f *testing.F) {
+	addSeeds(f,
+		`{"reportDocumentId":"amzn1.spdoc.1.4.na.doc","url":"https://tortuga-prod-na.s3.amazonaws.com/doc","compressionAlgorithm":"GZIP"}`,
+		`{"payload":{"reportDocumentId":"0356cf79","url":"https://example.com/doc","encryptionDetails":{"standard":"AES","initializationVector":"iv","key":"k"}}}`,
+		`{"reportDocumentId":null,"url":""}`,
+		`{"payload":{"url":null}}`,
+	)
+	f.Fuzz(func(t *testing.T, body []byte) {
+		decoded, err := decodeReportsGetReportDocument(body)
+		if err != nil {
+			return
+		}
+		if !decoded.payloadPresent && (decoded.reportDocumentID != "" || decoded.url != "" || decoded.compressionAlgorithm != "") {
+			t.Fatalf("no payload but decoded %+v", decoded)
+		}
+	})
+}
+
+func FuzzDecodeProductPricingBody(f *testing.F) {
+	addSeeds(f,
+		`{"payload":[{"status":"Success","ASIN":"B00V5DG6IQ","Product":{"Offers":[{"BuyingPrice":{"ListingPrice":{"CurrencyCode":"USD","Amount":10.0}}}]}}]}`,
+		`{"payload":[{"status":"ClientError","SellerSKU":"SKU-1","Product":{}}]}`,
+		`{"payload":[{"ASIN":5,"BuyingPrice":{"ListingPrice":{"Amount":"10","CurrencyCode":7}}},null,"x",[]]}`,
+		`{"payload":[{"identifier":{"SellerSKU":"SKU-1"},"competitivePricing":{"CompetitivePrices":[{},{}]}}]}`,
+		`{"payload":{"competitivePricing":[{"identifier":null}]}}`,
+	)
+	f.Fuzz(func(t *testing.T, body []byte) {
+		decoded, err := decodeProductPricingBody(body)
+		if err != nil {
+			return
+		}
+		if !decoded.payloadPresent && decoded.pricing != nil {
+			t.Fatalf("no payload but decoded %d entries", len(decoded.pricing))
+		}
+
+		pricing := buildGetPricingResult(decoded)
+		competitive := buildGetCompetitivePricingResult(decoded)
+		if pricing.ItemCount != len(decoded.pricing) || competitive.ItemCount != len(decoded.pricing) {
+			t.Fatalf("item counts %d and %d for %d entries", pricing.ItemCount, competitive.ItemCount, len(decoded.pricing))
+		}
+		pricing.RetrievedAt, competitive.RetrievedAt = time.Time{}, time.Time{}
+		for _, fallback := range []string{buildGetPricingFallback(pricing), buildGetCompetitivePricingFallback(competitive)} {
+			if !strings.Contains(fallback, "retrieved for") {
+				t.Fatalf("unexpected fallback %q", fallback)
+			}
+		}
+		if _, err := json.Marshal(pricing); err != nil {
+			t.Fatalf("pricing result does not encode: %v", err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxDocumentBytes bounds how much of a pre-signed document is pulled into memory.
+const maxDocumentBytes = 64 << 20
+
+// downloadDocument fetches a pre-signed SP-API document URL. GZIP content is inflated transparently whether or not the
+// API reported a compression algorithm, because Data Kiosk documents omit it.
+func downloadDocument(ctx context.Context, documentURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building document request: %w", err)
+	}
+
+	httpClient := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("document download failed with status %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), sanitizeBodySnippet(body))
+	}
+
+	if len(body) > maxDocumentBytes {
+		return nil, fmt.Errorf("document exceeds %d bytes", maxDocumentBytes)
+	}
+
+	if len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("opening gzip document: %w", err)
+		}
+		defer reader.Close()
+
+		inflated, err := io.ReadAll(io.LimitReader(reader, maxDocumentBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("inflating document: %w", err)
+		}
+		if len(inflated) > maxDocumentBytes {
+			return nil, fmt.Errorf("document exceeds %d bytes once decompressed", maxDocumentBytes)
+		}
+		body = inflated
+	}
+
+	return body, nil
+}
+
+// parseJSONLines splits a JSONL document into raw records. When limit is positive, parsing stops after limit records
+// and the second return value reports that more records were available.
+func parseJSONLines(data []byte, limit int) ([]json.RawMessage, bool, error) {
+	records := make([]json.RawMessage, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDocumentBytes)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		if limit > 0 && len(records) >= limit {
+			return records, true, nil
+		}
+
+		if !json.Valid(raw) {
+			return nil, false, fmt.Errorf("line %d is not valid JSON", line)
+		}
+
+		records = append(records, json.RawMessage(append([]byte(nil), raw...)))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("scanning document: %w", err)
+	}
+
+	return records, false, nil
+}
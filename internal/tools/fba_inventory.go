@@ -11,7 +11,6 @@ import (
 	"github.com/amzapi/selling-partner-api-sdk/fbaInventory"
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
 )
@@ -23,6 +22,7 @@ type fbaInventoryGetInventorySummariesArgs struct {
 	SellerSkus      []string `json:"sellerSkus"`
 	NextToken       string   `json:"nextToken"`
 	Details         *bool    `json:"details"`
+	pagingArgs
 }
 
 type fbaInventoryGetInventorySummariesResult struct {
@@ -30,18 +30,19 @@ type fbaInventoryGetInventorySummariesResult struct {
 	GranularityID      string                          `json:"granularityId,omitempty"`
 	InventorySummaries []fbaInventory.InventorySummary `json:"inventorySummaries"`
 	NextToken          string                          `json:"nextToken,omitempty"`
+	Paging             *pagingSummary                  `json:"paging,omitempty"`
 	RetrievedAt        time.Time                       `json:"retrievedAt"`
 }
 
-func newFBAInventoryTools(deps Dependencies) []server.ServerTool {
+func newFBAInventoryTools(deps Dependencies) []registeredTool {
 	spClient := deps.SellingPartner
 
-	getInventorySummariesHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args fbaInventoryGetInventorySummariesArgs) (*mcp.CallToolResult, error) {
-		return executeFBAInventoryGetInventorySummaries(ctx, args, spClient)
+	getInventorySummariesHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args fbaInventoryGetInventorySummariesArgs) (*mcp.CallToolResult, error) {
+		return executeFBAInventoryGetInventorySummaries(withProgressToken(ctx, req), args, spClient)
 	})
 
-	return []server.ServerTool{
-		serverToolFromSpec(fbaInventoryGetInventorySummariesSpec, getInventorySummariesHandler),
+	return []registeredTool{
+		toolFromSpec(fbaInventoryGetInventorySummariesSpec, getInventorySummariesHandler),
 	}
 }
 
@@ -79,58 +80,81 @@ func executeFBAInventoryGetInventorySummaries(ctx context.Context, args fbaInven
 		}
 	}
 
-	if nextToken := strings.TrimSpace(args.NextToken); nextToken != "" {
-		params.NextToken = &nextToken
-	}
-
 	if args.Details != nil {
 		params.Details = args.Details
 	}
 
+	var granularity, granularityID string
+	fetch := func(ctx context.Context, token string) ([]fbaInventory.InventorySummary, string, *mcp.CallToolResult) {
+		pageParams := *params
+		pageParams.NextToken = stringPtr(token)
+
+		decoded, failure := fetchInventorySummariesPage(ctx, client, &pageParams)
+		if failure != nil {
+			return nil, "", failure
+		}
+
+		granularity, granularityID = decoded.granularityType, decoded.granularityID
+		return decoded.inventorySummaries, decoded.nextToken, nil
+	}
+
+	summaries, next, paging, failure := collectPages(ctx, pagedOperation{tool: fbaInventoryGetInventorySummariesSpec.Name, operation: fbaInventoryGetInventorySummariesSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := fbaInventoryGetInventorySummariesResult{
+		GranularityType:    granularity,
+		GranularityID:      granularityID,
+		InventorySummaries: summaries,
+		NextToken:          next,
+		Paging:             paging,
+		RetrievedAt:        time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d inventory summaries", len(result.InventorySummaries))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+	if result.GranularityType != "" {
+		fallback = fmt.Sprintf("%s (granularity: %s)", fallback, result.GranularityType)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// fetchInventorySummariesPage performs one GetInventorySummaries call.
+func fetchInventorySummariesPage(ctx context.Context, client *fbaInventory.Client, params *fbaInventory.GetInventorySummariesParams) (fbaInventoryGetInventorySummariesDecoded, *mcp.CallToolResult) {
 	httpResp, err := client.GetInventorySummaries(ctx, params)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("fbaInventory.getInventorySummaries request failed", err), nil
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultErrorFromErr("fbaInventory.getInventorySummaries request failed", err)
 	}
 	if httpResp == nil {
-		return mcp.NewToolResultError("fbaInventory.getInventorySummaries returned no response"), nil
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultError("fbaInventory.getInventorySummaries returned no response")
 	}
 
 	body, readErr := io.ReadAll(httpResp.Body)
 	defer httpResp.Body.Close()
 	if readErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to read fbaInventory.getInventorySummaries response", readErr), nil
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultErrorFromErr("failed to read fbaInventory.getInventorySummaries response", readErr)
 	}
 
 	decoded, decodeErr := decodeFBAInventoryGetInventorySummaries(body)
 	if decodeErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to decode fbaInventory.getInventorySummaries response", decodeErr), nil
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultErrorFromErr("failed to decode fbaInventory.getInventorySummaries response", decodeErr)
 	}
 
 	if err := ensureFBAInventoryAPIResponse("getInventorySummaries", httpResp, body, decoded.apiErrors); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultError(err.Error())
 	}
 
 	if !decoded.payloadPresent {
-		return mcp.NewToolResultError("fbaInventory.getInventorySummaries response payload is empty"), nil
-	}
-
-	result := fbaInventoryGetInventorySummariesResult{
-		GranularityType:    decoded.granularityType,
-		GranularityID:      decoded.granularityID,
-		InventorySummaries: decoded.inventorySummaries,
-		NextToken:          decoded.nextToken,
-		RetrievedAt:        time.Now().UTC(),
+		return fbaInventoryGetInventorySummariesDecoded{}, mcp.NewToolResultError("fbaInventory.getInventorySummaries response payload is empty")
 	}
 
-	fallback := fmt.Sprintf("Retrieved %d inventory summaries", len(result.InventorySummaries))
-	if result.NextToken != "" {
-		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
-	}
-	if result.GranularityType != "" {
-		fallback = fmt.Sprintf("%s (granularity: %s)", fallback, result.GranularityType)
-	}
-
-	return mcp.NewToolResultStructured(result, fallback), nil
+	return decoded, nil
 }
 
 func ensureFBAInventoryClient(spClient spapi.Client) (*fbaInventory.Client, *mcp.CallToolResult) {
@@ -155,7 +179,7 @@ func ensureFBAInventoryClient(spClient spapi.Client) (*fbaInventory.Client, *mcp
 }
 
 func buildFBAInventoryClient(spClient spapi.Client) (*fbaInventory.Client, error) {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient := newSellingPartnerHTTPClient()
 
 	return &fbaInventory.Client{
 		Endpoint:      spClient.Endpoint(),
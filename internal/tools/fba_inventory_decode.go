@@ -34,7 +34,12 @@ func decodeFBAInventoryGetInventorySummaries(body []byte) (fbaInventoryGetInvent
 
 	if dto.Payload != nil {
 		decoded.payloadPresent = true
-		decoded.inventorySummaries = dto.Payload.InventorySummaries
+		if dto.Payload.InventorySummaries != nil {
+			decoded.inventorySummaries = make([]fbaInventory.InventorySummary, len(dto.Payload.InventorySummaries))
+			for i, summary := range dto.Payload.InventorySummaries {
+				decoded.inventorySummaries[i] = fbaInventory.InventorySummary(summary)
+			}
+		}
 		
 		if dto.Payload.Granularity.GranularityType != nil {
 			decoded.granularityType = *dto.Payload.Granularity.GranularityType
@@ -54,5 +59,30 @@ func decodeFBAInventoryGetInventorySummaries(body []byte) (fbaInventoryGetInvent
 type fbaInventoryGetInventorySummariesResponseDTO struct {
 	Errors     *fbaInventory.ErrorList                    `json:"errors,omitempty"`
 	Pagination *fbaInventory.Pagination                   `json:"pagination,omitempty"`
-	Payload    *fbaInventory.GetInventorySummariesResult  `json:"payload,omitempty"`
+	Payload    *fbaInventoryGetInventorySummariesPayloadDTO `json:"payload,omitempty"`
+}
+
+type fbaInventoryGetInventorySummariesPayloadDTO struct {
+	Granularity        fbaInventory.Granularity `json:"granularity"`
+	InventorySummaries []fbaInventorySummaryDTO `json:"inventorySummaries"`
+}
+
+// fbaInventorySummaryDTO accepts the empty lastUpdatedTime SP-API sends for SKUs without inventory activity, which
+// would otherwise fail the whole page.
+type fbaInventorySummaryDTO fbaInventory.InventorySummary
+
+func (s *fbaInventorySummaryDTO) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if raw, ok := fields["lastUpdatedTime"]; ok && string(bytes.TrimSpace(raw)) == `""` {
+		delete(fields, "lastUpdatedTime")
+		rewritten, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		data = rewritten
+	}
+	return json.Unmarshal(data, (*fbaInventory.InventorySummary)(s))
 }
\ No newline at end of file
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+// financesEventsDTO keeps the Finances API event lists the local store flattens. Other lists (loans, coupons, debt
+// recovery and so on) are not mirrored.
+type financesEventsDTO struct {
+	ShipmentEventList   []financesShipmentEventDTO   `json:"ShipmentEventList,omitempty"`
+	RefundEventList     []financesShipmentEventDTO   `json:"RefundEventList,omitempty"`
+	ServiceFeeEventList []financesServiceFeeEventDTO `json:"ServiceFeeEventList,omitempty"`
+	AdjustmentEventList []financesAdjustmentEventDTO `json:"AdjustmentEventList,omitempty"`
+}
+
+type financesShipmentEventDTO struct {
+	AmazonOrderID              string                    `json:"AmazonOrderId"`
+	MarketplaceName            string                    `json:"MarketplaceName"`
+	PostedDate                 string                    `json:"PostedDate"`
+	ShipmentItemList           []financesShipmentItemDTO `json:"ShipmentItemList,omitempty"`
+	ShipmentItemAdjustmentList []financesShipmentItemDTO `json:"ShipmentItemAdjustmentList,omitempty"`
+}
+
+type financesShipmentItemDTO struct {
+	SellerSKU                string                   `json:"SellerSKU"`
+	OrderItemID              string                   `json:"OrderItemId"`
+	QuantityShipped          int                      `json:"QuantityShipped"`
+	ItemChargeList           []financesChargeDTO      `json:"ItemChargeList,omitempty"`
+	ItemChargeAdjustmentList []financesChargeDTO      `json:"ItemChargeAdjustmentList,omitempty"`
+	ItemFeeList              []financesFeeDTO         `json:"ItemFeeList,omitempty"`
+	ItemFeeAdjustmentList    []financesFeeDTO         `json:"ItemFeeAdjustmentList,omitempty"`
+	PromotionList            []financesPromotionDTO   `json:"PromotionList,omitempty"`
+	PromotionAdjustmentList  []financesPromotionDTO   `json:"PromotionAdjustmentList,omitempty"`
+	ItemTaxWithheldList      []financesTaxWithheldDTO `json:"ItemTaxWithheldList,omitempty"`
+}
+
+type financesServiceFeeEventDTO struct {
+	AmazonOrderID string           `json:"AmazonOrderId"`
+	SellerSKU     string           `json:"SellerSKU"`
+	FeeList       []financesFeeDTO `json:"FeeList,omitempty"`
+}
+
+type financesAdjustmentEventDTO struct {
+	AdjustmentType   string               `json:"AdjustmentType"`
+	PostedDate       string               `json:"PostedDate"`
+	AdjustmentAmount *financesCurrencyDTO `json:"AdjustmentAmount,omitempty"`
+}
+
+type financesChargeDTO struct {
+	ChargeType   string               `json:"ChargeType"`
+	ChargeAmount *financesCurrencyDTO `json:"ChargeAmount,omitempty"`
+}
+
+type financesFeeDTO struct {
+	FeeType   string               `json:"FeeType"`
+	FeeAmount *financesCurrencyDTO `json:"FeeAmount,omitempty"`
+}
+
+type financesPromotionDTO struct {
+	PromotionType   string               `json:"PromotionType"`
+	PromotionAmount *financesCurrencyDTO `json:"PromotionAmount,omitempty"`
+}
+
+type financesTaxWithheldDTO struct {
+	TaxesWithheld []financesChargeDTO `json:"TaxesWithheld,omitempty"`
+}
+
+type financesCurrencyDTO struct {
+	CurrencyCode   string  `json:"CurrencyCode"`
+	CurrencyAmount float64 `json:"CurrencyAmount"`
+}
+
+type financesListFinancialEventsResponseDTO struct {
+	Errors  []spAPIError `json:"errors,omitempty"`
+	Payload *struct {
+		FinancialEvents *financesEventsDTO `json:"FinancialEvents,omitempty"`
+		NextToken       *string            `json:"NextToken,omitempty"`
+	} `json:"payload,omitempty"`
+}
+
+type financesListFinancialEventsDecoded struct {
+	events         financesEventsDTO
+	nextToken      string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+func decodeFinancesListFinancialEvents(body []byte) (financesListFinancialEventsDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return financesListFinancialEventsDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto financesListFinancialEventsResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return financesListFinancialEventsDecoded{}, err
+	}
+
+	decoded := financesListFinancialEventsDecoded{apiErrors: dto.Errors}
+	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		if dto.Payload.FinancialEvents != nil {
+			decoded.events = *dto.Payload.FinancialEvents
+		}
+		decoded.nextToken = valueOrEmpty(dto.Payload.NextToken)
+	}
+
+	return decoded, nil
+}
+
+// flattenFinancialEvents turns one page of events into store rows, one per amount. Service fee events carry no posted
+// date, so they take the fallback: the start of the synced range, which keeps them inside it for later replacement.
+func flattenFinancialEvents(events financesEventsDTO, fallback time.Time) []store.FinancialEvent {
+	var rows []store.FinancialEvent
+
+	add := func(base store.FinancialEvent, kind, amountType string, amount *financesCurrencyDTO) {
+		if amount == nil {
+			return
+		}
+		base.AmountKind = kind
+		base.AmountType = amountType
+		base.Amount = amount.CurrencyAmount
+		base.Currency = amount.CurrencyCode
+		rows = append(rows, base)
+	}
+
+	shipmentRows := func(eventType string, list []financesShipmentEventDTO) {
+		for _, event := range list {
+			posted := parseOrderTime(event.PostedDate)
+			if posted.IsZero() {
+				posted = fallback
+			}
+			items := append(append([]financesShipmentItemDTO(nil), event.ShipmentItemList...), event.ShipmentItemAdjustmentList...)
+			for _, item := range items {
+				base := store.FinancialEvent{
+					EventType:       eventType,
+					PostedDate:      posted,
+					AmazonOrderID:   event.AmazonOrderID,
+					SellerSKU:       item.SellerSKU,
+					OrderItemID:     item.OrderItemID,
+					Quantity:        item.QuantityShipped,
+					MarketplaceName: event.MarketplaceName,
+				}
+				for _, charge := range append(append([]financesChargeDTO(nil), item.ItemChargeList...), item.ItemChargeAdjustmentList...) {
+					add(base, "charge", charge.ChargeType, charge.ChargeAmount)
+				}
+				for _, fee := range append(append([]financesFeeDTO(nil), item.ItemFeeList...), item.ItemFeeAdjustmentList...) {
+					add(base, "fee", fee.FeeType, fee.FeeAmount)
+				}
+				for _, promotion := range append(append([]financesPromotionDTO(nil), item.PromotionList...), item.PromotionAdjustmentList...) {
+					add(base, "promotion", promotion.PromotionType, promotion.PromotionAmount)
+				}
+				for _, withheld := range item.ItemTaxWithheldList {
+					for _, tax := range withheld.TaxesWithheld {
+						add(base, "withheldTax", tax.ChargeType, tax.ChargeAmount)
+					}
+				}
+			}
+		}
+	}
+
+	shipmentRows("Shipment", events.ShipmentEventList)
+	shipmentRows("Refund", events.RefundEventList)
+
+	for _, event := range events.ServiceFeeEventList {
+		base := store.FinancialEvent{
+			EventType:     "ServiceFee",
+			PostedDate:    fallback,
+			AmazonOrderID: event.AmazonOrderID,
+			SellerSKU:     event.SellerSKU,
+		}
+		for _, fee := range event.FeeList {
+			add(base, "fee", fee.FeeType, fee.FeeAmount)
+		}
+	}
+
+	for _, event := range events.AdjustmentEventList {
+		posted := parseOrderTime(event.PostedDate)
+		if posted.IsZero() {
+			posted = fallback
+		}
+		add(store.FinancialEvent{EventType: "Adjustment", PostedDate: posted}, "adjustment", event.AdjustmentType, event.AdjustmentAmount)
+	}
+
+	return rows
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/golden/*.golden from the current decoders")
+
+// goldenCases pin what each tool returns for a real-shaped response. Each case replays testdata/golden/<name>.json
+// and compares the result with testdata/golden/<name>.golden; after an intended change, regenerate the files with
+// go test ./internal/tools -run TestDecoderGolden -update and review the diff.
+var goldenCases = []struct {
+	name      string
+	tool      string
+	arguments map[string]any
+}{
+	{"fbaInventory_getInventorySummaries", "fbaInventory.getInventorySummaries", fbaGoldenArguments},
+	{"fbaInventory_getInventorySummaries_noSummaries", "fbaInventory.getInventorySummaries", fbaGoldenArguments},
+	{"fbaInventory_getInventorySummaries_errorsWith200", "fbaInventory.getInventorySummaries", fbaGoldenArguments},
+	{"fbaInventory_getInventorySummaries_emptyPayload", "fbaInventory.getInventorySummaries", fbaGoldenArguments},
+	{"reports_getReportDocument", "reports.getReportDocument", reportDocumentGoldenArguments},
+	{"reports_getReportDocument_wrappedPayload", "reports.getReportDocument", reportDocumentGoldenArguments},
+	{"reports_getReportDocument_errorsWith200", "reports.getReportDocument", reportDocumentGoldenArguments},
+	{"reports_getReportDocument_emptyPayload", "reports.getReportDocument", reportDocumentGoldenArguments},
+	{"productPricing_getPricing", "productPricing.getPricing", pricingGoldenArguments},
+	{"productPricing_getPricing_bySku", "productPricing.getPricing", map[string]any{"marketplaceId": "ATVPDKIKX0DER", "itemType": "Sku", "skus": []any{"PS-4471-BLK"}}},
+	{"productPricing_getPricing_errorsWith200", "productPricing.getPricing", pricingGoldenArguments},
+	{"productPricing_getPricing_emptyPayload", "productPricing.getPricing", pricingGoldenArguments},
+	{"productPricing_getPricing_nullPayload", "productPricing.getPricing", pricingGoldenArguments},
+	{"productPricing_getPricing_badRequest", "productPricing.getPricing", pricingGoldenArguments},
+	{"productPricing_getCompetitivePricing", "productPricing.getCompetitivePricing", map[string]any{"marketplaceId": "ATVPDKIKX0DER", "itemType": "Asin", "asins": []any{"B00V5DG6IQ"}}},
+	{"sales_getOrderMetrics", "sales.getOrderMetrics", salesGoldenArguments},
+	{"sales_getOrderMetrics_noMetrics", "sales.getOrderMetrics", salesGoldenArguments},
+	{"sales_getOrderMetrics_errorsWith200", "sales.getOrderMetrics", salesGoldenArguments},
+}
+
+var (
+	fbaGoldenArguments = map[string]any{
+		"granularityType": "Marketplace",
+		"granularityId":   "ATVPDKIKX0DER",
+		"details":         true,
+	}
+	reportDocumentGoldenArguments = map[string]any{"reportDocumentId": "amzn1.spdoc.1.4.na.8d2e5b1f"}
+	pricingGoldenArguments        = map[string]any{
+		"marketplaceId": "ATVPDKIKX0DER",
+		"itemType":      "Asin",
+		"asins":         []any{"B00V5DG6IQ", "B0000NOTFND"},
+	}
+	salesGoldenArguments = map[string]any{
+		"marketplaceIds": []any{"ATVPDKIKX0DER"},
+		"interval":       "2025-01-01T00:00:00Z--2025-02-01T00:00:00Z",
+		"granularity":    "Total",
+	}
+)
+
+// goldenResult is the part of a tool result the golden files pin.
+type goldenResult struct {
+	IsError    bool            `json:"isError"`
+	Structured json.RawMessage `json:"structured,omitempty"`
+	Text       string          `json:"text"`
+}
+
+// Results carry the time they were retrieved, which the golden files replace with a fixed marker.
+var (
+	retrievedAtField = regexp.MustCompile(`"retrievedAt":"[^"]*"`)
+	clockTime        = regexp.MustCompile(`\b\d{2}:\d{2}:\d{2} UTC\b`)
+)
+
+func TestDecoderGolden(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := replayTool(t, loadCassette(t, filepath.Join("..", "golden", tc.name+".json")), tc.tool, tc.arguments)
+			text := resultText(result)
+			if strings.Contains(text, "has no interaction for") {
+				t.Fatalf("cassette does not cover the request: %s", text)
+			}
+
+			got := goldenResult{IsError: result.IsError, Text: clockTime.ReplaceAllString(text, "hh:mm:ss UTC")}
+			if result.StructuredContent != nil {
+				structured, err := encodeGolden(result.StructuredContent, "")
+				if err != nil {
+					t.Fatalf("encoding structured result: %v", err)
+				}
+				got.Structured = retrievedAtField.ReplaceAll(structured, []byte(`"retrievedAt":"<retrievedAt>"`))
+			}
+			encoded, err := encodeGolden(got, "  ")
+			if err != nil {
+				t.Fatalf("encoding golden result: %v", err)
+			}
+
+			path := filepath.Join("testdata", "golden", tc.name+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(path, encoded, 0o644); err != nil {
+					t.Fatalf("writing %s: %v", path, err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading %s (run with -update to create it): %v", path, err)
+			}
+			if !bytes.Equal(encoded, want) {
+				t.Errorf("%s does not match; run with -update and review the diff.\ngot:\n%s\nwant:\n%s", path, encoded, want)
+			}
+		})
+	}
+}
+
+// encodeGolden encodes v without HTML escaping, so URLs and markers read as they are.
+func encodeGolden(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
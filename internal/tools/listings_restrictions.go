@@ -0,0 +1,342 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	listingsRestrictionsPath   = "/listings/2021-08-01/restrictions"
+	fbaItemEligibilityPath     = "/fba/inbound/v1/eligibility/itemPreview"
+	defaultFBAEligibilityCheck = "INBOUND"
+	reasonApprovalRequired     = "APPROVAL_REQUIRED"
+)
+
+type listingsGetRestrictionsArgs struct {
+	ASIN           string   `json:"asin"`
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	ConditionType  string   `json:"conditionType"`
+	SellerID       string   `json:"sellerId"`
+	ReasonLocale   string   `json:"reasonLocale"`
+}
+
+type listingsGetRestrictionsResult struct {
+	ASIN           string                `json:"asin"`
+	SellerID       string                `json:"sellerId"`
+	MarketplaceIDs []string              `json:"marketplaceIds"`
+	ConditionType  string                `json:"conditionType,omitempty"`
+	Restrictions   []listingsRestriction `json:"restrictions"`
+	RetrievedAt    time.Time             `json:"retrievedAt"`
+}
+
+type listingsCheckSellabilityArgs struct {
+	listingsGetRestrictionsArgs
+	Program string `json:"program"`
+}
+
+// listingsSellability is the per-marketplace verdict combining listing restrictions and FBA eligibility.
+type listingsSellability struct {
+	MarketplaceID    string                      `json:"marketplaceId"`
+	CanList          bool                        `json:"canList"`
+	ApprovalRequired bool                        `json:"approvalRequired"`
+	ApprovalLinks    []string                    `json:"approvalLinks,omitempty"`
+	Reasons          []listingsRestrictionReason `json:"reasons,omitempty"`
+	FBA              *listingsFBAEligibility     `json:"fba,omitempty"`
+}
+
+type listingsFBAEligibility struct {
+	Program              string   `json:"program"`
+	Eligible             bool     `json:"eligible"`
+	IneligibilityReasons []string `json:"ineligibilityReasons,omitempty"`
+	Error                string   `json:"error,omitempty"`
+}
+
+type listingsCheckSellabilityResult struct {
+	ASIN          string                `json:"asin"`
+	SellerID      string                `json:"sellerId"`
+	ConditionType string                `json:"conditionType,omitempty"`
+	Marketplaces  []listingsSellability `json:"marketplaces"`
+	RetrievedAt   time.Time             `json:"retrievedAt"`
+}
+
+func newListingsRestrictionsTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	restrictionsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args listingsGetRestrictionsArgs) (*mcp.CallToolResult, error) {
+		return executeListingsGetRestrictions(ctx, args, spClient, deps.SellerID)
+	})
+
+	sellabilityHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args listingsCheckSellabilityArgs) (*mcp.CallToolResult, error) {
+		return executeListingsCheckSellability(ctx, args, spClient, deps.SellerID)
+	})
+
+	return []registeredTool{
+		toolFromSpec(listingsGetRestrictionsSpec, restrictionsHandler),
+		toolFromSpec(listingsCheckSellabilitySpec, sellabilityHandler),
+	}
+}
+
+func executeListingsGetRestrictions(ctx context.Context, args listingsGetRestrictionsArgs, spClient spapi.Client, defaultSellerID string) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	params, failure := normaliseListingsRestrictionsArgs(args, defaultSellerID)
+	if failure != nil {
+		return failure, nil
+	}
+
+	restrictions, err := getListingsRestrictions(ctx, spClient, params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := listingsGetRestrictionsResult{
+		ASIN:           params.ASIN,
+		SellerID:       params.SellerID,
+		MarketplaceIDs: params.MarketplaceIDs,
+		ConditionType:  params.ConditionType,
+		Restrictions:   restrictions,
+		RetrievedAt:    time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("No listing restrictions for %s in %s", params.ASIN, strings.Join(params.MarketplaceIDs, ", "))
+	if len(restrictions) > 0 {
+		fallback = fmt.Sprintf("%s has %d listing restriction(s): %s", params.ASIN, len(restrictions), summariseListingsRestrictions(restrictions))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeListingsCheckSellability(ctx context.Context, args listingsCheckSellabilityArgs, spClient spapi.Client, defaultSellerID string) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	params, failure := normaliseListingsRestrictionsArgs(args.listingsGetRestrictionsArgs, defaultSellerID)
+	if failure != nil {
+		return failure, nil
+	}
+
+	program := strings.ToUpper(strings.TrimSpace(args.Program))
+	if program == "" {
+		program = defaultFBAEligibilityCheck
+	}
+	if program != "INBOUND" && program != "COMMINGLING" && program != "NONE" {
+		return mcp.NewToolResultError("program must be INBOUND, COMMINGLING, or NONE"), nil
+	}
+
+	restrictions, err := getListingsRestrictions(ctx, spClient, params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := listingsCheckSellabilityResult{
+		ASIN:          params.ASIN,
+		SellerID:      params.SellerID,
+		ConditionType: params.ConditionType,
+		Marketplaces:  buildListingsSellability(params.MarketplaceIDs, restrictions),
+	}
+
+	if program != "NONE" {
+		for i := range result.Marketplaces {
+			entry := &result.Marketplaces[i]
+			// Eligibility is checked per marketplace; a failure for one marketplace is reported inline rather than
+			// failing the whole check.
+			eligibility, err := getFBAItemEligibility(ctx, spClient, params.ASIN, entry.MarketplaceID, program)
+			if err != nil {
+				entry.FBA = &listingsFBAEligibility{Program: program, Error: err.Error()}
+				continue
+			}
+			entry.FBA = &listingsFBAEligibility{
+				Program:              program,
+				Eligible:             eligibility.IsEligibleForProgram,
+				IneligibilityReasons: eligibility.IneligibilityReasonList,
+			}
+		}
+	}
+
+	result.RetrievedAt = time.Now().UTC()
+
+	segments := make([]string, 0, len(result.Marketplaces))
+	for _, entry := range result.Marketplaces {
+		status := "can list"
+		switch {
+		case entry.ApprovalRequired:
+			status = "approval required"
+		case !entry.CanList:
+			status = "restricted"
+		}
+		if entry.FBA != nil {
+			switch {
+			case entry.FBA.Error != "":
+				status = fmt.Sprintf("%s, FBA check failed", status)
+			case entry.FBA.Eligible:
+				status = fmt.Sprintf("%s, FBA eligible", status)
+			default:
+				status = fmt.Sprintf("%s, FBA ineligible (%s)", status, strings.Join(entry.FBA.IneligibilityReasons, ", "))
+			}
+		}
+		segments = append(segments, fmt.Sprintf("%s: %s", entry.MarketplaceID, status))
+	}
+
+	fallback := fmt.Sprintf("Sellability for %s: %s", params.ASIN, strings.Join(segments, "; "))
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func normaliseListingsRestrictionsArgs(args listingsGetRestrictionsArgs, defaultSellerID string) (listingsGetRestrictionsArgs, *mcp.CallToolResult) {
+	params := listingsGetRestrictionsArgs{
+		ASIN:           strings.ToUpper(strings.TrimSpace(args.ASIN)),
+		MarketplaceIDs: trimStringSlice(args.MarketplaceIDs),
+		ConditionType:  strings.ToLower(strings.TrimSpace(args.ConditionType)),
+		SellerID:       strings.TrimSpace(args.SellerID),
+		ReasonLocale:   strings.TrimSpace(args.ReasonLocale),
+	}
+
+	if params.SellerID == "" {
+		params.SellerID = defaultSellerID
+	}
+
+	if params.ASIN == "" {
+		return params, mcp.NewToolResultError("asin is required")
+	}
+	if len(params.MarketplaceIDs) == 0 {
+		return params, mcp.NewToolResultError("marketplaceIds must include at least one marketplace")
+	}
+	if params.SellerID == "" {
+		return params, mcp.NewToolResultError("sellerId is required; pass it or set SP_API_SELLER_ID")
+	}
+
+	return params, nil
+}
+
+func getListingsRestrictions(ctx context.Context, spClient spapi.Client, params listingsGetRestrictionsArgs) ([]listingsRestriction, error) {
+	query := url.Values{
+		"asin":           {params.ASIN},
+		"sellerId":       {params.SellerID},
+		"marketplaceIds": {strings.Join(params.MarketplaceIDs, ",")},
+	}
+	if params.ConditionType != "" {
+		query.Set("conditionType", params.ConditionType)
+	}
+	if params.ReasonLocale != "" {
+		query.Set("reasonLocale", params.ReasonLocale)
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   listingsRestrictionsPath,
+		Query:  query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listings.getListingsRestrictions request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeListingsRestrictions(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getListingsRestrictions", httpResp, body, nil); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to decode listings.getListingsRestrictions response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getListingsRestrictions", httpResp, body, decoded.apiErrors); err != nil {
+		return nil, err
+	}
+
+	if !decoded.payloadPresent {
+		return nil, fmt.Errorf("listings.getListingsRestrictions response payload is empty")
+	}
+
+	return decoded.restrictions, nil
+}
+
+func getFBAItemEligibility(ctx context.Context, spClient spapi.Client, asin, marketplaceID, program string) (fbaItemEligibility, error) {
+	query := url.Values{
+		"asin":    {asin},
+		"program": {program},
+	}
+	if program == "INBOUND" {
+		query.Set("marketplaceIds", marketplaceID)
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   fbaItemEligibilityPath,
+		Query:  query,
+	})
+	if err != nil {
+		return fbaItemEligibility{}, fmt.Errorf("fbaInbound.getItemEligibilityPreview request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeFBAItemEligibility(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getItemEligibilityPreview", httpResp, body, nil); err != nil {
+			return fbaItemEligibility{}, err
+		}
+		return fbaItemEligibility{}, fmt.Errorf("failed to decode fbaInbound.getItemEligibilityPreview response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getItemEligibilityPreview", httpResp, body, decoded.apiErrors); err != nil {
+		return fbaItemEligibility{}, err
+	}
+
+	if !decoded.payloadPresent {
+		return fbaItemEligibility{}, fmt.Errorf("fbaInbound.getItemEligibilityPreview response payload is empty")
+	}
+
+	return decoded.eligibility, nil
+}
+
+// buildListingsSellability folds restrictions into one entry per requested marketplace. Amazon omits marketplaces with
+// no restrictions, so those are reported as listable.
+func buildListingsSellability(marketplaceIDs []string, restrictions []listingsRestriction) []listingsSellability {
+	entries := make([]listingsSellability, 0, len(marketplaceIDs))
+	for _, marketplaceID := range marketplaceIDs {
+		entry := listingsSellability{MarketplaceID: marketplaceID, CanList: true}
+
+		for _, restriction := range restrictions {
+			if restriction.MarketplaceID != marketplaceID {
+				continue
+			}
+			for _, reason := range restriction.Reasons {
+				entry.CanList = false
+				entry.Reasons = append(entry.Reasons, reason)
+				if reason.ReasonCode == reasonApprovalRequired {
+					entry.ApprovalRequired = true
+				}
+				for _, link := range reason.Links {
+					if link.Resource != "" {
+						entry.ApprovalLinks = append(entry.ApprovalLinks, link.Resource)
+					}
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func summariseListingsRestrictions(restrictions []listingsRestriction) string {
+	segments := make([]string, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		for _, reason := range restriction.Reasons {
+			segment := fmt.Sprintf("%s: %s", restriction.MarketplaceID, strings.TrimSpace(reason.Message))
+			if reason.ReasonCode != "" {
+				segment = fmt.Sprintf("%s (%s)", segment, reason.ReasonCode)
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "; ")
+}
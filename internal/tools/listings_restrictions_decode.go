@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// listingsRestriction mirrors the Listings Restrictions API Restriction model.
+type listingsRestriction struct {
+	MarketplaceID string                      `json:"marketplaceId"`
+	ConditionType string                      `json:"conditionType,omitempty"`
+	Reasons       []listingsRestrictionReason `json:"reasons,omitempty"`
+}
+
+type listingsRestrictionReason struct {
+	Message    string                    `json:"message"`
+	ReasonCode string                    `json:"reasonCode,omitempty"`
+	Links      []listingsRestrictionLink `json:"links,omitempty"`
+}
+
+type listingsRestrictionLink struct {
+	Resource string `json:"resource"`
+	Verb     string `json:"verb,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// fbaItemEligibility mirrors the FBA Inbound Eligibility ItemEligibilityPreview model.
+type fbaItemEligibility struct {
+	ASIN                    string   `json:"asin"`
+	MarketplaceID           string   `json:"marketplaceId,omitempty"`
+	Program                 string   `json:"program"`
+	IsEligibleForProgram    bool     `json:"isEligibleForProgram"`
+	IneligibilityReasonList []string `json:"ineligibilityReasonList,omitempty"`
+}
+
+type listingsRestrictionsDecoded struct {
+	restrictions   []listingsRestriction
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type fbaItemEligibilityDecoded struct {
+	eligibility    fbaItemEligibility
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+func decodeListingsRestrictions(body []byte) (listingsRestrictionsDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return listingsRestrictionsDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto listingsRestrictionsResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return listingsRestrictionsDecoded{}, err
+	}
+
+	decoded := listingsRestrictionsDecoded{apiErrors: dto.Errors}
+
+	if dto.Restrictions != nil {
+		decoded.payloadPresent = true
+		decoded.restrictions = *dto.Restrictions
+	}
+
+	return decoded, nil
+}
+
+func decodeFBAItemEligibility(body []byte) (fbaItemEligibilityDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return fbaItemEligibilityDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto fbaItemEligibilityResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return fbaItemEligibilityDecoded{}, err
+	}
+
+	decoded := fbaItemEligibilityDecoded{apiErrors: dto.Errors}
+
+	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		decoded.eligibility = *dto.Payload
+	}
+
+	return decoded, nil
+}
+
+type listingsRestrictionsResponseDTO struct {
+	Errors       []spAPIError           `json:"errors,omitempty"`
+	Restrictions *[]listingsRestriction `json:"restrictions,omitempty"`
+}
+
+type fbaItemEligibilityResponseDTO struct {
+	Errors  []spAPIError        `json:"errors,omitempty"`
+	Payload *fbaItemEligibility `json:"payload,omitempty"`
+}
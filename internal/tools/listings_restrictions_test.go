@@ -0,0 +1,51 @@
+package tools
+
+import "testing"
+
+func TestBuildListingsSellability(t *testing.T) {
+	restrictions := []listingsRestriction{
+		{
+			MarketplaceID: "ATVPDKIKX0DER",
+			ConditionType: "new_new",
+			Reasons: []listingsRestrictionReason{
+				{
+					Message:    "You need approval to list in this brand.",
+					ReasonCode: reasonApprovalRequired,
+					Links:      []listingsRestrictionLink{{Resource: "https://sellercentral.amazon.com/hz/approvalrequest", Verb: "GET"}},
+				},
+			},
+		},
+	}
+
+	entries := buildListingsSellability([]string{"ATVPDKIKX0DER", "A2EUQ1WTGCTBG2"}, restrictions)
+	if len(entries) != 2 {
+		t.Fatalf("expected two marketplaces, got %d", len(entries))
+	}
+
+	us := entries[0]
+	if us.CanList || !us.ApprovalRequired || len(us.ApprovalLinks) != 1 {
+		t.Fatalf("unexpected US verdict %+v", us)
+	}
+
+	ca := entries[1]
+	if !ca.CanList || ca.ApprovalRequired || len(ca.Reasons) != 0 {
+		t.Fatalf("unrestricted marketplace should be listable: %+v", ca)
+	}
+}
+
+func TestNormaliseListingsRestrictionsArgsUsesDefaultSeller(t *testing.T) {
+	params, failure := normaliseListingsRestrictionsArgs(listingsGetRestrictionsArgs{
+		ASIN:           " b0example ",
+		MarketplaceIDs: []string{"ATVPDKIKX0DER"},
+	}, "A1SELLER")
+	if failure != nil {
+		t.Fatalf("unexpected failure")
+	}
+	if params.SellerID != "A1SELLER" || params.ASIN != "B0EXAMPLE" {
+		t.Fatalf("unexpected params %+v", params)
+	}
+
+	if _, failure := normaliseListingsRestrictionsArgs(listingsGetRestrictionsArgs{ASIN: "B0EXAMPLE", MarketplaceIDs: []string{"ATVPDKIKX0DER"}}, ""); failure == nil {
+		t.Fatalf("expected sellerId to be required")
+	}
+}
@@ -0,0 +1,367 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+const (
+	defaultSyncLookback = 30 * 24 * time.Hour
+	defaultSyncMaxPages = 50
+)
+
+// storeDisabledMessage explains how to enable the local mirror when a store tool is called without one.
+const storeDisabledMessage = "the local order store is disabled; set SP_API_STORE_PATH to a database file to enable it"
+
+type syncOrdersArgs struct {
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	Since          string   `json:"since"`
+	IncludeItems   *bool    `json:"includeItems"`
+	MaxPages       *int     `json:"maxPages"`
+}
+
+type syncOrdersMarketplaceResult struct {
+	MarketplaceID   string    `json:"marketplaceId"`
+	Orders          int       `json:"orders"`
+	OrdersWithItems int       `json:"ordersWithItems"`
+	Pages           int       `json:"pages"`
+	Complete        bool      `json:"complete"`
+	Resumed         bool      `json:"resumed"`
+	Watermark       time.Time `json:"watermark"`
+}
+
+type syncOrdersResult struct {
+	Marketplaces []syncOrdersMarketplaceResult `json:"marketplaces"`
+	RetrievedAt  time.Time                     `json:"retrievedAt"`
+}
+
+type storeQueryOrdersArgs struct {
+	MarketplaceIDs     []string `json:"marketplaceIds"`
+	OrderStatuses      []string `json:"orderStatuses"`
+	FulfillmentChannel string   `json:"fulfillmentChannel"`
+	SellerSKU          string   `json:"sellerSku"`
+	PurchasedAfter     string   `json:"purchasedAfter"`
+	PurchasedBefore    string   `json:"purchasedBefore"`
+	ShipState          string   `json:"shipState"`
+	MinTotal           *float64 `json:"minTotal"`
+	MaxTotal           *float64 `json:"maxTotal"`
+	Limit              *int     `json:"limit"`
+	IncludeItems       bool     `json:"includeItems"`
+}
+
+type storeQueryOrdersResult struct {
+	store.OrderQueryResult
+	SyncStatus  []store.SyncState `json:"syncStatus"`
+	RetrievedAt time.Time         `json:"retrievedAt"`
+}
+
+func newOrderStoreTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+	orderStore := deps.Store
+
+	syncOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args syncOrdersArgs) (*mcp.CallToolResult, error) {
+		return executeSyncOrders(withoutResponseCache(withProgressToken(ctx, req)), args, spClient, orderStore)
+	})
+
+	queryOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args storeQueryOrdersArgs) (*mcp.CallToolResult, error) {
+		return executeStoreQueryOrders(ctx, args, orderStore)
+	})
+
+	return []registeredTool{
+		toolFromSpec(syncOrdersSpec, syncOrdersHandler),
+		toolFromSpec(storeQueryOrdersSpec, queryOrdersHandler),
+	}
+}
+
+// executeSyncOrders pulls orders updated since each marketplace's watermark into the local store. Each run fixes its
+// LastUpdatedBefore bound up front, so a complete run can move the watermark there. Pages are saved as they arrive; when
+// maxPages or an error stops a run early, the next token is saved and the following call resumes it before moving the
+// watermark.
+func executeSyncOrders(ctx context.Context, args syncOrdersArgs, spClient spapi.Client, orderStore *store.Store) (*mcp.CallToolResult, error) {
+	if orderStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	client, failure := ensureOrdersClient(spClient)
+	if failure != nil {
+		return failure, nil
+	}
+
+	marketplaces := trimStringSlice(args.MarketplaceIDs)
+	if len(marketplaces) == 0 {
+		return mcp.NewToolResultError("marketplaceIds is required"), nil
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-defaultSyncLookback)
+	if raw := strings.TrimSpace(args.Since); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return mcp.NewToolResultError("since must be an ISO 8601 timestamp"), nil
+		}
+		since = parsed.UTC()
+	}
+
+	includeItems := args.IncludeItems == nil || *args.IncludeItems
+	maxPages := sanitizePagingCap(args.MaxPages, defaultSyncMaxPages, maxPagingMaxPages)
+
+	// Page progress is reported per marketplace below, so the page loop gets no progress token.
+	pageCtx := context.WithValue(ctx, progressTokenKey{}, nil)
+
+	result := syncOrdersResult{Marketplaces: make([]syncOrdersMarketplaceResult, 0, len(marketplaces))}
+	step := 0
+
+	for _, marketplaceID := range marketplaces {
+		state, err := orderStore.SyncState(ctx, marketplaceID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("sync.orders could not read sync state", err), nil
+		}
+
+		summary := syncOrdersMarketplaceResult{MarketplaceID: marketplaceID, Resumed: state.PendingNextToken != ""}
+
+		filters := ordersv0.GetOrdersParams{MarketplaceIds: []string{marketplaceID}}
+		before := state.PendingBefore
+		if !summary.Resumed {
+			after := state.Watermark
+			if after.IsZero() {
+				after = since
+			}
+			before = now.Add(-ordersCreatedBeforeMinDelay).Truncate(time.Second)
+			filters.LastUpdatedAfter = stringPtr(after.Format(time.RFC3339))
+			filters.LastUpdatedBefore = stringPtr(before.Format(time.RFC3339))
+		}
+
+		// Each page is saved with its items before the next one is fetched, and the token for the next page is
+		// recorded with it, so a failure part way through keeps what was mirrored and the next call resumes there.
+		resumeToken := state.PendingNextToken
+		fetch := func(fetchCtx context.Context, token string) ([]ordersv0.Order, string, *mcp.CallToolResult) {
+			params := filters
+			if token != "" {
+				params = ordersv0.GetOrdersParams{NextToken: stringPtr(token)}
+			}
+			payload, failure := fetchOrdersPage(fetchCtx, client, params)
+			if failure != nil {
+				return nil, "", failure
+			}
+
+			mirrored := make([]store.Order, 0, len(payload.Orders))
+			for _, order := range payload.Orders {
+				record := storeOrderFromAPI(order, marketplaceID)
+				if includeItems {
+					items, err := fetchAllOrderItems(fetchCtx, client, order.AmazonOrderId)
+					if err != nil {
+						return nil, "", mcp.NewToolResultErrorFromErr(fmt.Sprintf("sync.orders could not fetch items for order %s", order.AmazonOrderId), err)
+					}
+					record.Items = storeOrderItemsFromAPI(items)
+					summary.OrdersWithItems++
+
+					step++
+					reportProgress(ctx, syncOrdersSpec.Name, step, 0, fmt.Sprintf("%s: %s fetched items for %d orders", syncOrdersSpec.Name, marketplaceID, summary.OrdersWithItems))
+				}
+				mirrored = append(mirrored, record)
+			}
+
+			if err := orderStore.SaveOrders(fetchCtx, mirrored, time.Now().UTC()); err != nil {
+				return nil, "", mcp.NewToolResultErrorFromErr("sync.orders could not save orders", err)
+			}
+
+			next := valueOrEmpty(payload.NextToken)
+			if next != "" {
+				state.PendingNextToken, state.PendingBefore = next, before
+				if err := orderStore.SaveSyncState(fetchCtx, state); err != nil {
+					return nil, "", mcp.NewToolResultErrorFromErr("sync.orders could not save sync state", err)
+				}
+			}
+			return payload.Orders, next, nil
+		}
+
+		orders, next, paging, failure := collectPages(pageCtx, pagedOperation{tool: syncOrdersSpec.Name, operation: syncOrdersSpec.RateLimit}, pagingArgs{AllPages: true, MaxPages: &maxPages, MaxItems: intPtr(maxPagingMaxItems)}, state.PendingNextToken, fetch)
+		if failure != nil {
+			if resumeToken != "" && state.PendingNextToken == resumeToken {
+				// Next tokens expire; drop one that failed before any page of this call was saved, so the following
+				// sync restarts from the watermark.
+				state.PendingNextToken, state.PendingBefore = "", time.Time{}
+				if err := orderStore.SaveSyncState(ctx, state); err != nil {
+					return mcp.NewToolResultErrorFromErr("sync.orders could not save sync state", err), nil
+				}
+			}
+			return failure, nil
+		}
+
+		step++
+		reportProgress(ctx, syncOrdersSpec.Name, step, 0, fmt.Sprintf("%s: %s fetched %d orders in %d pages", syncOrdersSpec.Name, marketplaceID, len(orders), paging.Pages))
+
+		summary.Orders = len(orders)
+		summary.Pages = paging.Pages
+		summary.Complete = next == ""
+
+		if summary.Complete {
+			state.Watermark = before
+			state.PendingNextToken, state.PendingBefore = "", time.Time{}
+		} else {
+			state.PendingNextToken, state.PendingBefore = next, before
+		}
+		state.LastSyncedAt = time.Now().UTC()
+		if err := orderStore.SaveSyncState(ctx, state); err != nil {
+			return mcp.NewToolResultErrorFromErr("sync.orders could not save sync state", err), nil
+		}
+
+		summary.Watermark = state.Watermark
+		result.Marketplaces = append(result.Marketplaces, summary)
+	}
+
+	result.RetrievedAt = time.Now().UTC()
+
+	parts := make([]string, 0, len(result.Marketplaces))
+	for _, summary := range result.Marketplaces {
+		part := fmt.Sprintf("%s: %d orders", summary.MarketplaceID, summary.Orders)
+		if !summary.Complete {
+			part += " (incomplete, call sync.orders again to continue)"
+		}
+		parts = append(parts, part)
+	}
+
+	return mcp.NewToolResultStructured(result, "Synced "+strings.Join(parts, "; ")), nil
+}
+
+func executeStoreQueryOrders(ctx context.Context, args storeQueryOrdersArgs, orderStore *store.Store) (*mcp.CallToolResult, error) {
+	if orderStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	query := store.OrderQuery{
+		MarketplaceIDs:     trimStringSlice(args.MarketplaceIDs),
+		OrderStatuses:      trimStringSlice(args.OrderStatuses),
+		FulfillmentChannel: strings.TrimSpace(args.FulfillmentChannel),
+		SellerSKU:          strings.TrimSpace(args.SellerSKU),
+		ShipState:          strings.TrimSpace(args.ShipState),
+		MinTotal:           args.MinTotal,
+		MaxTotal:           args.MaxTotal,
+		IncludeItems:       args.IncludeItems,
+	}
+	if args.Limit != nil {
+		query.Limit = *args.Limit
+	}
+
+	for _, bound := range []struct {
+		name   string
+		raw    string
+		target *time.Time
+	}{
+		{"purchasedAfter", args.PurchasedAfter, &query.PurchasedAfter},
+		{"purchasedBefore", args.PurchasedBefore, &query.PurchasedBefore},
+	} {
+		raw := strings.TrimSpace(bound.raw)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return mcp.NewToolResultError(bound.name + " must be an ISO 8601 timestamp"), nil
+		}
+		*bound.target = parsed
+	}
+
+	found, err := orderStore.QueryOrders(ctx, query)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("store.queryOrders failed", err), nil
+	}
+
+	states, err := orderStore.SyncStates(ctx)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("store.queryOrders could not read sync status", err), nil
+	}
+
+	result := storeQueryOrdersResult{
+		OrderQueryResult: found,
+		SyncStatus:       states,
+		RetrievedAt:      time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("%d orders match in the local store", found.Matched)
+	if len(found.Orders) < found.Matched {
+		fallback = fmt.Sprintf("%s, showing the newest %d", fallback, len(found.Orders))
+	}
+	if len(states) == 0 {
+		fallback += "; the store has never been synced, run sync.orders first"
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func storeOrderFromAPI(order ordersv0.Order, marketplaceID string) store.Order {
+	record := store.Order{
+		AmazonOrderID:      order.AmazonOrderId,
+		MarketplaceID:      marketplaceID,
+		OrderStatus:        order.OrderStatus,
+		FulfillmentChannel: valueOrEmpty(order.FulfillmentChannel),
+		SalesChannel:       valueOrEmpty(order.SalesChannel),
+		PurchaseDate:       parseOrderTime(order.PurchaseDate),
+		LastUpdateDate:     parseOrderTime(order.LastUpdateDate),
+	}
+	if order.MarketplaceId != nil && *order.MarketplaceId != "" {
+		record.MarketplaceID = *order.MarketplaceId
+	}
+	record.OrderTotal, record.Currency = moneyValue(order.OrderTotal)
+	if address := order.ShippingAddress; address != nil {
+		record.ShipCity = valueOrEmpty(address.City)
+		record.ShipState = valueOrEmpty(address.StateOrRegion)
+		record.ShipPostalCode = valueOrEmpty(address.PostalCode)
+		record.ShipCountry = valueOrEmpty(address.CountryCode)
+	}
+	if raw, err := json.Marshal(order); err == nil {
+		record.Raw = raw
+	}
+	return record
+}
+
+func storeOrderItemsFromAPI(items []ordersv0.OrderItem) []store.OrderItem {
+	records := make([]store.OrderItem, 0, len(items))
+	for _, item := range items {
+		record := store.OrderItem{
+			OrderItemID:     item.OrderItemId,
+			SellerSKU:       valueOrEmpty(item.SellerSKU),
+			ASIN:            item.ASIN,
+			Title:           valueOrEmpty(item.Title),
+			QuantityOrdered: item.QuantityOrdered,
+		}
+		if item.QuantityShipped != nil {
+			record.QuantityShipped = *item.QuantityShipped
+		}
+		record.ItemPrice, record.Currency = moneyValue(item.ItemPrice)
+		if raw, err := json.Marshal(item); err == nil {
+			record.Raw = raw
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func moneyValue(money *ordersv0.Money) (*float64, string) {
+	if money == nil {
+		return nil, ""
+	}
+	currency := valueOrEmpty(money.CurrencyCode)
+	if money.Amount == nil {
+		return nil, currency
+	}
+	amount, err := strconv.ParseFloat(strings.TrimSpace(*money.Amount), 64)
+	if err != nil {
+		return nil, currency
+	}
+	return &amount, currency
+}
+
+func intPtr(value int) *int {
+	return &value
+}
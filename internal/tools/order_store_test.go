@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+func TestStoreOrderFromAPI(t *testing.T) {
+	marketplace := "A2EUQ1WTGCTBG2"
+	order := ordersv0.Order{
+		AmazonOrderId:      "111-2222222-3333333",
+		MarketplaceId:      &marketplace,
+		OrderStatus:        "Shipped",
+		FulfillmentChannel: stringPtr("AFN"),
+		PurchaseDate:       "2026-01-05T10:00:00Z",
+		LastUpdateDate:     "2026-01-06T10:00:00Z",
+		OrderTotal:         &ordersv0.Money{Amount: stringPtr("19.99"), CurrencyCode: stringPtr("CAD")},
+		ShippingAddress:    &ordersv0.Address{StateOrRegion: stringPtr("ON"), CountryCode: stringPtr("CA")},
+	}
+
+	record := storeOrderFromAPI(order, "ATVPDKIKX0DER")
+	if record.MarketplaceID != marketplace || record.FulfillmentChannel != "AFN" || record.ShipState != "ON" {
+		t.Fatalf("unexpected record %+v", record)
+	}
+	if record.OrderTotal == nil || *record.OrderTotal != 19.99 || record.Currency != "CAD" {
+		t.Fatalf("unexpected total %v %s", record.OrderTotal, record.Currency)
+	}
+	if record.PurchaseDate.IsZero() || len(record.Raw) == 0 {
+		t.Fatalf("expected purchase date and raw JSON, got %+v", record)
+	}
+
+	shipped := 1
+	items := storeOrderItemsFromAPI([]ordersv0.OrderItem{{
+		OrderItemId:     "1",
+		SellerSKU:       stringPtr("SKU-1"),
+		QuantityOrdered: 2,
+		QuantityShipped: &shipped,
+		ItemPrice:       &ordersv0.Money{Amount: stringPtr("not a number")},
+	}})
+	if len(items) != 1 || items[0].SellerSKU != "SKU-1" || items[0].QuantityShipped != 1 || items[0].ItemPrice != nil {
+		t.Fatalf("unexpected items %+v", items)
+	}
+}
+
+func TestOrderStoreToolsRequireStore(t *testing.T) {
+	result, err := executeStoreQueryOrders(context.Background(), storeQueryOrdersArgs{}, nil)
+	if err != nil || result == nil || !result.IsError {
+		t.Fatalf("expected a tool error without a store, got %+v (%v)", result, err)
+	}
+}
+
+// secondPageFailsTransport serves one page of orders and rejects the request for the next one.
+type secondPageFailsTransport struct{}
+
+func (secondPageFailsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, `{"payload":{"Orders":[{"AmazonOrderId":"111-1","PurchaseDate":"2026-01-05T00:00:00Z","LastUpdateDate":"2026-01-05T00:00:00Z","OrderStatus":"Shipped"}],"NextToken":"page-2"}}`
+	if req.URL.Query().Get("NextToken") != "" {
+		status, body = http.StatusBadRequest, `{"errors":[{"code":"InvalidInput","message":"bad token"}]}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Request:    req,
+	}, nil
+}
+
+func TestSyncOrdersSavesEachPage(t *testing.T) {
+	orderStore, err := store.Open(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer orderStore.Close()
+
+	ctx := context.WithValue(context.Background(), baseTransportKey{}, secondPageFailsTransport{})
+	includeItems := false
+	result, err := executeSyncOrders(ctx, syncOrdersArgs{MarketplaceIDs: []string{"ATVPDKIKX0DER"}, IncludeItems: &includeItems}, cassetteClient{}, orderStore)
+	if err != nil || result == nil || !result.IsError {
+		t.Fatalf("expected the second page to fail the sync, got %+v (%v)", result, err)
+	}
+
+	found, err := orderStore.QueryOrders(context.Background(), store.OrderQuery{})
+	if err != nil || found.Matched != 1 {
+		t.Fatalf("expected the first page to be saved, got %+v (%v)", found, err)
+	}
+	state, err := orderStore.SyncState(context.Background(), "ATVPDKIKX0DER")
+	if err != nil || state.PendingNextToken != "page-2" || !state.Watermark.IsZero() {
+		t.Fatalf("expected the sync to resume from page 2, got %+v (%v)", state, err)
+	}
+}
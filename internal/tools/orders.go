@@ -12,7 +12,6 @@ import (
 	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
 )
@@ -32,6 +31,9 @@ type ordersListOrdersArgs struct {
 	EasyShipShipmentStatuses []string `json:"easyShipShipmentStatuses"`
 	AmazonOrderIDs           []string `json:"amazonOrderIds"`
 	NextToken                string   `json:"nextToken"`
+	SplitDays                *int     `json:"splitDays"`
+	Concurrency              *int     `json:"concurrency"`
+	pagingArgs
 }
 
 type ordersListOrdersResult struct {
@@ -39,7 +41,12 @@ type ordersListOrdersResult struct {
 	NextToken         string           `json:"nextToken,omitempty"`
 	CreatedBefore     string           `json:"createdBefore,omitempty"`
 	LastUpdatedBefore string           `json:"lastUpdatedBefore,omitempty"`
-	RetrievedAt       time.Time        `json:"retrievedAt"`
+	Paging            *pagingSummary   `json:"paging,omitempty"`
+	Windows           []ordersWindow   `json:"windows,omitempty"`
+	// ResumeCreatedAfter is set when a split call's budget ran out before every window was started; calling again with
+	// it as createdAfter, and the same createdBefore, fetches the rest.
+	ResumeCreatedAfter string    `json:"resumeCreatedAfter,omitempty"`
+	RetrievedAt        time.Time `json:"retrievedAt"`
 }
 
 type ordersGetOrderArgs struct {
@@ -72,12 +79,14 @@ type ordersGetOrderBuyerInfoResult struct {
 type ordersGetOrderItemsArgs struct {
 	AmazonOrderID string `json:"amazonOrderId"`
 	NextToken     string `json:"nextToken"`
+	pagingArgs
 }
 
 type ordersGetOrderItemsResult struct {
 	AmazonOrderID string               `json:"amazonOrderId"`
 	Items         []ordersv0.OrderItem `json:"items"`
 	NextToken     string               `json:"nextToken,omitempty"`
+	Paging        *pagingSummary       `json:"paging,omitempty"`
 	RetrievedAt   time.Time            `json:"retrievedAt"`
 }
 
@@ -85,14 +94,15 @@ type ordersGetOrderItemsBuyerInfoResult struct {
 	AmazonOrderID string                        `json:"amazonOrderId"`
 	Items         []ordersv0.OrderItemBuyerInfo `json:"items"`
 	NextToken     string                        `json:"nextToken,omitempty"`
+	Paging        *pagingSummary                `json:"paging,omitempty"`
 	RetrievedAt   time.Time                     `json:"retrievedAt"`
 }
 
-func newOrdersTools(deps Dependencies) []server.ServerTool {
+func newOrdersTools(deps Dependencies) []registeredTool {
 	spClient := deps.SellingPartner
 
-	listOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersListOrdersArgs) (*mcp.CallToolResult, error) {
-		return executeOrdersListOrders(ctx, args, spClient)
+	listOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args ordersListOrdersArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersListOrders(withProgressToken(ctx, req), args, spClient)
 	})
 
 	getOrderHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersGetOrderArgs) (*mcp.CallToolResult, error) {
@@ -107,21 +117,21 @@ func newOrdersTools(deps Dependencies) []server.ServerTool {
 		return executeOrdersGetOrderBuyerInfo(ctx, strings.TrimSpace(args.AmazonOrderID), spClient)
 	})
 
-	getOrderItemsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersGetOrderItemsArgs) (*mcp.CallToolResult, error) {
-		return executeOrdersGetOrderItems(ctx, args, spClient)
+	getOrderItemsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args ordersGetOrderItemsArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersGetOrderItems(withProgressToken(ctx, req), args, spClient)
 	})
 
-	getOrderItemsBuyerInfoHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersGetOrderItemsArgs) (*mcp.CallToolResult, error) {
-		return executeOrdersGetOrderItemsBuyerInfo(ctx, args, spClient)
+	getOrderItemsBuyerInfoHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args ordersGetOrderItemsArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersGetOrderItemsBuyerInfo(withProgressToken(ctx, req), args, spClient)
 	})
 
-	return []server.ServerTool{
-		serverToolFromSpec(ordersListOrdersSpec, listOrdersHandler),
-		serverToolFromSpec(ordersGetOrderSpec, getOrderHandler),
-		serverToolFromSpec(ordersGetOrderAddressSpec, getOrderAddressHandler),
-		serverToolFromSpec(ordersGetOrderBuyerInfoSpec, getOrderBuyerInfoHandler),
-		serverToolFromSpec(ordersGetOrderItemsSpec, getOrderItemsHandler),
-		serverToolFromSpec(ordersGetOrderItemsBuyerInfoSpec, getOrderItemsBuyerInfoHandler),
+	return []registeredTool{
+		toolFromSpec(ordersListOrdersSpec, listOrdersHandler),
+		toolFromSpec(ordersGetOrderSpec, getOrderHandler),
+		toolFromSpec(ordersGetOrderAddressSpec, getOrderAddressHandler),
+		toolFromSpec(ordersGetOrderBuyerInfoSpec, getOrderBuyerInfoHandler),
+		toolFromSpec(ordersGetOrderItemsSpec, getOrderItemsHandler),
+		toolFromSpec(ordersGetOrderItemsBuyerInfoSpec, getOrderItemsBuyerInfoHandler),
 	}
 }
 
@@ -132,39 +142,89 @@ func executeOrdersListOrders(ctx context.Context, args ordersListOrdersArgs, spC
 	}
 
 	nextToken := strings.TrimSpace(args.NextToken)
-	params := ordersv0.GetOrdersParams{}
+	filters := ordersv0.GetOrdersParams{}
 
 	if nextToken != "" {
 		if hasListOrdersFilters(args) {
 			return mcp.NewToolResultError("when nextToken is provided, omit additional filters"), nil
 		}
-		params.NextToken = stringPtr(nextToken)
 	} else {
 		marketplaces := trimStringSlice(args.MarketplaceIDs)
 		if len(marketplaces) == 0 {
 			return mcp.NewToolResultError("marketplaceIds is required unless nextToken is provided"), nil
 		}
-		params.MarketplaceIds = marketplaces
-		params.CreatedAfter = stringPtr(args.CreatedAfter)
-		params.CreatedBefore = stringPtr(args.CreatedBefore)
-		params.LastUpdatedAfter = stringPtr(args.LastUpdatedAfter)
-		params.LastUpdatedBefore = stringPtr(args.LastUpdatedBefore)
-		params.OrderStatuses = stringSlicePtr(args.OrderStatuses)
-		params.FulfillmentChannels = stringSlicePtr(args.FulfillmentChannels)
-		params.PaymentMethods = stringSlicePtr(args.PaymentMethods)
-		params.BuyerEmail = stringPtr(args.BuyerEmail)
-		params.SellerOrderId = stringPtr(args.SellerOrderID)
-		params.EasyShipShipmentStatuses = stringSlicePtr(args.EasyShipShipmentStatuses)
-		params.AmazonOrderIds = stringSlicePtr(args.AmazonOrderIDs)
-		params.MaxResultsPerPage = sanitizeMaxResults(args.MaxResultsPerPage)
+		filters.MarketplaceIds = marketplaces
+		filters.CreatedAfter = stringPtr(args.CreatedAfter)
+		filters.CreatedBefore = stringPtr(args.CreatedBefore)
+		filters.LastUpdatedAfter = stringPtr(args.LastUpdatedAfter)
+		filters.LastUpdatedBefore = stringPtr(args.LastUpdatedBefore)
+		filters.OrderStatuses = stringSlicePtr(args.OrderStatuses)
+		filters.FulfillmentChannels = stringSlicePtr(args.FulfillmentChannels)
+		filters.PaymentMethods = stringSlicePtr(args.PaymentMethods)
+		filters.BuyerEmail = stringPtr(args.BuyerEmail)
+		filters.SellerOrderId = stringPtr(args.SellerOrderID)
+		filters.EasyShipShipmentStatuses = stringSlicePtr(args.EasyShipShipmentStatuses)
+		filters.AmazonOrderIds = stringSlicePtr(args.AmazonOrderIDs)
+		filters.MaxResultsPerPage = sanitizeMaxResults(args.MaxResultsPerPage)
+	}
+
+	if args.SplitDays != nil {
+		return executeOrdersListOrdersByWindow(ctx, client, args, filters)
+	}
+
+	var createdBefore, lastUpdatedBefore string
+	fetch := func(ctx context.Context, token string) ([]ordersv0.Order, string, *mcp.CallToolResult) {
+		params := filters
+		if token != "" {
+			params = ordersv0.GetOrdersParams{NextToken: stringPtr(token)}
+		}
+
+		payload, failure := fetchOrdersPage(ctx, client, params)
+		if failure != nil {
+			return nil, "", failure
+		}
+
+		if createdBefore == "" {
+			createdBefore = valueOrEmpty(payload.CreatedBefore)
+		}
+		if lastUpdatedBefore == "" {
+			lastUpdatedBefore = valueOrEmpty(payload.LastUpdatedBefore)
+		}
+		return payload.Orders, valueOrEmpty(payload.NextToken), nil
 	}
 
+	orders, next, paging, failure := collectPages(ctx, pagedOperation{tool: ordersListOrdersSpec.Name, operation: ordersListOrdersSpec.RateLimit}, args.pagingArgs, nextToken, fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := ordersListOrdersResult{
+		Orders:            orders,
+		NextToken:         next,
+		CreatedBefore:     createdBefore,
+		LastUpdatedBefore: lastUpdatedBefore,
+		Paging:            paging,
+		RetrievedAt:       time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d orders", len(result.Orders))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// fetchOrdersPage sends one GetOrders request and returns its payload.
+func fetchOrdersPage(ctx context.Context, client *ordersv0.ClientWithResponses, params ordersv0.GetOrdersParams) (*ordersv0.OrdersList, *mcp.CallToolResult) {
 	resp, err := client.GetOrdersWithResponse(ctx, &params)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("orders.listOrders request failed", err), nil
+		return nil, mcp.NewToolResultErrorFromErr("orders.listOrders request failed", err)
 	}
 	if resp == nil {
-		return mcp.NewToolResultError("orders.listOrders returned no response"), nil
+		return nil, mcp.NewToolResultError("orders.listOrders returned no response")
 	}
 
 	var apiErrors *ordersv0.ErrorList
@@ -174,28 +234,14 @@ func executeOrdersListOrders(ctx context.Context, args ordersListOrdersArgs, spC
 
 	if err := ensureOrdersAPIResponse("listOrders", resp.HTTPResponse, resp.Body, apiErrors); err != nil {
 		log.Printf("[ERROR] orders.listOrders: %v", err)
-		return mcp.NewToolResultError(err.Error()), nil
+		return nil, mcp.NewToolResultError(err.Error())
 	}
 
 	if resp.Model == nil || resp.Model.Payload == nil {
-		return mcp.NewToolResultError("orders.listOrders response payload is empty"), nil
+		return nil, mcp.NewToolResultError("orders.listOrders response payload is empty")
 	}
 
-	payload := resp.Model.Payload
-	result := ordersListOrdersResult{
-		Orders:            payload.Orders,
-		NextToken:         valueOrEmpty(payload.NextToken),
-		CreatedBefore:     valueOrEmpty(payload.CreatedBefore),
-		LastUpdatedBefore: valueOrEmpty(payload.LastUpdatedBefore),
-		RetrievedAt:       time.Now().UTC(),
-	}
-
-	fallback := fmt.Sprintf("Retrieved %d orders", len(result.Orders))
-	if result.NextToken != "" {
-		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
-	}
-
-	return mcp.NewToolResultStructured(result, fallback), nil
+	return resp.Model.Payload, nil
 }
 
 func executeOrdersGetOrder(ctx context.Context, orderID string, spClient spapi.Client) (*mcp.CallToolResult, error) {
@@ -359,43 +405,51 @@ func executeOrdersGetOrderItems(ctx context.Context, args ordersGetOrderItemsArg
 		return mcp.NewToolResultError("amazonOrderId is required"), nil
 	}
 
-	params := ordersv0.GetOrderItemsParams{}
-	nextToken := strings.TrimSpace(args.NextToken)
-	if nextToken != "" {
-		params.NextToken = &nextToken
-	}
+	fetch := func(ctx context.Context, token string) ([]ordersv0.OrderItem, string, *mcp.CallToolResult) {
+		params := ordersv0.GetOrderItemsParams{NextToken: stringPtr(token)}
 
-	resp, err := client.GetOrderItemsWithResponse(ctx, orderID, &params)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("orders.getOrderItems request failed", err), nil
-	}
-	if resp == nil {
-		return mcp.NewToolResultError("orders.getOrderItems returned no response"), nil
-	}
+		resp, err := client.GetOrderItemsWithResponse(ctx, orderID, &params)
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("orders.getOrderItems request failed", err)
+		}
+		if resp == nil {
+			return nil, "", mcp.NewToolResultError("orders.getOrderItems returned no response")
+		}
 
-	var apiErrors *ordersv0.ErrorList
-	if resp.Model != nil {
-		apiErrors = resp.Model.Errors
-	}
+		var apiErrors *ordersv0.ErrorList
+		if resp.Model != nil {
+			apiErrors = resp.Model.Errors
+		}
 
-	if err := ensureOrdersAPIResponse("getOrderItems", resp.HTTPResponse, resp.Body, apiErrors); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		if err := ensureOrdersAPIResponse("getOrderItems", resp.HTTPResponse, resp.Body, apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if resp.Model == nil || resp.Model.Payload == nil {
+			return nil, "", mcp.NewToolResultError("orders.getOrderItems response payload is empty")
+		}
+
+		payload := resp.Model.Payload
+		return payload.OrderItems, valueOrEmpty(payload.NextToken), nil
 	}
 
-	if resp.Model == nil || resp.Model.Payload == nil {
-		return mcp.NewToolResultError("orders.getOrderItems response payload is empty"), nil
+	items, next, paging, failure := collectPages(ctx, pagedOperation{tool: ordersGetOrderItemsSpec.Name, operation: ordersGetOrderItemsSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
 	}
 
-	payload := resp.Model.Payload
 	result := ordersGetOrderItemsResult{
-		AmazonOrderID: payload.AmazonOrderId,
-		Items:         payload.OrderItems,
-		NextToken:     valueOrEmpty(payload.NextToken),
+		AmazonOrderID: orderID,
+		Items:         items,
+		NextToken:     next,
+		Paging:        paging,
 		RetrievedAt:   time.Now().UTC(),
 	}
 
 	fallback := fmt.Sprintf("Retrieved %d items for order %s", len(result.Items), result.AmazonOrderID)
-	if result.NextToken != "" {
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
 		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
 	}
 
@@ -413,43 +467,51 @@ func executeOrdersGetOrderItemsBuyerInfo(ctx context.Context, args ordersGetOrde
 		return mcp.NewToolResultError("amazonOrderId is required"), nil
 	}
 
-	params := ordersv0.GetOrderItemsBuyerInfoParams{}
-	nextToken := strings.TrimSpace(args.NextToken)
-	if nextToken != "" {
-		params.NextToken = &nextToken
-	}
+	fetch := func(ctx context.Context, token string) ([]ordersv0.OrderItemBuyerInfo, string, *mcp.CallToolResult) {
+		params := ordersv0.GetOrderItemsBuyerInfoParams{NextToken: stringPtr(token)}
 
-	resp, err := client.GetOrderItemsBuyerInfoWithResponse(ctx, orderID, &params)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("orders.getOrderItemsBuyerInfo request failed", err), nil
-	}
-	if resp == nil {
-		return mcp.NewToolResultError("orders.getOrderItemsBuyerInfo returned no response"), nil
-	}
+		resp, err := client.GetOrderItemsBuyerInfoWithResponse(ctx, orderID, &params)
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("orders.getOrderItemsBuyerInfo request failed", err)
+		}
+		if resp == nil {
+			return nil, "", mcp.NewToolResultError("orders.getOrderItemsBuyerInfo returned no response")
+		}
 
-	var apiErrors *ordersv0.ErrorList
-	if resp.Model != nil {
-		apiErrors = resp.Model.Errors
-	}
+		var apiErrors *ordersv0.ErrorList
+		if resp.Model != nil {
+			apiErrors = resp.Model.Errors
+		}
 
-	if err := ensureOrdersAPIResponse("getOrderItemsBuyerInfo", resp.HTTPResponse, resp.Body, apiErrors); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		if err := ensureOrdersAPIResponse("getOrderItemsBuyerInfo", resp.HTTPResponse, resp.Body, apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if resp.Model == nil || resp.Model.Payload == nil {
+			return nil, "", mcp.NewToolResultError("orders.getOrderItemsBuyerInfo response payload is empty")
+		}
+
+		payload := resp.Model.Payload
+		return payload.OrderItems, valueOrEmpty(payload.NextToken), nil
 	}
 
-	if resp.Model == nil || resp.Model.Payload == nil {
-		return mcp.NewToolResultError("orders.getOrderItemsBuyerInfo response payload is empty"), nil
+	items, next, paging, failure := collectPages(ctx, pagedOperation{tool: ordersGetOrderItemsBuyerInfoSpec.Name, operation: ordersGetOrderItemsBuyerInfoSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
 	}
 
-	payload := resp.Model.Payload
 	result := ordersGetOrderItemsBuyerInfoResult{
-		AmazonOrderID: payload.AmazonOrderId,
-		Items:         payload.OrderItems,
-		NextToken:     valueOrEmpty(payload.NextToken),
+		AmazonOrderID: orderID,
+		Items:         items,
+		NextToken:     next,
+		Paging:        paging,
 		RetrievedAt:   time.Now().UTC(),
 	}
 
 	fallback := fmt.Sprintf("Retrieved buyer info for %d items on order %s", len(result.Items), result.AmazonOrderID)
-	if result.NextToken != "" {
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
 		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
 	}
 
@@ -458,7 +520,7 @@ func executeOrdersGetOrderItemsBuyerInfo(ctx context.Context, args ordersGetOrde
 
 // buildOrdersClient constructs an Orders API client that reuses the shared Selling Partner authentication state.
 func buildOrdersClient(spClient spapi.Client) (*ordersv0.ClientWithResponses, error) {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient := newSellingPartnerHTTPClient()
 
 	return ordersv0.NewClientWithResponses(
 		spClient.Endpoint(),
@@ -487,9 +549,14 @@ func fetchAllOrderItems(ctx context.Context, client *ordersv0.ClientWithResponse
 	)
 
 	for {
-		var params *ordersv0.GetOrderItemsParams
+		// The SDK reads params unconditionally, so the first page still needs an empty struct.
+		params := &ordersv0.GetOrderItemsParams{}
 		if hasNextToken {
-			params = &ordersv0.GetOrderItemsParams{NextToken: &nextToken}
+			params.NextToken = &nextToken
+		}
+
+		if _, err := waitForOperation(ctx, "orders.getOrderItems"); err != nil {
+			return nil, fmt.Errorf("waiting for getOrderItems rate limit: %w", err)
 		}
 
 		resp, err := client.GetOrderItemsWithResponse(ctx, orderID, params)
@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	maxOrdersSplitDays          = 30
+	defaultOrdersConcurrency    = 2
+	maxOrdersConcurrency        = 4
+	ordersCreatedBeforeMinDelay = 2 * time.Minute
+)
+
+// ordersWindow reports how one sub-window of a split listOrders call was fetched. NextToken is set when a paging cap
+// stopped the window early, so the caller can resume just that window.
+type ordersWindow struct {
+	CreatedAfter  string `json:"createdAfter"`
+	CreatedBefore string `json:"createdBefore"`
+	Orders        int    `json:"orders"`
+	Pages         int    `json:"pages"`
+	Truncated     bool   `json:"truncated"`
+	StoppedBy     string `json:"stoppedBy,omitempty"`
+	NextToken     string `json:"nextToken,omitempty"`
+}
+
+// executeOrdersListOrdersByWindow splits the createdAfter/createdBefore range into splitDays windows and pages through
+// them with a fixed pool of workers. Every request still waits on the shared GetOrders rate limiter, so concurrency only
+// overlaps the waiting. maxItems and maxPages bound the whole call: windows are started in order until the budget runs
+// out, and resumeCreatedAfter marks where the unfetched windows begin. Orders are de-duplicated by AmazonOrderId,
+// because both window bounds are inclusive, and sorted by purchase date.
+func executeOrdersListOrdersByWindow(ctx context.Context, client *ordersv0.ClientWithResponses, args ordersListOrdersArgs, filters ordersv0.GetOrdersParams) (*mcp.CallToolResult, error) {
+	windows, failure := planOrdersWindows(args, time.Now().UTC())
+	if failure != nil {
+		return failure, nil
+	}
+
+	concurrency := defaultOrdersConcurrency
+	if args.Concurrency != nil && *args.Concurrency > 0 {
+		concurrency = min(*args.Concurrency, maxOrdersConcurrency)
+	}
+
+	// Each window may use whatever the shared budget has left, so its own caps are the largest allowed.
+	budget := newPagingBudget(pagingArgs{MaxItems: args.MaxItems, MaxPages: args.MaxPages})
+	paging := pagingArgs{AllPages: true, MaxItems: intPtr(maxPagingMaxItems), MaxPages: intPtr(maxPagingMaxPages), budget: budget}
+	operation := pagedOperation{tool: ordersListOrdersSpec.Name, operation: ordersListOrdersSpec.RateLimit}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Sub-windows report progress per window below rather than per page, so the page loop gets no progress token.
+	windowCtx := context.WithValue(ctx, progressTokenKey{}, nil)
+
+	var (
+		mu        sync.Mutex
+		firstFail *mcp.CallToolResult
+		completed int
+		started   int
+		wg        sync.WaitGroup
+	)
+	results := make([][]ordersv0.Order, len(windows))
+
+	fetchWindow := func(i int) {
+		windowFilters := filters
+		windowFilters.CreatedAfter = stringPtr(windows[i].CreatedAfter)
+		windowFilters.CreatedBefore = stringPtr(windows[i].CreatedBefore)
+
+		fetch := func(ctx context.Context, token string) ([]ordersv0.Order, string, *mcp.CallToolResult) {
+			params := windowFilters
+			if token != "" {
+				params = ordersv0.GetOrdersParams{NextToken: stringPtr(token)}
+			}
+			payload, failure := fetchOrdersPage(ctx, client, params)
+			if failure != nil {
+				return nil, "", failure
+			}
+			return payload.Orders, valueOrEmpty(payload.NextToken), nil
+		}
+
+		orders, next, summary, failure := collectPages(windowCtx, operation, paging, "", fetch)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failure != nil {
+			if firstFail == nil {
+				firstFail = failure
+				cancel()
+			}
+			return
+		}
+
+		results[i] = orders
+		windows[i].Orders = len(orders)
+		windows[i].Pages = summary.Pages
+		windows[i].Truncated = summary.Truncated
+		windows[i].StoppedBy = summary.StoppedBy
+		windows[i].NextToken = next
+
+		completed++
+		reportProgress(ctx, ordersListOrdersSpec.Name, completed, len(windows),
+			fmt.Sprintf("%s: window %s to %s done (%d orders, %d of %d windows)", ordersListOrdersSpec.Name, windows[i].CreatedAfter, windows[i].CreatedBefore, len(orders), completed, len(windows)))
+	}
+
+	// Workers claim windows in order and only while the budget lasts, so the windows left unstarted are always the last.
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				if started == len(windows) || firstFail != nil || ctx.Err() != nil || budget.exhausted() != "" {
+					mu.Unlock()
+					return
+				}
+				i := started
+				started++
+				mu.Unlock()
+
+				fetchWindow(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstFail != nil {
+		return firstFail, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return mcp.NewToolResultErrorFromErr(ordersListOrdersSpec.Name+" cancelled", err), nil
+	}
+
+	merged := mergeOrdersWindows(results)
+	fetched := windows[:started]
+
+	summary := &pagingSummary{Items: len(merged)}
+	for _, window := range fetched {
+		summary.Pages += window.Pages
+		if window.Truncated && !summary.Truncated {
+			summary.Truncated = true
+			summary.StoppedBy = window.StoppedBy
+		}
+	}
+
+	result := ordersListOrdersResult{
+		Orders:        merged,
+		CreatedBefore: windows[len(windows)-1].CreatedBefore,
+		Paging:        summary,
+		Windows:       fetched,
+		RetrievedAt:   time.Now().UTC(),
+	}
+	if started < len(windows) {
+		result.ResumeCreatedAfter = windows[started].CreatedAfter
+		if !summary.Truncated {
+			summary.Truncated = true
+			summary.StoppedBy = budget.exhausted()
+		}
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d orders from %d windows across %d pages", len(merged), len(fetched), summary.Pages)
+	if summary.Truncated {
+		fallback = fmt.Sprintf("%s (stopped at %s; resume truncated windows with their nextToken", fallback, summary.StoppedBy)
+		if result.ResumeCreatedAfter != "" {
+			fallback = fmt.Sprintf("%s and the %d unfetched windows with createdAfter %s", fallback, len(windows)-started, result.ResumeCreatedAfter)
+		}
+		fallback += ")"
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// planOrdersWindows validates split mode arguments and divides the creation range into consecutive windows.
+// createdBefore defaults to two minutes ago, the latest value GetOrders accepts.
+func planOrdersWindows(args ordersListOrdersArgs, now time.Time) ([]ordersWindow, *mcp.CallToolResult) {
+	days := *args.SplitDays
+	if days <= 0 || days > maxOrdersSplitDays {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("splitDays must be between 1 and %d", maxOrdersSplitDays))
+	}
+	if strings.TrimSpace(args.NextToken) != "" {
+		return nil, mcp.NewToolResultError("splitDays cannot be combined with nextToken; resume a window with its own nextToken instead")
+	}
+	if strings.TrimSpace(args.LastUpdatedAfter) != "" || strings.TrimSpace(args.LastUpdatedBefore) != "" {
+		return nil, mcp.NewToolResultError("splitDays splits the createdAfter/createdBefore range and cannot be combined with lastUpdated filters")
+	}
+
+	rawAfter := strings.TrimSpace(args.CreatedAfter)
+	if rawAfter == "" {
+		return nil, mcp.NewToolResultError("createdAfter is required with splitDays")
+	}
+	after, err := time.Parse(time.RFC3339, rawAfter)
+	if err != nil {
+		return nil, mcp.NewToolResultError("createdAfter must be an ISO 8601 timestamp")
+	}
+
+	before := now.Add(-ordersCreatedBeforeMinDelay).Truncate(time.Second)
+	if rawBefore := strings.TrimSpace(args.CreatedBefore); rawBefore != "" {
+		if before, err = time.Parse(time.RFC3339, rawBefore); err != nil {
+			return nil, mcp.NewToolResultError("createdBefore must be an ISO 8601 timestamp")
+		}
+	}
+	if !before.After(after) {
+		return nil, mcp.NewToolResultError("createdBefore must be after createdAfter")
+	}
+
+	size := time.Duration(days) * 24 * time.Hour
+	var windows []ordersWindow
+	for start := after; start.Before(before); start = start.Add(size) {
+		end := start.Add(size)
+		if end.After(before) {
+			end = before
+		}
+		windows = append(windows, ordersWindow{
+			CreatedAfter:  start.UTC().Format(time.RFC3339),
+			CreatedBefore: end.UTC().Format(time.RFC3339),
+		})
+	}
+	return windows, nil
+}
+
+// mergeOrdersWindows de-duplicates orders by AmazonOrderId and sorts them by purchase date, then order ID, so the
+// result does not depend on which window finished first.
+func mergeOrdersWindows(results [][]ordersv0.Order) []ordersv0.Order {
+	seen := make(map[string]bool)
+	merged := make([]ordersv0.Order, 0)
+	for _, orders := range results {
+		for _, order := range orders {
+			if seen[order.AmazonOrderId] {
+				continue
+			}
+			seen[order.AmazonOrderId] = true
+			merged = append(merged, order)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		left, right := parseOrderTime(merged[i].PurchaseDate), parseOrderTime(merged[j].PurchaseDate)
+		if !left.Equal(right) {
+			return left.Before(right)
+		}
+		return merged[i].AmazonOrderId < merged[j].AmazonOrderId
+	})
+	return merged
+}
+
+func parseOrderTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestPlanOrdersWindows(t *testing.T) {
+	days := 7
+	args := ordersListOrdersArgs{
+		CreatedAfter:  "2026-01-01T00:00:00Z",
+		CreatedBefore: "2026-01-20T12:00:00Z",
+		SplitDays:     &days,
+	}
+
+	windows, failure := planOrdersWindows(args, time.Now())
+	if failure != nil {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+
+	want := [][2]string{
+		{"2026-01-01T00:00:00Z", "2026-01-08T00:00:00Z"},
+		{"2026-01-08T00:00:00Z", "2026-01-15T00:00:00Z"},
+		{"2026-01-15T00:00:00Z", "2026-01-20T12:00:00Z"},
+	}
+	if len(windows) != len(want) {
+		t.Fatalf("expected %d windows, got %+v", len(want), windows)
+	}
+	for i, window := range windows {
+		if window.CreatedAfter != want[i][0] || window.CreatedBefore != want[i][1] {
+			t.Fatalf("window %d: got %s to %s", i, window.CreatedAfter, window.CreatedBefore)
+		}
+	}
+
+	args.CreatedBefore = ""
+	now := time.Date(2026, 1, 3, 0, 0, 30, 0, time.UTC)
+	windows, _ = planOrdersWindows(args, now)
+	if last := windows[len(windows)-1].CreatedBefore; last != "2026-01-02T23:58:30Z" {
+		t.Fatalf("expected createdBefore to default to two minutes ago, got %s", last)
+	}
+
+	args.NextToken = "abc"
+	if _, failure := planOrdersWindows(args, now); failure == nil {
+		t.Fatalf("expected nextToken to be rejected in split mode")
+	}
+}
+
+func TestMergeOrdersWindows(t *testing.T) {
+	merged := mergeOrdersWindows([][]ordersv0.Order{
+		{
+			{AmazonOrderId: "B", PurchaseDate: "2026-01-08T00:00:00Z"},
+			{AmazonOrderId: "C", PurchaseDate: "2026-01-03T00:00:00Z"},
+		},
+		{
+			{AmazonOrderId: "B", PurchaseDate: "2026-01-08T00:00:00Z"},
+			{AmazonOrderId: "A", PurchaseDate: "2026-01-08T00:00:00Z"},
+		},
+	})
+
+	var ids []string
+	for _, order := range merged {
+		ids = append(ids, order.AmazonOrderId)
+	}
+	if len(ids) != 3 || ids[0] != "C" || ids[1] != "A" || ids[2] != "B" {
+		t.Fatalf("unexpected merge order %v", ids)
+	}
+}
+
+// windowPagesTransport answers GetOrders with three pages of one order per window.
+type windowPagesTransport struct {
+	mu       sync.Mutex
+	requests int
+}
+
+func (t *windowPagesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests++
+	t.mu.Unlock()
+
+	query := req.URL.Query()
+	window, page := query.Get("CreatedAfter"), 1
+	if token := query.Get("NextToken"); token != "" {
+		window, _ = strings.CutSuffix(token, "|2")
+		window, _ = strings.CutSuffix(window, "|3")
+		page = int(token[len(token)-1] - '0')
+	}
+	next := ""
+	if page < 3 {
+		next = fmt.Sprintf("%s|%d", window, page+1)
+	}
+	body, _ := json.Marshal(map[string]any{"payload": map[string]any{
+		"Orders":    []map[string]any{{"AmazonOrderId": fmt.Sprintf("%s-%d", window, page), "PurchaseDate": window, "LastUpdateDate": window, "OrderStatus": "Shipped"}},
+		"NextToken": next,
+	}})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestSplitListOrdersBudgetsTheWholeCall(t *testing.T) {
+	transport := &windowPagesTransport{}
+	tools, err := BuildAll(Dependencies{SellingPartner: cassetteClient{}, Transport: transport, EnabledDomains: []string{"orders"}})
+	if err != nil {
+		t.Fatalf("BuildAll: %v", err)
+	}
+
+	var result *mcp.CallToolResult
+	for _, tool := range tools {
+		if tool.Tool.Name != ordersListOrdersSpec.Name {
+			continue
+		}
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{
+			"marketplaceIds": []any{"ATVPDKIKX0DER"},
+			"createdAfter":   "2026-01-01T00:00:00Z",
+			"createdBefore":  "2026-01-11T00:00:00Z",
+			"splitDays":      1,
+			"concurrency":    1,
+			"maxPages":       4,
+		}
+		if result, err = tool.Handler(context.Background(), req); err != nil {
+			t.Fatalf("handler: %v", err)
+		}
+	}
+	if result == nil || result.IsError {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	listed := result.StructuredContent.(ordersListOrdersResult)
+	if transport.requests != 4 || listed.Paging.Pages != 4 || len(listed.Orders) != 4 {
+		t.Fatalf("maxPages should bound the whole call: %d requests, %+v, %d orders", transport.requests, listed.Paging, len(listed.Orders))
+	}
+	if len(listed.Windows) != 2 || listed.Windows[0].Truncated || !listed.Windows[1].Truncated || listed.Windows[1].NextToken == "" {
+		t.Fatalf("expected one complete and one truncated window, got %+v", listed.Windows)
+	}
+	if listed.ResumeCreatedAfter != "2026-01-03T00:00:00Z" || !listed.Paging.Truncated || listed.Paging.StoppedBy != "maxPages" {
+		t.Fatalf("expected the remaining windows to resume at 2026-01-03, got %q, %+v", listed.ResumeCreatedAfter, listed.Paging)
+	}
+}
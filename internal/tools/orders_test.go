@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// stubSellingPartner points the tools at a local test server and signs every request with a fixed access token.
+type stubSellingPartner struct {
+	endpoint string
+}
+
+func (c stubSellingPartner) AuthorizeRequest(req *http.Request) error {
+	req.Header.Set("X-Amz-Access-Token", "Atza|stub")
+	return nil
+}
+func (c stubSellingPartner) Endpoint() string                       { return c.endpoint }
+func (c stubSellingPartner) Status() spapi.Status                   { return spapi.Status{Ready: true} }
+func (c stubSellingPartner) CheckCredentials(context.Context) error { return nil }
+
+// newStubServer serves handler and returns a client pointed at it.
+func newStubServer(t *testing.T, handler http.HandlerFunc) stubSellingPartner {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return stubSellingPartner{endpoint: srv.URL}
+}
+
+func TestFetchAllOrderItemsFollowsNextToken(t *testing.T) {
+	spClient := newStubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("NextToken") == "items-2" {
+			w.Write([]byte(`{"payload":{"AmazonOrderId":"111-1","OrderItems":[{"ASIN":"B000000002","OrderItemId":"2","QuantityOrdered":1}]}}`))
+			return
+		}
+		w.Write([]byte(`{"payload":{"AmazonOrderId":"111-1","OrderItems":[{"ASIN":"B000000001","OrderItemId":"1","QuantityOrdered":1}],"NextToken":"items-2"}}`))
+	})
+
+	client, failure := ensureOrdersClient(spClient)
+	if failure != nil {
+		t.Fatalf("orders client: %+v", failure)
+	}
+	// The SDK dereferences the params, so the first page must not send nil.
+	items, err := fetchAllOrderItems(context.Background(), client, "111-1")
+	if err != nil {
+		t.Fatalf("fetchAllOrderItems: %v", err)
+	}
+	if len(items) != 2 || items[0].OrderItemId != "1" || items[1].OrderItemId != "2" {
+		t.Fatalf("expected both pages of items, got %+v", items)
+	}
+}
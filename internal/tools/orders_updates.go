@@ -0,0 +1,472 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const ordersBasePath = "/orders/v0/orders"
+
+type ordersShipmentItemArg struct {
+	OrderItemID       string   `json:"orderItemId"`
+	Quantity          int      `json:"quantity"`
+	TransparencyCodes []string `json:"transparencyCodes,omitempty"`
+}
+
+type ordersConfirmShipmentArgs struct {
+	AmazonOrderID          string                  `json:"amazonOrderId"`
+	MarketplaceID          string                  `json:"marketplaceId"`
+	PackageReferenceID     string                  `json:"packageReferenceId"`
+	CarrierCode            string                  `json:"carrierCode"`
+	CarrierName            string                  `json:"carrierName"`
+	ShippingMethod         string                  `json:"shippingMethod"`
+	TrackingNumber         string                  `json:"trackingNumber"`
+	ShipDate               string                  `json:"shipDate"`
+	ShipFromSupplySourceID string                  `json:"shipFromSupplySourceId"`
+	OrderItems             []ordersShipmentItemArg `json:"orderItems"`
+	CodCollectionMethod    string                  `json:"codCollectionMethod"`
+}
+
+type ordersUpdateShipmentStatusArgs struct {
+	AmazonOrderID  string                  `json:"amazonOrderId"`
+	MarketplaceID  string                  `json:"marketplaceId"`
+	ShipmentStatus string                  `json:"shipmentStatus"`
+	OrderItems     []ordersShipmentItemArg `json:"orderItems"`
+}
+
+type ordersUpdateVerificationStatusArgs struct {
+	AmazonOrderID       string         `json:"amazonOrderId"`
+	Status              string         `json:"status"`
+	ValidUntil          string         `json:"validUntil"`
+	RejectionReasonID   string         `json:"rejectionReasonId"`
+	VerificationDetails map[string]any `json:"verificationDetails"`
+}
+
+type ordersGetOrderItemsApprovalsArgs struct {
+	AmazonOrderID      string   `json:"amazonOrderId"`
+	MarketplaceID      string   `json:"marketplaceId"`
+	ItemApprovalTypes  []string `json:"itemApprovalTypes"`
+	ItemApprovalStatus []string `json:"itemApprovalStatus"`
+	NextToken          string   `json:"nextToken"`
+	pagingArgs
+}
+
+// ordersUpdateResult reports a successful update; the Orders API answers these operations with 204 No Content.
+type ordersUpdateResult struct {
+	AmazonOrderID string    `json:"amazonOrderId"`
+	Operation     string    `json:"operation"`
+	Status        int       `json:"status"`
+	RetrievedAt   time.Time `json:"retrievedAt"`
+}
+
+type ordersGetOrderRegulatedInfoResult struct {
+	AmazonOrderID string              `json:"amazonOrderId"`
+	RegulatedInfo ordersRegulatedInfo `json:"regulatedInfo"`
+	RetrievedAt   time.Time           `json:"retrievedAt"`
+}
+
+type ordersGetOrderItemsApprovalsResult struct {
+	AmazonOrderID string               `json:"amazonOrderId"`
+	Approvals     []ordersItemApproval `json:"approvals"`
+	NextToken     string               `json:"nextToken,omitempty"`
+	Paging        *pagingSummary       `json:"paging,omitempty"`
+	RetrievedAt   time.Time            `json:"retrievedAt"`
+}
+
+type ordersPackageDetailBody struct {
+	PackageReferenceID     string                  `json:"packageReferenceId"`
+	CarrierCode            string                  `json:"carrierCode"`
+	CarrierName            string                  `json:"carrierName,omitempty"`
+	ShippingMethod         string                  `json:"shippingMethod,omitempty"`
+	TrackingNumber         string                  `json:"trackingNumber"`
+	ShipDate               string                  `json:"shipDate"`
+	ShipFromSupplySourceID string                  `json:"shipFromSupplySourceId,omitempty"`
+	OrderItems             []ordersShipmentItemArg `json:"orderItems"`
+}
+
+type ordersConfirmShipmentBody struct {
+	MarketplaceID       string                  `json:"marketplaceId"`
+	CodCollectionMethod string                  `json:"codCollectionMethod,omitempty"`
+	PackageDetail       ordersPackageDetailBody `json:"packageDetail"`
+}
+
+type ordersUpdateShipmentStatusBody struct {
+	MarketplaceID  string                  `json:"marketplaceId"`
+	ShipmentStatus string                  `json:"shipmentStatus"`
+	OrderItems     []ordersShipmentItemArg `json:"orderItems,omitempty"`
+}
+
+type ordersUpdateVerificationStatusBody struct {
+	RegulatedOrderVerificationStatus ordersVerificationStatusBody `json:"regulatedOrderVerificationStatus"`
+}
+
+type ordersVerificationStatusBody struct {
+	Status              string         `json:"status"`
+	ValidUntil          string         `json:"validUntil,omitempty"`
+	RejectionReasonID   string         `json:"rejectionReasonId,omitempty"`
+	VerificationDetails map[string]any `json:"verificationDetails,omitempty"`
+}
+
+func newOrdersUpdatesTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	confirmShipmentHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersConfirmShipmentArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersConfirmShipment(ctx, args, spClient)
+	})
+
+	updateShipmentStatusHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersUpdateShipmentStatusArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersUpdateShipmentStatus(ctx, args, spClient)
+	})
+
+	getOrderRegulatedInfoHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersGetOrderArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersGetOrderRegulatedInfo(ctx, strings.TrimSpace(args.AmazonOrderID), spClient)
+	})
+
+	updateVerificationStatusHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args ordersUpdateVerificationStatusArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersUpdateVerificationStatus(ctx, args, spClient)
+	})
+
+	getOrderItemsApprovalsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args ordersGetOrderItemsApprovalsArgs) (*mcp.CallToolResult, error) {
+		return executeOrdersGetOrderItemsApprovals(withProgressToken(ctx, req), args, spClient)
+	})
+
+	return []registeredTool{
+		newWriteTool(ordersConfirmShipmentSpec, deps, confirmShipmentHandler),
+		newWriteTool(ordersUpdateShipmentStatusSpec, deps, updateShipmentStatusHandler),
+		toolFromSpec(ordersGetOrderRegulatedInfoSpec, getOrderRegulatedInfoHandler),
+		newWriteTool(ordersUpdateVerificationStatusSpec, deps, updateVerificationStatusHandler),
+		toolFromSpec(ordersGetOrderItemsApprovalsSpec, getOrderItemsApprovalsHandler),
+	}
+}
+
+func executeOrdersConfirmShipment(ctx context.Context, args ordersConfirmShipmentArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	orderID := strings.TrimSpace(args.AmazonOrderID)
+	spReq, failure := buildOrdersConfirmShipmentRequest(orderID, args, time.Now().UTC())
+	if failure != nil {
+		return failure, nil
+	}
+
+	return sendOrdersUpdate(ctx, spClient, "confirmShipment", orderID, spReq)
+}
+
+func executeOrdersUpdateShipmentStatus(ctx context.Context, args ordersUpdateShipmentStatusArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	orderID := strings.TrimSpace(args.AmazonOrderID)
+	if orderID == "" {
+		return mcp.NewToolResultError("amazonOrderId is required"), nil
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return mcp.NewToolResultError("marketplaceId is required"), nil
+	}
+
+	status := strings.TrimSpace(args.ShipmentStatus)
+	switch status {
+	case "ReadyForPickup", "PickedUp", "RefusedPickup":
+	default:
+		return mcp.NewToolResultError("shipmentStatus must be ReadyForPickup, PickedUp, or RefusedPickup"), nil
+	}
+
+	items, failure := normaliseOrdersShipmentItems(args.OrderItems, false)
+	if failure != nil {
+		return failure, nil
+	}
+
+	spReq := spRequest{
+		Method: http.MethodPost,
+		Path:   ordersBasePath + "/" + url.PathEscape(orderID) + "/shipment",
+		Body: ordersUpdateShipmentStatusBody{
+			MarketplaceID:  marketplaceID,
+			ShipmentStatus: status,
+			OrderItems:     items,
+		},
+	}
+
+	return sendOrdersUpdate(ctx, spClient, "updateShipmentStatus", orderID, spReq)
+}
+
+func executeOrdersUpdateVerificationStatus(ctx context.Context, args ordersUpdateVerificationStatusArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	orderID := strings.TrimSpace(args.AmazonOrderID)
+	if orderID == "" {
+		return mcp.NewToolResultError("amazonOrderId is required"), nil
+	}
+
+	status := strings.TrimSpace(args.Status)
+	rejectionReasonID := strings.TrimSpace(args.RejectionReasonID)
+	switch status {
+	case "Approved":
+		if rejectionReasonID != "" {
+			return mcp.NewToolResultError("rejectionReasonId is only allowed when status is Rejected"), nil
+		}
+	case "Rejected":
+		if rejectionReasonID == "" {
+			return mcp.NewToolResultError("rejectionReasonId is required when status is Rejected; see ValidRejectionReasons from orders.getOrderRegulatedInfo"), nil
+		}
+	default:
+		return mcp.NewToolResultError("status must be Approved or Rejected"), nil
+	}
+
+	validUntil := strings.TrimSpace(args.ValidUntil)
+	if validUntil != "" {
+		if _, err := time.Parse(time.RFC3339, validUntil); err != nil {
+			return mcp.NewToolResultError("validUntil must be an ISO 8601 timestamp"), nil
+		}
+	}
+
+	spReq := spRequest{
+		Method: http.MethodPatch,
+		Path:   ordersBasePath + "/" + url.PathEscape(orderID) + "/regulatedInfo",
+		Body: ordersUpdateVerificationStatusBody{
+			RegulatedOrderVerificationStatus: ordersVerificationStatusBody{
+				Status:              status,
+				ValidUntil:          validUntil,
+				RejectionReasonID:   rejectionReasonID,
+				VerificationDetails: args.VerificationDetails,
+			},
+		},
+	}
+
+	return sendOrdersUpdate(ctx, spClient, "updateVerificationStatus", orderID, spReq)
+}
+
+func executeOrdersGetOrderRegulatedInfo(ctx context.Context, orderID string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if orderID == "" {
+		return mcp.NewToolResultError("amazonOrderId is required"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   ordersBasePath + "/" + url.PathEscape(orderID) + "/regulatedInfo",
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("orders.getOrderRegulatedInfo request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeOrdersRegulatedInfo(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getOrderRegulatedInfo", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode orders.getOrderRegulatedInfo response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getOrderRegulatedInfo", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("orders.getOrderRegulatedInfo response payload is empty"), nil
+	}
+
+	result := ordersGetOrderRegulatedInfoResult{
+		AmazonOrderID: orderID,
+		RegulatedInfo: decoded.info,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Regulated info for order %s", orderID)
+	if status := result.RegulatedInfo.RegulatedOrderVerificationStatus; status != nil {
+		fallback = fmt.Sprintf("%s: verification %s", fallback, status.Status)
+		if status.RequiresMerchantAction {
+			fallback = fmt.Sprintf("%s, merchant action required", fallback)
+		}
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeOrdersGetOrderItemsApprovals(ctx context.Context, args ordersGetOrderItemsApprovalsArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	orderID := strings.TrimSpace(args.AmazonOrderID)
+	if orderID == "" {
+		return mcp.NewToolResultError("amazonOrderId is required"), nil
+	}
+
+	query := url.Values{}
+	setQueryValue(query, "marketplaceId", args.MarketplaceID)
+	if types := trimStringSlice(args.ItemApprovalTypes); len(types) > 0 {
+		query.Set("itemApprovalTypes", strings.Join(types, ","))
+	}
+	if statuses := trimStringSlice(args.ItemApprovalStatus); len(statuses) > 0 {
+		query.Set("itemApprovalStatus", strings.Join(statuses, ","))
+	}
+
+	fetch := func(ctx context.Context, token string) ([]ordersItemApproval, string, *mcp.CallToolResult) {
+		pageQuery := maps.Clone(query)
+		setQueryValue(pageQuery, "nextToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   ordersBasePath + "/" + url.PathEscape(orderID) + "/approvals",
+			Query:  pageQuery,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("orders.getOrderItemsApprovals request failed", err)
+		}
+
+		decoded, decodeErr := decodeOrdersItemsApprovals(body)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("getOrderItemsApprovals", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode orders.getOrderItemsApprovals response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("getOrderItemsApprovals", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("orders.getOrderItemsApprovals response payload is empty")
+		}
+
+		return decoded.approvals, decoded.nextToken, nil
+	}
+
+	approvals, next, paging, failure := collectPages(ctx, pagedOperation{tool: ordersGetOrderItemsApprovalsSpec.Name, operation: ordersGetOrderItemsApprovalsSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := ordersGetOrderItemsApprovalsResult{
+		AmazonOrderID: orderID,
+		Approvals:     approvals,
+		NextToken:     next,
+		Paging:        paging,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d item approvals for order %s", len(result.Approvals), orderID)
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// buildOrdersConfirmShipmentRequest validates the package details and builds the confirmShipment request. shipDate
+// defaults to now so a dry run shows the exact body that would be sent.
+func buildOrdersConfirmShipmentRequest(orderID string, args ordersConfirmShipmentArgs, now time.Time) (spRequest, *mcp.CallToolResult) {
+	if orderID == "" {
+		return spRequest{}, mcp.NewToolResultError("amazonOrderId is required")
+	}
+
+	marketplaceID := strings.TrimSpace(args.MarketplaceID)
+	if marketplaceID == "" {
+		return spRequest{}, mcp.NewToolResultError("marketplaceId is required")
+	}
+
+	packageReferenceID := strings.TrimSpace(args.PackageReferenceID)
+	if packageReferenceID == "" {
+		return spRequest{}, mcp.NewToolResultError("packageReferenceId is required")
+	}
+
+	carrierCode := strings.TrimSpace(args.CarrierCode)
+	if carrierCode == "" {
+		return spRequest{}, mcp.NewToolResultError("carrierCode is required")
+	}
+
+	carrierName := strings.TrimSpace(args.CarrierName)
+	if strings.EqualFold(carrierCode, "Other") && carrierName == "" {
+		return spRequest{}, mcp.NewToolResultError("carrierName is required when carrierCode is Other")
+	}
+
+	trackingNumber := strings.TrimSpace(args.TrackingNumber)
+	if trackingNumber == "" {
+		return spRequest{}, mcp.NewToolResultError("trackingNumber is required")
+	}
+
+	shipDate := strings.TrimSpace(args.ShipDate)
+	if shipDate == "" {
+		shipDate = now.Format(time.RFC3339)
+	} else if _, err := time.Parse(time.RFC3339, shipDate); err != nil {
+		return spRequest{}, mcp.NewToolResultError("shipDate must be an ISO 8601 timestamp")
+	}
+
+	items, failure := normaliseOrdersShipmentItems(args.OrderItems, true)
+	if failure != nil {
+		return spRequest{}, failure
+	}
+
+	return spRequest{
+		Method: http.MethodPost,
+		Path:   ordersBasePath + "/" + url.PathEscape(orderID) + "/shipmentConfirmation",
+		Body: ordersConfirmShipmentBody{
+			MarketplaceID:       marketplaceID,
+			CodCollectionMethod: strings.TrimSpace(args.CodCollectionMethod),
+			PackageDetail: ordersPackageDetailBody{
+				PackageReferenceID:     packageReferenceID,
+				CarrierCode:            carrierCode,
+				CarrierName:            carrierName,
+				ShippingMethod:         strings.TrimSpace(args.ShippingMethod),
+				TrackingNumber:         trackingNumber,
+				ShipDate:               shipDate,
+				ShipFromSupplySourceID: strings.TrimSpace(args.ShipFromSupplySourceID),
+				OrderItems:             items,
+			},
+		},
+	}, nil
+}
+
+func normaliseOrdersShipmentItems(items []ordersShipmentItemArg, required bool) ([]ordersShipmentItemArg, *mcp.CallToolResult) {
+	if required && len(items) == 0 {
+		return nil, mcp.NewToolResultError("orderItems must include at least one item")
+	}
+
+	normalised := make([]ordersShipmentItemArg, 0, len(items))
+	for i, item := range items {
+		item.OrderItemID = strings.TrimSpace(item.OrderItemID)
+		if item.OrderItemID == "" {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("orderItems[%d].orderItemId is required", i))
+		}
+		if item.Quantity <= 0 {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("orderItems[%d].quantity must be positive", i))
+		}
+		item.TransparencyCodes = trimStringSlice(item.TransparencyCodes)
+		normalised = append(normalised, item)
+	}
+	return normalised, nil
+}
+
+// sendOrdersUpdate sends an order update and treats an empty 2xx response as success.
+func sendOrdersUpdate(ctx context.Context, spClient spapi.Client, operation, orderID string, spReq spRequest) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("orders."+operation+" request failed", err), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse(operation, httpResp, body, decodeSellingPartnerErrors(body)); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := ordersUpdateResult{
+		AmazonOrderID: orderID,
+		Operation:     operation,
+		Status:        httpResp.StatusCode,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	return mcp.NewToolResultStructured(result, fmt.Sprintf("orders.%s succeeded for order %s", operation, orderID)), nil
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ordersRegulatedInfo mirrors the Orders API OrderRegulatedInfo model. The regulated fields vary by programme (for
+// example prescription details), so they are passed through as raw JSON.
+type ordersRegulatedInfo struct {
+	AmazonOrderID                    string                             `json:"AmazonOrderId"`
+	RequiresDosageLabel              bool                               `json:"RequiresDosageLabel"`
+	RegulatedInformation             json.RawMessage                    `json:"RegulatedInformation,omitempty"`
+	RegulatedOrderVerificationStatus *ordersRegulatedVerificationStatus `json:"RegulatedOrderVerificationStatus,omitempty"`
+}
+
+type ordersRegulatedVerificationStatus struct {
+	Status                 string                  `json:"Status"`
+	RequiresMerchantAction bool                    `json:"RequiresMerchantAction"`
+	ValidRejectionReasons  []ordersRejectionReason `json:"ValidRejectionReasons,omitempty"`
+	RejectionReason        *ordersRejectionReason  `json:"RejectionReason,omitempty"`
+	ReviewDate             string                  `json:"ReviewDate,omitempty"`
+	ExternalReviewerID     string                  `json:"ExternalReviewerId,omitempty"`
+}
+
+type ordersRejectionReason struct {
+	RejectionReasonID          string `json:"RejectionReasonId"`
+	RejectionReasonDescription string `json:"RejectionReasonDescription"`
+}
+
+// ordersItemApproval keeps the approval type and status typed for summaries and passes the rest through.
+type ordersItemApproval struct {
+	OrderItemID    string          `json:"orderItemId"`
+	ApprovalType   string          `json:"approvalType"`
+	ApprovalStatus string          `json:"approvalStatus"`
+	Details        json.RawMessage `json:"-"`
+}
+
+func (a *ordersItemApproval) UnmarshalJSON(data []byte) error {
+	type plain ordersItemApproval
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*a = ordersItemApproval(decoded)
+	a.Details = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (a ordersItemApproval) MarshalJSON() ([]byte, error) {
+	if len(a.Details) > 0 {
+		return a.Details, nil
+	}
+	type plain ordersItemApproval
+	return json.Marshal(plain(a))
+}
+
+type ordersRegulatedInfoDecoded struct {
+	info           ordersRegulatedInfo
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type ordersItemsApprovalsDecoded struct {
+	approvals      []ordersItemApproval
+	nextToken      string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+func decodeOrdersRegulatedInfo(body []byte) (ordersRegulatedInfoDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ordersRegulatedInfoDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto ordersRegulatedInfoResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return ordersRegulatedInfoDecoded{}, err
+	}
+
+	decoded := ordersRegulatedInfoDecoded{apiErrors: dto.Errors}
+
+	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		decoded.info = *dto.Payload
+	}
+
+	return decoded, nil
+}
+
+func decodeOrdersItemsApprovals(body []byte) (ordersItemsApprovalsDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ordersItemsApprovalsDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto ordersItemsApprovalsResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return ordersItemsApprovalsDecoded{}, err
+	}
+
+	decoded := ordersItemsApprovalsDecoded{apiErrors: dto.Errors}
+
+	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		decoded.approvals = dto.Payload.OrderItemsApprovals
+		decoded.nextToken = valueOrEmpty(dto.Payload.NextToken)
+	}
+
+	return decoded, nil
+}
+
+type ordersRegulatedInfoResponseDTO struct {
+	Errors  []spAPIError         `json:"errors,omitempty"`
+	Payload *ordersRegulatedInfo `json:"payload,omitempty"`
+}
+
+type ordersItemsApprovalsResponseDTO struct {
+	Errors  []spAPIError `json:"errors,omitempty"`
+	Payload *struct {
+		NextToken           *string              `json:"nextToken,omitempty"`
+		OrderItemsApprovals []ordersItemApproval `json:"orderItemsApprovals"`
+	} `json:"payload,omitempty"`
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBuildOrdersConfirmShipmentRequest(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	args := ordersConfirmShipmentArgs{
+		MarketplaceID:      "ATVPDKIKX0DER",
+		PackageReferenceID: "1",
+		CarrierCode:        "UPS",
+		TrackingNumber:     " 1Z999 ",
+		OrderItems:         []ordersShipmentItemArg{{OrderItemID: "111", Quantity: 2}},
+	}
+
+	spReq, failure := buildOrdersConfirmShipmentRequest("123-1234567-1234567", args, now)
+	if failure != nil {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if spReq.Path != "/orders/v0/orders/123-1234567-1234567/shipmentConfirmation" {
+		t.Fatalf("unexpected path %q", spReq.Path)
+	}
+	body, ok := spReq.Body.(ordersConfirmShipmentBody)
+	if !ok {
+		t.Fatalf("unexpected body type %T", spReq.Body)
+	}
+	if body.PackageDetail.ShipDate != "2026-03-04T05:06:07Z" || body.PackageDetail.TrackingNumber != "1Z999" {
+		t.Fatalf("unexpected package detail %+v", body.PackageDetail)
+	}
+
+	cases := map[string]func(*ordersConfirmShipmentArgs){
+		"carrierName is required": func(a *ordersConfirmShipmentArgs) { a.CarrierCode = "Other" },
+		"orderItems must include": func(a *ordersConfirmShipmentArgs) { a.OrderItems = nil },
+		"quantity must be positive": func(a *ordersConfirmShipmentArgs) {
+			a.OrderItems = []ordersShipmentItemArg{{OrderItemID: "111"}}
+		},
+		"shipDate must be": func(a *ordersConfirmShipmentArgs) { a.ShipDate = "yesterday" },
+	}
+	for want, mutate := range cases {
+		invalid := args
+		mutate(&invalid)
+		_, failure := buildOrdersConfirmShipmentRequest("123-1234567-1234567", invalid, now)
+		if failure == nil || !failure.IsError {
+			t.Fatalf("expected failure containing %q", want)
+		}
+		text := failure.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %q", want, text)
+		}
+	}
+}
+
+func TestOrdersUpdateVerificationStatusDryRunBypassesWriteGate(t *testing.T) {
+	for _, tool := range newOrdersUpdatesTools(Dependencies{SellingPartner: cassetteClient{}}) {
+		if tool.Tool.Name != ordersUpdateVerificationStatusSpec.Name {
+			continue
+		}
+
+		call := func(args map[string]any) *mcp.CallToolResult {
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = args
+			result, err := tool.Handler(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return result
+		}
+
+		live := call(map[string]any{"amazonOrderId": "123-1234567-1234567", "status": "Approved"})
+		if !live.IsError {
+			t.Fatalf("expected live call to be refused when writes are disabled")
+		}
+
+		preview := call(map[string]any{"amazonOrderId": "123-1234567-1234567", "status": "Approved", "dryRun": true})
+		if preview.IsError {
+			t.Fatalf("expected dry run to succeed, got %+v", preview.Content)
+		}
+		captured, ok := preview.StructuredContent.(writePreview)
+		if !ok {
+			t.Fatalf("expected write preview, got %T", preview.StructuredContent)
+		}
+		if captured.Method != http.MethodPatch || captured.Path != ordersBasePath+"/123-1234567-1234567/regulatedInfo" {
+			t.Fatalf("unexpected preview %+v", captured)
+		}
+
+		rejected := call(map[string]any{"amazonOrderId": "123-1234567-1234567", "status": "Rejected", "dryRun": true})
+		if !rejected.IsError {
+			t.Fatalf("expected rejection without a reason to fail validation")
+		}
+		return
+	}
+
+	t.Fatalf("%s not registered", ordersUpdateVerificationStatusSpec.Name)
+}
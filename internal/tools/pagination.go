@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultPagingMaxPages = 10
+	maxPagingMaxPages     = 100
+	defaultPagingMaxItems = 1000
+	maxPagingMaxItems     = 10000
+)
+
+// pagingArgs is embedded in the arguments of list tools that return a nextToken.
+type pagingArgs struct {
+	AllPages bool `json:"allPages"`
+	MaxItems *int `json:"maxItems"`
+	MaxPages *int `json:"maxPages"`
+
+	// budget, when set, is shared with other collectPages loops and stops this one once the call as a whole is over.
+	budget *pagingBudget
+}
+
+// pagingBudget shares maxItems and maxPages across several collectPages loops, such as the windows of a split
+// listOrders call, so the caps bound the whole call rather than each loop.
+type pagingBudget struct {
+	mu    sync.Mutex
+	items int
+	pages int
+}
+
+func newPagingBudget(paging pagingArgs) *pagingBudget {
+	return &pagingBudget{
+		items: sanitizePagingCap(paging.MaxItems, defaultPagingMaxItems, maxPagingMaxItems),
+		pages: sanitizePagingCap(paging.MaxPages, defaultPagingMaxPages, maxPagingMaxPages),
+	}
+}
+
+// spend records a fetched page of items and returns the cap it used up, if any.
+func (b *pagingBudget) spend(items int) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.items -= items
+	b.pages--
+	return b.stoppedBy()
+}
+
+// exhausted returns the cap that is already used up, if any.
+func (b *pagingBudget) exhausted() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stoppedBy()
+}
+
+func (b *pagingBudget) stoppedBy() string {
+	switch {
+	case b.items <= 0:
+		return "maxItems"
+	case b.pages <= 0:
+		return "maxPages"
+	}
+	return ""
+}
+
+// pagingSummary tells the client how much an allPages call fetched and whether a cap stopped it early.
+type pagingSummary struct {
+	Pages     int    `json:"pages"`
+	Items     int    `json:"items"`
+	Truncated bool   `json:"truncated"`
+	StoppedBy string `json:"stoppedBy,omitempty"`
+}
+
+// pagedOperation names a list tool and the SP-API operation whose rate limit each page consumes.
+type pagedOperation struct {
+	tool      string
+	operation string
+}
+
+// pageFetcher requests one page. An empty nextToken requests the first page; a non-nil result is returned to the
+// client as the tool's failure.
+type pageFetcher[T any] func(ctx context.Context, nextToken string) ([]T, string, *mcp.CallToolResult)
+
+// withPagingOptions appends the allPages, maxItems and maxPages arguments to a list tool's options.
+func withPagingOptions(options ...mcp.ToolOption) []mcp.ToolOption {
+	return append(options,
+		mcp.WithBoolean("allPages", mcp.Description("Follow nextToken until every page is fetched or a cap is reached.")),
+		mcp.WithNumber("maxItems", mcp.Description(fmt.Sprintf("With allPages, stop once this many items are collected (default %d, max %d). The last page is kept whole so nextToken resumes exactly.", defaultPagingMaxItems, maxPagingMaxItems))),
+		mcp.WithNumber("maxPages", mcp.Description(fmt.Sprintf("With allPages, stop after this many pages (default %d, max %d).", defaultPagingMaxPages, maxPagingMaxPages))),
+	)
+}
+
+// collectPages fetches a single page, or with allPages keeps following nextToken until the caps are reached. Every
+// request waits on the operation's rate limiter, and clients that sent a progress token are told about each page.
+// The summary is nil unless allPages was requested.
+func collectPages[T any](ctx context.Context, op pagedOperation, paging pagingArgs, nextToken string, fetch pageFetcher[T]) ([]T, string, *pagingSummary, *mcp.CallToolResult) {
+	if !paging.AllPages {
+		if paging.MaxItems != nil || paging.MaxPages != nil {
+			return nil, "", nil, mcp.NewToolResultError("maxItems and maxPages require allPages")
+		}
+		if _, err := waitForOperation(ctx, op.operation); err != nil {
+			return nil, "", nil, mcp.NewToolResultErrorFromErr(op.tool+" cancelled while waiting for rate limit", err)
+		}
+		items, next, failure := fetch(ctx, nextToken)
+		return items, next, nil, failure
+	}
+
+	maxItems := sanitizePagingCap(paging.MaxItems, defaultPagingMaxItems, maxPagingMaxItems)
+	maxPages := sanitizePagingCap(paging.MaxPages, defaultPagingMaxPages, maxPagingMaxPages)
+
+	var all []T
+	summary := &pagingSummary{}
+	for {
+		if paging.budget != nil && summary.Pages > 0 {
+			if stoppedBy := paging.budget.exhausted(); stoppedBy != "" {
+				summary.Truncated = true
+				summary.StoppedBy = stoppedBy
+				return all, nextToken, summary, nil
+			}
+		}
+
+		waited, err := waitForOperation(ctx, op.operation)
+		if err != nil {
+			return nil, "", nil, mcp.NewToolResultErrorFromErr(fmt.Sprintf("%s cancelled after %d pages while waiting for rate limit", op.tool, summary.Pages), err)
+		}
+
+		items, next, failure := fetch(ctx, nextToken)
+		if failure != nil {
+			return nil, "", nil, failure
+		}
+
+		all = append(all, items...)
+		nextToken = next
+		summary.Pages++
+		summary.Items = len(all)
+
+		reportPagingProgress(ctx, op.tool, summary, waited)
+
+		var budgetStop string
+		if paging.budget != nil {
+			budgetStop = paging.budget.spend(len(items))
+		}
+		if nextToken == "" {
+			return all, "", summary, nil
+		}
+		if budgetStop != "" {
+			summary.Truncated = true
+			summary.StoppedBy = budgetStop
+			return all, nextToken, summary, nil
+		}
+		if summary.Items >= maxItems {
+			summary.Truncated = true
+			summary.StoppedBy = "maxItems"
+			return all, nextToken, summary, nil
+		}
+		if summary.Pages >= maxPages {
+			summary.Truncated = true
+			summary.StoppedBy = "maxPages"
+			return all, nextToken, summary, nil
+		}
+	}
+}
+
+func sanitizePagingCap(value *int, fallback, limit int) int {
+	if value == nil || *value <= 0 {
+		return fallback
+	}
+	if *value > limit {
+		return limit
+	}
+	return *value
+}
+
+// pagingFallback extends a list tool's text summary with the allPages outcome.
+func pagingFallback(fallback string, summary *pagingSummary) string {
+	if summary == nil {
+		return fallback
+	}
+	fallback = fmt.Sprintf("%s across %d pages", fallback, summary.Pages)
+	if summary.Truncated {
+		fallback = fmt.Sprintf("%s (stopped at %s, more available via nextToken)", fallback, summary.StoppedBy)
+	}
+	return fallback
+}
+
+type progressTokenKey struct{}
+
+// withProgressToken keeps the request's progress token on the context so paging loops deep in a tool can report to it.
+func withProgressToken(ctx context.Context, req mcp.CallToolRequest) context.Context {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressTokenKey{}, req.Params.Meta.ProgressToken)
+}
+
+func reportPagingProgress(ctx context.Context, tool string, summary *pagingSummary, waited time.Duration) {
+	message := fmt.Sprintf("%s: fetched page %d (%d items)", tool, summary.Pages, summary.Items)
+	if waited > 0 {
+		message = fmt.Sprintf("%s after waiting %s for the rate limit", message, waited.Round(time.Second))
+	}
+	reportProgress(ctx, tool, summary.Pages, 0, message)
+}
+
+// reportProgress sends a progress notification when the client asked for them. A zero total means the total is unknown.
+func reportProgress(ctx context.Context, tool string, progress, total int, message string) {
+	token := ctx.Value(progressTokenKey{})
+	if token == nil {
+		return
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": token,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+
+	if err := srv.SendNotificationToClient(ctx, "notifications/progress", params); err != nil {
+		log.Printf("[WARN] %s: progress notification failed: %v", tool, err)
+	}
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakePages serves pages of two items each; the token for page n is "n".
+func fakePages(total int, calls *int) pageFetcher[int] {
+	return func(_ context.Context, token string) ([]int, string, *mcp.CallToolResult) {
+		*calls++
+		page := 0
+		if token != "" {
+			fmt.Sscanf(token, "%d", &page)
+		}
+		next := ""
+		if page+1 < total {
+			next = fmt.Sprintf("%d", page+1)
+		}
+		return []int{page * 2, page*2 + 1}, next, nil
+	}
+}
+
+func TestCollectPagesSinglePage(t *testing.T) {
+	var calls int
+	items, next, summary, failure := collectPages(context.Background(), pagedOperation{tool: "test.list"}, pagingArgs{}, "", fakePages(3, &calls))
+	if failure != nil {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if calls != 1 || len(items) != 2 || next != "1" || summary != nil {
+		t.Fatalf("unexpected single page: calls=%d items=%v next=%q summary=%+v", calls, items, next, summary)
+	}
+}
+
+func TestCollectPagesAllPages(t *testing.T) {
+	var calls int
+	items, next, summary, failure := collectPages(context.Background(), pagedOperation{tool: "test.list"}, pagingArgs{AllPages: true}, "", fakePages(3, &calls))
+	if failure != nil {
+		t.Fatalf("unexpected failure: %+v", failure)
+	}
+	if len(items) != 6 || next != "" || summary.Pages != 3 || summary.Truncated {
+		t.Fatalf("unexpected result: items=%v next=%q summary=%+v", items, next, summary)
+	}
+}
+
+func TestCollectPagesCaps(t *testing.T) {
+	maxPages, maxItems := 2, 3
+
+	var calls int
+	_, next, summary, _ := collectPages(context.Background(), pagedOperation{tool: "test.list"}, pagingArgs{AllPages: true, MaxPages: &maxPages}, "", fakePages(5, &calls))
+	if !summary.Truncated || summary.StoppedBy != "maxPages" || summary.Pages != 2 || next != "2" {
+		t.Fatalf("expected maxPages truncation, got next=%q summary=%+v", next, summary)
+	}
+
+	calls = 0
+	items, next, summary, _ := collectPages(context.Background(), pagedOperation{tool: "test.list"}, pagingArgs{AllPages: true, MaxItems: &maxItems}, "", fakePages(5, &calls))
+	if !summary.Truncated || summary.StoppedBy != "maxItems" || len(items) != 4 || next != "2" {
+		t.Fatalf("expected maxItems truncation keeping whole pages, got items=%v next=%q summary=%+v", items, next, summary)
+	}
+
+	_, _, _, failure := collectPages(context.Background(), pagedOperation{tool: "test.list"}, pagingArgs{MaxItems: &maxItems}, "", fakePages(5, &calls))
+	if failure == nil || !failure.IsError {
+		t.Fatalf("expected maxItems without allPages to fail")
+	}
+}
+
+func TestOperationLimiterReserve(t *testing.T) {
+	limiter := newOperationLimiter(rateLimit{rate: 0.5, burst: 2})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if delay := limiter.reserve(start); delay != 0 {
+		t.Fatalf("expected burst to allow first request, got %s", delay)
+	}
+	if delay := limiter.reserve(start); delay != 0 {
+		t.Fatalf("expected burst to allow second request, got %s", delay)
+	}
+	if delay := limiter.reserve(start); delay != 2*time.Second {
+		t.Fatalf("expected third request to wait 2s, got %s", delay)
+	}
+	if delay := limiter.reserve(start.Add(10 * time.Second)); delay != 0 {
+		t.Fatalf("expected bucket to refill, got %s", delay)
+	}
+}
@@ -2,16 +2,18 @@ package tools
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/amzapi/selling-partner-api-sdk/productPricing"
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
 )
 
-func newProductPricingTools(deps Dependencies) []server.ServerTool {
+func newProductPricingTools(deps Dependencies) []registeredTool {
 	spClient := deps.SellingPartner
 	
 	getPricingHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args productPricingGetPricingArgs) (*mcp.CallToolResult, error) {
@@ -22,9 +24,9 @@ func newProductPricingTools(deps Dependencies) []server.ServerTool {
 		return executeProductPricingGetCompetitivePricing(ctx, args, spClient)
 	})
 	
-	return []server.ServerTool{
-		serverToolFromSpec(productPricingGetPricingSpec, getPricingHandler),
-		serverToolFromSpec(productPricingGetCompetitivePricingSpec, getCompetitivePricingHandler),
+	return []registeredTool{
+		toolFromSpec(productPricingGetPricingSpec, getPricingHandler),
+		toolFromSpec(productPricingGetCompetitivePricingSpec, getCompetitivePricingHandler),
 	}
 }
 
@@ -163,10 +165,32 @@ func executeProductPricingGetCompetitivePricing(ctx context.Context, args produc
 }
 
 func ensureProductPricingClient(spClient spapi.Client) (*productPricing.Client, *mcp.CallToolResult) {
-	endpoint := spClient.Endpoint()
-	client, err := productPricing.NewClient(endpoint)
-	if err != nil {
-		return nil, mcp.NewToolResultErrorFromErr("Failed to create Product Pricing client", err)
+	if spClient == nil {
+		return nil, mcp.NewToolResultError("Selling Partner API client is not initialised")
+	}
+
+	if status := spClient.Status(); !status.Ready {
+		message := strings.TrimSpace(status.Message)
+		if message == "" {
+			message = "Selling Partner API client is not ready"
+		}
+		return nil, mcp.NewToolResultError(message)
+	}
+
+	return &productPricing.Client{
+		Endpoint:      spClient.Endpoint(),
+		Client:        newSellingPartnerHTTPClient(),
+		RequestBefore: buildProductPricingRequestBefore(spClient),
+	}, nil
+}
+
+func buildProductPricingRequestBefore(spClient spapi.Client) productPricing.RequestBeforeFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Amzn-Requestid", uuid.NewString())
+		req.Header.Set("Accept", "application/json")
+		if err := spClient.AuthorizeRequest(req); err != nil {
+			return fmt.Errorf("authorize request: %w", err)
+		}
+		return nil
 	}
-	return client, nil
 }
\ No newline at end of file
@@ -104,8 +104,12 @@ func decodeProductPricingBody(body []byte) (productPricingDecoded, error) {
 
 	if dto.Payload != nil {
 		decoded.payloadPresent = true
+		// GetPricing and GetCompetitivePricing return one entry per requested ASIN or SKU.
+		var entries []interface{}
 		var payload map[string]interface{}
-		if err := json.Unmarshal(*dto.Payload, &payload); err == nil {
+		if err := json.Unmarshal(*dto.Payload, &entries); err == nil {
+			decoded.pricing = entries
+		} else if err := json.Unmarshal(*dto.Payload, &payload); err == nil {
 			// Extract pricing items from various possible array fields
 			if items, ok := payload["offers"].([]interface{}); ok {
 				decoded.pricing = items
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestProductPricingRequestsAreAuthorized(t *testing.T) {
+	var tokens []string
+	spClient := newStubServer(t, func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("X-Amz-Access-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"payload":[{"status":"Success","ASIN":"B00V5DG6IQ"}]}`))
+	})
+	ctx := context.Background()
+
+	result, err := executeProductPricingGetPricing(ctx, productPricingGetPricingArgs{MarketplaceID: "ATVPDKIKX0DER", ItemType: "Asin", Asins: []string{"B00V5DG6IQ"}}, spClient)
+	if err != nil || result.IsError {
+		t.Fatalf("getPricing: %+v (%v)", result, err)
+	}
+	if output, ok := result.StructuredContent.(productPricingGetPricingResult); !ok || output.ItemCount != 1 {
+		t.Fatalf("the array payload should decode to one entry, got %+v", result.StructuredContent)
+	}
+	result, err = executeProductPricingGetCompetitivePricing(ctx, productPricingGetCompetitivePricingArgs{MarketplaceID: "ATVPDKIKX0DER", ItemType: "Asin", Asins: []string{"B00V5DG6IQ"}}, spClient)
+	if err != nil || result.IsError {
+		t.Fatalf("getCompetitivePricing: %+v (%v)", result, err)
+	}
+
+	if len(tokens) != 2 || tokens[0] != "Atza|stub" || tokens[1] != "Atza|stub" {
+		t.Fatalf("every pricing request should carry the access token, got %q", tokens)
+	}
+
+	if result, _ := executeProductPricingGetPricing(ctx, productPricingGetPricingArgs{}, nil); result == nil || !result.IsError {
+		t.Fatalf("expected a tool error without a client, got %+v", result)
+	}
+}
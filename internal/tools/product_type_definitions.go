@@ -0,0 +1,452 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/producttypes"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	productTypeDefinitionsBasePath     = "/definitions/2020-09-01/productTypes"
+	defaultProductTypeRequirements     = "LISTING"
+	defaultProductTypeRequirementsMode = "ENFORCED"
+	defaultProductTypeLocale           = "DEFAULT"
+	defaultProductTypeVersion          = "LATEST"
+)
+
+type productTypeSearchArgs struct {
+	Keywords       []string `json:"keywords"`
+	ItemName       string   `json:"itemName"`
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	Locale         string   `json:"locale"`
+	SearchLocale   string   `json:"searchLocale"`
+}
+
+type productTypeSearchResult struct {
+	MarketplaceIDs     []string             `json:"marketplaceIds"`
+	ProductTypes       []productTypeSummary `json:"productTypes"`
+	ProductTypeVersion string               `json:"productTypeVersion,omitempty"`
+	RetrievedAt        time.Time            `json:"retrievedAt"`
+}
+
+// productTypeDefinitionArgs identifies one product type schema. The same fields key the local schema cache.
+type productTypeDefinitionArgs struct {
+	ProductType          string   `json:"productType"`
+	MarketplaceIDs       []string `json:"marketplaceIds"`
+	SellerID             string   `json:"sellerId"`
+	ProductTypeVersion   string   `json:"productTypeVersion"`
+	Requirements         string   `json:"requirements"`
+	RequirementsEnforced string   `json:"requirementsEnforced"`
+	Locale               string   `json:"locale"`
+}
+
+type productTypeGetDefinitionArgs struct {
+	productTypeDefinitionArgs
+	Properties []string `json:"properties"`
+}
+
+type productTypeGetDefinitionResult struct {
+	productTypeDefinition
+	RequiredProperties []string                   `json:"requiredProperties"`
+	PropertyCount      int                        `json:"propertyCount"`
+	PropertySchemas    map[string]json.RawMessage `json:"propertySchemas,omitempty"`
+	UnknownProperties  []string                   `json:"unknownProperties,omitempty"`
+	SchemaChecksum     string                     `json:"schemaChecksum"`
+	SchemaCached       bool                       `json:"schemaCached"`
+	RetrievedAt        time.Time                  `json:"retrievedAt"`
+}
+
+type productTypeValidateArgs struct {
+	productTypeDefinitionArgs
+	Attributes map[string]any `json:"attributes"`
+}
+
+type productTypeValidateResult struct {
+	ProductType        string               `json:"productType"`
+	MarketplaceIDs     []string             `json:"marketplaceIds"`
+	ProductTypeVersion string               `json:"productTypeVersion"`
+	Requirements       string               `json:"requirements"`
+	Valid              bool                 `json:"valid"`
+	Issues             []producttypes.Issue `json:"issues"`
+	SchemaCached       bool                 `json:"schemaCached"`
+	RetrievedAt        time.Time            `json:"retrievedAt"`
+}
+
+func newProductTypeDefinitionsTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+	cache := producttypes.NewCache(producttypes.DefaultCacheTTL)
+
+	searchHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args productTypeSearchArgs) (*mcp.CallToolResult, error) {
+		return executeProductTypeSearch(ctx, args, spClient)
+	})
+
+	getHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args productTypeGetDefinitionArgs) (*mcp.CallToolResult, error) {
+		return executeProductTypeGetDefinition(ctx, args, spClient, cache)
+	})
+
+	validateHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args productTypeValidateArgs) (*mcp.CallToolResult, error) {
+		return executeProductTypeValidateAttributes(ctx, args, spClient, cache)
+	})
+
+	return []registeredTool{
+		toolFromSpec(productTypeSearchSpec, searchHandler),
+		toolFromSpec(productTypeGetDefinitionSpec, getHandler),
+		toolFromSpec(productTypeValidateAttributesSpec, validateHandler),
+	}
+}
+
+func executeProductTypeSearch(ctx context.Context, args productTypeSearchArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	marketplaces := trimStringSlice(args.MarketplaceIDs)
+	if len(marketplaces) == 0 {
+		return mcp.NewToolResultError("marketplaceIds must include at least one marketplace"), nil
+	}
+
+	keywords := trimStringSlice(args.Keywords)
+	itemName := strings.TrimSpace(args.ItemName)
+	if len(keywords) > 0 && itemName != "" {
+		return mcp.NewToolResultError("provide either keywords or itemName, not both"), nil
+	}
+
+	query := url.Values{"marketplaceIds": {strings.Join(marketplaces, ",")}}
+	if len(keywords) > 0 {
+		query.Set("keywords", strings.Join(keywords, ","))
+	}
+	if itemName != "" {
+		query.Set("itemName", itemName)
+	}
+	if locale := strings.TrimSpace(args.Locale); locale != "" {
+		query.Set("locale", locale)
+	}
+	if searchLocale := strings.TrimSpace(args.SearchLocale); searchLocale != "" {
+		query.Set("searchLocale", searchLocale)
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   productTypeDefinitionsBasePath,
+		Query:  query,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("productTypeDefinitions.searchDefinitionsProductTypes request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeProductTypeSearch(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("searchDefinitionsProductTypes", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode productTypeDefinitions.searchDefinitionsProductTypes response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("searchDefinitionsProductTypes", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("productTypeDefinitions.searchDefinitionsProductTypes response payload is empty"), nil
+	}
+
+	result := productTypeSearchResult{
+		MarketplaceIDs:     marketplaces,
+		ProductTypes:       decoded.productTypes,
+		ProductTypeVersion: decoded.version,
+		RetrievedAt:        time.Now().UTC(),
+	}
+
+	names := make([]string, 0, len(result.ProductTypes))
+	for _, productType := range result.ProductTypes {
+		names = append(names, productType.Name)
+	}
+	fallback := fmt.Sprintf("Found %d product types", len(names))
+	if len(names) > 0 {
+		fallback = fmt.Sprintf("%s: %s", fallback, strings.Join(names, ", "))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeProductTypeGetDefinition(ctx context.Context, args productTypeGetDefinitionArgs, spClient spapi.Client, cache *producttypes.Cache) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	params, failure := normaliseProductTypeDefinitionArgs(args.productTypeDefinitionArgs)
+	if failure != nil {
+		return failure, nil
+	}
+
+	definition, err := getProductTypeDefinition(ctx, spClient, params)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	entry, cached, err := loadProductTypeSchema(ctx, cache, params, definition)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := productTypeGetDefinitionResult{
+		productTypeDefinition: definition,
+		RequiredProperties:    entry.Validator.Required(),
+		PropertyCount:         len(entry.Validator.PropertyNames()),
+		SchemaChecksum:        entry.Checksum,
+		SchemaCached:          cached,
+		RetrievedAt:           time.Now().UTC(),
+	}
+
+	for _, name := range trimStringSlice(args.Properties) {
+		fragment, ok := entry.Validator.Property(name)
+		if !ok {
+			result.UnknownProperties = append(result.UnknownProperties, name)
+			continue
+		}
+		if result.PropertySchemas == nil {
+			result.PropertySchemas = make(map[string]json.RawMessage)
+		}
+		result.PropertySchemas[name] = fragment
+	}
+
+	fallback := fmt.Sprintf("Product type %s (version %s) defines %d attributes, %d required", definition.ProductType, definition.ProductTypeVersion.Version, result.PropertyCount, len(result.RequiredProperties))
+	if len(result.UnknownProperties) > 0 {
+		fallback = fmt.Sprintf("%s; unknown attributes: %s", fallback, strings.Join(result.UnknownProperties, ", "))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeProductTypeValidateAttributes(ctx context.Context, args productTypeValidateArgs, spClient spapi.Client, cache *producttypes.Cache) (*mcp.CallToolResult, error) {
+	params, failure := normaliseProductTypeDefinitionArgs(args.productTypeDefinitionArgs)
+	if failure != nil {
+		return failure, nil
+	}
+
+	if len(args.Attributes) == 0 {
+		return mcp.NewToolResultError("attributes is required"), nil
+	}
+
+	// A cached schema lets validation run offline; only go to SP-API when the cache is cold.
+	entry, cached := cache.Get(productTypeCacheKey(params))
+	if !cached {
+		if failure := ensureSellingPartnerClient(spClient); failure != nil {
+			return failure, nil
+		}
+
+		definition, err := getProductTypeDefinition(ctx, spClient, params)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		entry, cached, err = loadProductTypeSchema(ctx, cache, params, definition)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	issues, err := entry.Validator.Validate(args.Attributes)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to validate attributes", err), nil
+	}
+	if issues == nil {
+		issues = []producttypes.Issue{}
+	}
+
+	result := productTypeValidateResult{
+		ProductType:        params.ProductType,
+		MarketplaceIDs:     params.MarketplaceIDs,
+		ProductTypeVersion: entry.Version,
+		Requirements:       params.Requirements,
+		Valid:              len(issues) == 0,
+		Issues:             issues,
+		SchemaCached:       cached,
+		RetrievedAt:        time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Attributes are valid for product type %s", params.ProductType)
+	if !result.Valid {
+		segments := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			segments = append(segments, fmt.Sprintf("%s: %s", issue.Path, issue.Message))
+		}
+		fallback = fmt.Sprintf("Attributes have %d issue(s) for product type %s: %s", len(issues), params.ProductType, strings.Join(segments, "; "))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func normaliseProductTypeDefinitionArgs(args productTypeDefinitionArgs) (productTypeDefinitionArgs, *mcp.CallToolResult) {
+	params := productTypeDefinitionArgs{
+		ProductType:          strings.ToUpper(strings.TrimSpace(args.ProductType)),
+		MarketplaceIDs:       trimStringSlice(args.MarketplaceIDs),
+		SellerID:             strings.TrimSpace(args.SellerID),
+		ProductTypeVersion:   strings.TrimSpace(args.ProductTypeVersion),
+		Requirements:         strings.ToUpper(strings.TrimSpace(args.Requirements)),
+		RequirementsEnforced: strings.ToUpper(strings.TrimSpace(args.RequirementsEnforced)),
+		Locale:               strings.TrimSpace(args.Locale),
+	}
+
+	if params.ProductType == "" {
+		return params, mcp.NewToolResultError("productType is required")
+	}
+	if len(params.MarketplaceIDs) == 0 {
+		return params, mcp.NewToolResultError("marketplaceIds must include at least one marketplace")
+	}
+
+	if params.ProductTypeVersion == "" {
+		params.ProductTypeVersion = defaultProductTypeVersion
+	}
+	if params.Requirements == "" {
+		params.Requirements = defaultProductTypeRequirements
+	}
+	if params.RequirementsEnforced == "" {
+		params.RequirementsEnforced = defaultProductTypeRequirementsMode
+	}
+	if params.Locale == "" {
+		params.Locale = defaultProductTypeLocale
+	}
+
+	return params, nil
+}
+
+func productTypeCacheKey(params productTypeDefinitionArgs) string {
+	return strings.Join([]string{
+		params.ProductType,
+		strings.Join(params.MarketplaceIDs, ","),
+		params.SellerID,
+		params.ProductTypeVersion,
+		params.Requirements,
+		params.RequirementsEnforced,
+		params.Locale,
+	}, "|")
+}
+
+func getProductTypeDefinition(ctx context.Context, spClient spapi.Client, params productTypeDefinitionArgs) (productTypeDefinition, error) {
+	query := url.Values{
+		"marketplaceIds":       {strings.Join(params.MarketplaceIDs, ",")},
+		"productTypeVersion":   {params.ProductTypeVersion},
+		"requirements":         {params.Requirements},
+		"requirementsEnforced": {params.RequirementsEnforced},
+		"locale":               {params.Locale},
+	}
+	if params.SellerID != "" {
+		query.Set("sellerId", params.SellerID)
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   productTypeDefinitionsBasePath + "/" + url.PathEscape(params.ProductType),
+		Query:  query,
+	})
+	if err != nil {
+		return productTypeDefinition{}, fmt.Errorf("productTypeDefinitions.getDefinitionsProductType request failed: %w", err)
+	}
+
+	decoded, decodeErr := decodeProductTypeDefinition(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getDefinitionsProductType", httpResp, body, nil); err != nil {
+			return productTypeDefinition{}, err
+		}
+		return productTypeDefinition{}, fmt.Errorf("failed to decode productTypeDefinitions.getDefinitionsProductType response: %w", decodeErr)
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getDefinitionsProductType", httpResp, body, decoded.apiErrors); err != nil {
+		return productTypeDefinition{}, err
+	}
+
+	if !decoded.payloadPresent {
+		return productTypeDefinition{}, fmt.Errorf("productTypeDefinitions.getDefinitionsProductType response payload is empty")
+	}
+
+	if decoded.definition.Schema.Resource == "" || decoded.definition.MetaSchema.Resource == "" {
+		return productTypeDefinition{}, fmt.Errorf("product type %s response did not include schema links", params.ProductType)
+	}
+
+	return decoded.definition, nil
+}
+
+// loadProductTypeSchema returns the compiled schema for a definition, downloading it only when no cached copy with the
+// same checksum exists. The boolean reports whether the schema came from the cache.
+func loadProductTypeSchema(ctx context.Context, cache *producttypes.Cache, params productTypeDefinitionArgs, definition productTypeDefinition) (producttypes.Entry, bool, error) {
+	key := productTypeCacheKey(params)
+
+	if entry, ok := cache.Get(key); ok && entry.Checksum == definition.Schema.Checksum {
+		return entry, true, nil
+	}
+	if entry, ok := cache.GetByChecksum(definition.Schema.Checksum); ok {
+		entry.Version = definition.ProductTypeVersion.Version
+		cache.Put(key, entry)
+		return entry, true, nil
+	}
+
+	schemaDoc, err := downloadDocument(ctx, definition.Schema.Resource)
+	if err != nil {
+		return producttypes.Entry{}, false, fmt.Errorf("failed to download %s schema: %w", definition.ProductType, err)
+	}
+
+	metaSchemaDoc, err := downloadDocument(ctx, definition.MetaSchema.Resource)
+	if err != nil {
+		return producttypes.Entry{}, false, fmt.Errorf("failed to download product type meta-schema: %w", err)
+	}
+
+	schemaURL, metaSchemaURL, err := productTypeSchemaIDs(schemaDoc, metaSchemaDoc)
+	if err != nil {
+		return producttypes.Entry{}, false, err
+	}
+
+	validator, err := producttypes.Compile(schemaURL, schemaDoc, metaSchemaURL, metaSchemaDoc)
+	if err != nil {
+		return producttypes.Entry{}, false, fmt.Errorf("failed to compile %s schema: %w", definition.ProductType, err)
+	}
+
+	entry := producttypes.Entry{
+		Validator: validator,
+		Checksum:  definition.Schema.Checksum,
+		Version:   definition.ProductTypeVersion.Version,
+	}
+	cache.Put(key, entry)
+
+	return entry, false, nil
+}
+
+// productTypeSchemaIDs reads the $id of the schema and meta-schema. The download links are pre-signed S3 URLs, so the
+// compiler must resolve the schema's $schema reference by the meta-schema's own identifier.
+func productTypeSchemaIDs(schemaDoc, metaSchemaDoc []byte) (string, string, error) {
+	var schemaHeader, metaHeader struct {
+		ID     string `json:"$id"`
+		Schema string `json:"$schema"`
+	}
+
+	if err := json.Unmarshal(schemaDoc, &schemaHeader); err != nil {
+		return "", "", fmt.Errorf("product type schema is not valid JSON: %w", err)
+	}
+	if err := json.Unmarshal(metaSchemaDoc, &metaHeader); err != nil {
+		return "", "", fmt.Errorf("product type meta-schema is not valid JSON: %w", err)
+	}
+
+	metaSchemaURL := metaHeader.ID
+	if metaSchemaURL == "" {
+		metaSchemaURL = schemaHeader.Schema
+	}
+	if metaSchemaURL == "" {
+		return "", "", fmt.Errorf("product type meta-schema has no $id")
+	}
+
+	schemaURL := schemaHeader.ID
+	if schemaURL == "" {
+		schemaURL = "https://schemas.amazon.com/selling-partners/definitions/product-types/schema/local"
+	}
+
+	return schemaURL, metaSchemaURL, nil
+}
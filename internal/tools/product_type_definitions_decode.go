@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// productTypeSummary mirrors the Product Type Definitions ProductType model returned by search.
+type productTypeSummary struct {
+	Name           string   `json:"name"`
+	DisplayName    string   `json:"displayName,omitempty"`
+	MarketplaceIDs []string `json:"marketplaceIds,omitempty"`
+}
+
+type productTypePropertyGroup struct {
+	Title         string   `json:"title,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	PropertyNames []string `json:"propertyNames,omitempty"`
+}
+
+type productTypeVersion struct {
+	Version          string `json:"version"`
+	Latest           bool   `json:"latest"`
+	ReleaseCandidate bool   `json:"releaseCandidate,omitempty"`
+}
+
+type productTypeSchemaLink struct {
+	Resource string `json:"resource"`
+	Checksum string `json:"checksum"`
+}
+
+// productTypeDefinition is the getDefinitionsProductType payload with the schema links flattened.
+type productTypeDefinition struct {
+	ProductType          string                              `json:"productType"`
+	DisplayName          string                              `json:"displayName,omitempty"`
+	MarketplaceIDs       []string                            `json:"marketplaceIds,omitempty"`
+	Locale               string                              `json:"locale,omitempty"`
+	Requirements         string                              `json:"requirements,omitempty"`
+	RequirementsEnforced string                              `json:"requirementsEnforced,omitempty"`
+	ProductTypeVersion   productTypeVersion                  `json:"productTypeVersion"`
+	PropertyGroups       map[string]productTypePropertyGroup `json:"propertyGroups,omitempty"`
+	Schema               productTypeSchemaLink               `json:"-"`
+	MetaSchema           productTypeSchemaLink               `json:"-"`
+}
+
+type productTypeSearchDecoded struct {
+	productTypes   []productTypeSummary
+	version        string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type productTypeDefinitionDecoded struct {
+	definition     productTypeDefinition
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+func decodeProductTypeSearch(body []byte) (productTypeSearchDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return productTypeSearchDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto productTypeSearchResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return productTypeSearchDecoded{}, err
+	}
+
+	decoded := productTypeSearchDecoded{
+		apiErrors: dto.Errors,
+		version:   dto.ProductTypeVersion,
+	}
+
+	if dto.ProductTypes != nil {
+		decoded.payloadPresent = true
+		decoded.productTypes = *dto.ProductTypes
+	}
+
+	return decoded, nil
+}
+
+func decodeProductTypeDefinition(body []byte) (productTypeDefinitionDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return productTypeDefinitionDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto productTypeDefinitionResponseDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return productTypeDefinitionDecoded{}, err
+	}
+
+	decoded := productTypeDefinitionDecoded{apiErrors: dto.Errors}
+
+	if dto.ProductType != "" {
+		decoded.payloadPresent = true
+		decoded.definition = productTypeDefinition{
+			ProductType:          dto.ProductType,
+			DisplayName:          dto.DisplayName,
+			MarketplaceIDs:       dto.MarketplaceIDs,
+			Locale:               dto.Locale,
+			Requirements:         dto.Requirements,
+			RequirementsEnforced: dto.RequirementsEnforced,
+			ProductTypeVersion:   dto.ProductTypeVersion,
+			PropertyGroups:       dto.PropertyGroups,
+			Schema:               dto.Schema.link(),
+			MetaSchema:           dto.MetaSchema.link(),
+		}
+	}
+
+	return decoded, nil
+}
+
+type productTypeSearchResponseDTO struct {
+	Errors             []spAPIError          `json:"errors,omitempty"`
+	ProductTypes       *[]productTypeSummary `json:"productTypes,omitempty"`
+	ProductTypeVersion string                `json:"productTypeVersion,omitempty"`
+}
+
+type productTypeDefinitionResponseDTO struct {
+	Errors               []spAPIError                        `json:"errors,omitempty"`
+	ProductType          string                              `json:"productType"`
+	DisplayName          string                              `json:"displayName"`
+	MarketplaceIDs       []string                            `json:"marketplaceIds"`
+	Locale               string                              `json:"locale"`
+	Requirements         string                              `json:"requirements"`
+	RequirementsEnforced string                              `json:"requirementsEnforced"`
+	ProductTypeVersion   productTypeVersion                  `json:"productTypeVersion"`
+	PropertyGroups       map[string]productTypePropertyGroup `json:"propertyGroups"`
+	Schema               productTypeSchemaLinkDTO            `json:"schema"`
+	MetaSchema           productTypeSchemaLinkDTO            `json:"metaSchema"`
+}
+
+type productTypeSchemaLinkDTO struct {
+	Link struct {
+		Resource string `json:"resource"`
+		Verb     string `json:"verb"`
+	} `json:"link"`
+	Checksum string `json:"checksum"`
+}
+
+func (dto productTypeSchemaLinkDTO) link() productTypeSchemaLink {
+	return productTypeSchemaLink{Resource: dto.Link.Resource, Checksum: dto.Checksum}
+}
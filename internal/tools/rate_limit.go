@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimit is the default rate (requests per second) and burst Amazon documents for an SP-API operation.
+type rateLimit struct {
+	rate  float64
+	burst int
+}
+
+// defaultRateLimits covers the operations tools call repeatedly. Keys are "<api>.<operation>"; operations without an
+// entry are not throttled locally.
+var defaultRateLimits = map[string]rateLimit{
+	"orders.getOrders":                          {rate: 0.0167, burst: 20},
+	"orders.getOrderItems":                      {rate: 0.5, burst: 30},
+	"orders.getOrderItemsBuyerInfo":             {rate: 0.5, burst: 30},
+	"orders.getOrderItemsApprovals":             {rate: 0.5, burst: 30},
+	"reports.getReports":                        {rate: 0.0222, burst: 10},
+	"fbaInventory.getInventorySummaries":        {rate: 2, burst: 2},
+	"finances.listFinancialEvents":              {rate: 0.5, burst: 30},
+	"dataKiosk.getQueries":                      {rate: 0.0222, burst: 10},
+	"aplusContent.searchContentDocuments":       {rate: 10, burst: 10},
+	"vendorOrders.getPurchaseOrders":            {rate: 10, burst: 10},
+	"vendorDirectFulfillment.getOrders":         {rate: 10, burst: 10},
+	"vendorDirectFulfillment.getShippingLabels": {rate: 10, burst: 10},
+}
+
+// operationLimiter is a token bucket for one operation. Tokens may go negative so callers queue fairly behind earlier
+// reservations.
+type operationLimiter struct {
+	mu     sync.Mutex
+	limit  rateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newOperationLimiter(limit rateLimit) *operationLimiter {
+	return &operationLimiter{limit: limit, tokens: float64(limit.burst)}
+}
+
+// reserve takes a token and reports how long the caller must wait before sending its request.
+func (l *operationLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.limit.rate
+		if burst := float64(l.limit.burst); l.tokens > burst {
+			l.tokens = burst
+		}
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limit.rate * float64(time.Second))
+}
+
+// operationLimiters shares buckets across tool calls, since Amazon applies limits per selling partner and application.
+var operationLimiters = struct {
+	mu       sync.Mutex
+	limiters map[string]*operationLimiter
+}{limiters: make(map[string]*operationLimiter)}
+
+func limiterFor(operation string) *operationLimiter {
+	operationLimiters.mu.Lock()
+	defer operationLimiters.mu.Unlock()
+
+	if limiter, ok := operationLimiters.limiters[operation]; ok {
+		return limiter
+	}
+
+	limit, ok := defaultRateLimits[operation]
+	if !ok {
+		return nil
+	}
+
+	limiter := newOperationLimiter(limit)
+	operationLimiters.limiters[operation] = limiter
+	return limiter
+}
+
+// waitForOperation blocks until the operation's rate limit allows another request, returning how long it waited.
+func waitForOperation(ctx context.Context, operation string) (time.Duration, error) {
+	limiter := limiterFor(operation)
+	if limiter == nil {
+		return 0, nil
+	}
+
+	delay := limiter.reserve(time.Now())
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
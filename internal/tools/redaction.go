@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+)
+
+// withRedaction passes every tool result through the PII redactor before it is serialised for the client. Structured
+// content is redacted field by field; text content is either redacted as JSON or has the redacted values replaced.
+// Only field paths are logged, never the values.
+func withRedaction(tools []server.ServerTool, redactor *redact.Redactor) []server.ServerTool {
+	for i := range tools {
+		tool := &tools[i]
+		name := tool.Tool.Name
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := handler(ctx, req)
+			if result != nil {
+				redactResult(name, result, redactor)
+			}
+			return result, err
+		}
+	}
+	return tools
+}
+
+func redactResult(tool string, result *mcp.CallToolResult, redactor *redact.Redactor) {
+	var paths []string
+
+	structured := redact.Result{}
+	if result.StructuredContent != nil {
+		redacted, err := redactor.Value(result.StructuredContent)
+		if err != nil {
+			// Never hand back content the redactor could not inspect.
+			log.Printf("[ERROR] %s: redaction failed: %v", tool, err)
+			*result = *mcp.NewToolResultError(tool + " result withheld: PII redaction failed")
+			return
+		}
+		if redacted.Changed() {
+			result.StructuredContent = redacted.Value
+			paths = append(paths, redacted.Paths()...)
+		}
+		structured = redacted
+	}
+
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			continue
+		}
+
+		if redacted, ok := redactJSONText(text.Text, redactor); ok {
+			if redacted.Changed() {
+				encoded, err := json.Marshal(redacted.Value)
+				if err == nil {
+					text.Text = string(encoded)
+					paths = append(paths, redacted.Paths()...)
+				}
+			}
+		}
+		text.Text = structured.Text(text.Text)
+		result.Content[i] = text
+	}
+
+	if len(paths) > 0 {
+		log.Printf("[INFO] %s: redacted PII fields %s", tool, strings.Join(uniqueStrings(paths), ", "))
+	}
+}
+
+// redactJSONText handles tools whose text content is itself a JSON document.
+func redactJSONText(text string, redactor *redact.Redactor) (redact.Result, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return redact.Result{}, false
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return redact.Result{}, false
+	}
+
+	redacted, err := redactor.Value(decoded)
+	if err != nil {
+		return redact.Result{}, false
+	}
+	return redacted, true
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	unique := values[:0]
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+	return unique
+}
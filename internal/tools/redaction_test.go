@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ordersv0 "github.com/amzapi/selling-partner-api-sdk/ordersV0"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+func TestWithRedaction(t *testing.T) {
+	redactor, err := redact.New(redact.Policy{Default: redact.ActionMask})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+
+	tools := withRedaction([]server.ServerTool{{
+		Tool: mcp.NewTool("orders.getOrderBuyerInfo"),
+		Handler: func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result := ordersGetOrderBuyerInfoResult{AmazonOrderID: "111-2", BuyerInfo: &ordersv0.OrderBuyerInfo{BuyerEmail: stringPtr("jane@example.com"), BuyerName: stringPtr("Jane Doe")}}
+			return mcp.NewToolResultStructured(result, "Retrieved buyer info for order 111-2 (Jane Doe)"), nil
+		},
+	}}, redactor)
+
+	result, err := tools[0].Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	buyer := result.StructuredContent.(map[string]any)["buyerInfo"].(map[string]any)
+	if buyer["BuyerEmail"] != "j***" || buyer["BuyerName"] != "J***" {
+		t.Fatalf("structured content not redacted: %v", buyer)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if strings.Contains(text, "Jane Doe") || !strings.Contains(text, "111-2") {
+		t.Fatalf("unexpected fallback %q", text)
+	}
+}
+
+func TestAnalyticsSQLRedactsByColumn(t *testing.T) {
+	ctx := context.Background()
+	localStore, err := store.Open(filepath.Join(t.TempDir(), "orders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer localStore.Close()
+
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	err = localStore.SaveOrders(ctx, []store.Order{{
+		AmazonOrderID: "111-2", MarketplaceID: "ATVPDKIKX0DER", OrderStatus: "Shipped", PurchaseDate: now, LastUpdateDate: now,
+		Raw: json.RawMessage(`{"AmazonOrderId":"111-2","BuyerInfo":{"BuyerEmail":"jane@example.com","BuyerName":"Jane Doe"}}`),
+	}}, now)
+	if err != nil {
+		t.Fatalf("save orders: %v", err)
+	}
+	err = localStore.ImportReportRows(ctx, "doc-1", "GET_FLAT_FILE_ALL_ORDERS_DATA_BY_ORDER_DATE_GENERAL",
+		[]string{"order-id", "buyer-email", "ship-address-1", "ship-city"},
+		[][]string{{"111-2", "jane@example.com", "1 Main St", "Seattle"}}, now)
+	if err != nil {
+		t.Fatalf("import report rows: %v", err)
+	}
+
+	redactor, err := redact.New(redact.Policy{Default: redact.ActionMask})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	run := func(query string) *mcp.CallToolResult {
+		t.Helper()
+		result, err := executeAnalyticsSQL(ctx, analyticsSQLArgs{Query: query}, localStore, redactor)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return result
+	}
+
+	for _, query := range []string{
+		"SELECT raw FROM orders",
+		"SELECT data FROM report_rows",
+		"SELECT json_extract(raw, '$.BuyerInfo.BuyerEmail') FROM orders",
+		`SELECT json_extract(data, '$."buyer-email"'), data ->> 'ship-address-1' FROM report_rows`,
+		"SELECT json_extract(data, '$.buyer-email') AS buyer_email FROM report_rows",
+	} {
+		result := run(query)
+		if result.IsError {
+			t.Fatalf("%s: %+v", query, result.Content)
+		}
+		encoded, _ := json.Marshal(result.StructuredContent)
+		for _, pii := range []string{"jane@example.com", "Jane Doe", "1 Main St"} {
+			if strings.Contains(string(encoded), pii) {
+				t.Fatalf("%s leaked %q: %s", query, pii, encoded)
+			}
+		}
+		if strings.Contains(query, "data FROM") && !strings.Contains(string(encoded), "Seattle") {
+			t.Fatalf("%s should keep the city: %s", query, encoded)
+		}
+	}
+
+	if result := run("SELECT substr(raw, 1, 60) FROM orders"); !result.IsError {
+		t.Fatalf("a partial JSON cell should be withheld, got %+v", result.StructuredContent)
+	}
+	if rows := run("SELECT amazon_order_id FROM orders").StructuredContent.(analyticsSQLResult).Rows; rows[0][0] != "111-2" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+}
@@ -1,25 +1,267 @@
 package tools
 
-import "github.com/mark3labs/mcp-go/server"
-
-// BuildAll assembles every tool the server should expose. Future tools can be registered by extending the relevant specs.
-func BuildAll(deps Dependencies) []server.ServerTool {
-	orders := newOrdersTools(deps)
-	sales := newSalesTools(deps)
-	reports := newReportsTools(deps)
-	fbaInventory := newFBAInventoryTools(deps)
-	productPricing := newProductPricingTools(deps)
-	all := make([]server.ServerTool, 0, len(orders)+len(sales)+len(reports)+len(fbaInventory)+len(productPricing)+len(placeholderSpecs))
-
-	all = append(all, orders...)
-	all = append(all, sales...)
-	all = append(all, reports...)
-	all = append(all, fbaInventory...)
-	all = append(all, productPricing...)
+import (
+	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AuthMode is the kind of credentials a tool needs.
+type AuthMode string
+
+const (
+	// AuthSeller tools call seller-side SP-API operations.
+	AuthSeller AuthMode = "seller"
+	// AuthVendor tools call Vendor Central operations.
+	AuthVendor AuthMode = "vendor"
+	// AuthAny tools call operations open to both account types.
+	AuthAny AuthMode = "any"
+	// AuthNone tools only read local state and never call SP-API.
+	AuthNone AuthMode = "none"
+)
+
+// toolGroup is one builder in the registry. Every tool it returns shares its auth mode and prerequisites.
+type toolGroup struct {
+	auth AuthMode
+	// requires explains what deps lack for the group to work, or returns "" when nothing is missing.
+	requires func(deps Dependencies) string
+	build    func(deps Dependencies) []registeredTool
+}
+
+// toolGroups lists every real tool family. New families are registered here; the account type and configuration then
+// decide which of them are exposed.
+var toolGroups = []toolGroup{
+	{auth: AuthSeller, build: newOrdersTools},
+	{auth: AuthSeller, build: newOrdersUpdatesTools},
+	{auth: AuthSeller, build: newOrderStoreTools, requires: requiresStore},
+	{auth: AuthSeller, build: newStoreSyncTools, requires: requiresStore},
+	{auth: AuthSeller, build: newAnalyticsTools, requires: requiresStore},
+	{auth: AuthSeller, build: newSalesTools},
+	{auth: AuthSeller, build: newFBAInventoryTools},
+	{auth: AuthSeller, build: newProductPricingTools},
+	{auth: AuthSeller, build: newListingsRestrictionsTools},
+	{auth: AuthVendor, build: newVendorOrdersTools},
+	{auth: AuthVendor, build: newVendorDirectFulfillmentTools},
+	{auth: AuthAny, build: newReportsTools},
+	{auth: AuthAny, build: newDataKioskTools},
+	{auth: AuthAny, build: newUploadsTools},
+	{auth: AuthAny, build: newUploadFileTools, requires: requiresUploadDir},
+	{auth: AuthAny, build: newAplusContentTools},
+	{auth: AuthAny, build: newProductTypeDefinitionsTools},
+	{auth: AuthNone, build: newAuditLogTools, requires: requiresAuditLog},
+	{auth: AuthNone, build: newAuthorizationTools, requires: requiresOnboarding},
+}
+
+func requiresStore(deps Dependencies) string {
+	if deps.Store == nil {
+		return "SP_API_STORE_PATH is not set"
+	}
+	return ""
+}
+
+func requiresUploadDir(deps Dependencies) string {
+	if deps.UploadDir == "" {
+		return "SP_API_UPLOAD_DIR is not set"
+	}
+	return ""
+}
+
+func requiresAuditLog(deps Dependencies) string {
+	if deps.AuditLog == nil {
+		return "SP_API_AUDIT_LOG is not a queryable file"
+	}
+	return ""
+}
+
+func requiresOnboarding(deps Dependencies) string {
+	if deps.Onboarding == nil {
+		return "seller authorization needs an HTTP transport, SP_API_APPLICATION_ID and SP_API_PROFILES_FILE"
+	}
+	return ""
+}
+
+// ToolInfo describes one tool the registry knows about, whether or not it is exposed.
+type ToolInfo struct {
+	Name string
+	// Domain is the part of the name before the first dot; configuration enables and disables tools by domain.
+	Domain    string
+	Auth      AuthMode
+	Mutating  bool
+	RateLimit string
+	// Placeholder marks tools that only describe a capability that is not implemented yet.
+	Placeholder bool
+	// Unavailable says why the tool is not exposed; empty when it is.
+	Unavailable string
+}
+
+// Registry holds every known tool with its metadata.
+type Registry struct {
+	deps    Dependencies
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	info ToolInfo
+	tool server.ServerTool
+}
+
+// NewRegistry builds every tool family and decides which tools deps can expose. Two real tools with the same name, a
+// rate-limit key without a known limit or an unknown domain in the configuration are startup errors; a placeholder
+// drops out once a real tool takes its name.
+func NewRegistry(deps Dependencies) (*Registry, error) {
+	registry := &Registry{deps: deps}
+	seen := make(map[string]bool)
+
+	for _, group := range toolGroups {
+		unavailable := groupUnavailable(group, deps)
+		for _, tool := range group.build(deps) {
+			if seen[tool.Tool.Name] {
+				return nil, fmt.Errorf("tool %s is registered twice", tool.Tool.Name)
+			}
+			seen[tool.Tool.Name] = true
+
+			if tool.spec.RateLimit != "" {
+				if _, ok := defaultRateLimits[tool.spec.RateLimit]; !ok {
+					return nil, fmt.Errorf("tool %s uses rate limit %q, which has no default", tool.Tool.Name, tool.spec.RateLimit)
+				}
+			}
+			registry.add(tool, group.auth, false, unavailable)
+		}
+	}
+
+	placeholders := make(map[string]bool)
 	for _, spec := range placeholderSpecs {
-		all = append(all, newPlaceholderTool(spec, deps))
+		if placeholders[spec.Name] {
+			return nil, fmt.Errorf("placeholder %s is declared twice", spec.Name)
+		}
+		placeholders[spec.Name] = true
+		if seen[spec.Name] {
+			continue
+		}
+		registry.add(newPlaceholderTool(spec, deps), AuthAny, true, "")
+	}
+
+	if err := registry.applyDomainFilter(deps.EnabledDomains, deps.DisabledDomains); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+func groupUnavailable(group toolGroup, deps Dependencies) string {
+	switch {
+	case group.auth == AuthSeller && deps.Vendor:
+		return "needs a seller account"
+	case group.auth == AuthVendor && !deps.Vendor:
+		return "needs a vendor account"
+	case group.requires != nil:
+		return group.requires(deps)
+	}
+	return ""
+}
+
+func (r *Registry) add(tool registeredTool, auth AuthMode, placeholder bool, unavailable string) {
+	domain, _, _ := strings.Cut(tool.Tool.Name, ".")
+	r.entries = append(r.entries, registryEntry{
+		info: ToolInfo{
+			Name:        tool.Tool.Name,
+			Domain:      domain,
+			Auth:        auth,
+			Mutating:    tool.spec.Mutating,
+			RateLimit:   tool.spec.RateLimit,
+			Placeholder: placeholder,
+			Unavailable: unavailable,
+		},
+		tool: tool.ServerTool,
+	})
+}
+
+// applyDomainFilter keeps the enabled domains (all when none are listed) and then removes the disabled ones.
+func (r *Registry) applyDomainFilter(enabled, disabled []string) error {
+	known := make(map[string]bool)
+	for _, entry := range r.entries {
+		known[entry.info.Domain] = true
+	}
+	for _, domain := range append(append([]string(nil), enabled...), disabled...) {
+		if !known[domain] {
+			return fmt.Errorf("unknown tool domain %q; known domains: %s", domain, strings.Join(sortedKeys(known), ", "))
+		}
+	}
+
+	enabledSet := make(map[string]bool, len(enabled))
+	for _, domain := range enabled {
+		enabledSet[domain] = true
+	}
+	disabledSet := make(map[string]bool, len(disabled))
+	for _, domain := range disabled {
+		disabledSet[domain] = true
+	}
+
+	for i := range r.entries {
+		info := &r.entries[i].info
+		if info.Unavailable != "" {
+			continue
+		}
+		switch {
+		case len(enabledSet) > 0 && !enabledSet[info.Domain]:
+			info.Unavailable = "domain not in SP_API_ENABLE_DOMAINS"
+		case disabledSet[info.Domain]:
+			info.Unavailable = "domain in SP_API_DISABLE_DOMAINS"
+		}
+	}
+	return nil
+}
+
+// Catalog lists every known tool in registration order.
+func (r *Registry) Catalog() []ToolInfo {
+	catalog := make([]ToolInfo, len(r.entries))
+	for i, entry := range r.entries {
+		catalog[i] = entry.info
+	}
+	return catalog
+}
+
+// Tools returns the exposed tools, wrapped with the base transport, response cache and PII redaction when those are
+// configured.
+func (r *Registry) Tools() []server.ServerTool {
+	var all []server.ServerTool
+	for _, entry := range r.entries {
+		if entry.info.Unavailable == "" {
+			all = append(all, entry.tool)
+		}
+	}
+
+	if r.deps.Transport != nil {
+		all = withBaseTransport(all, r.deps.Transport)
+	}
+
+	if r.deps.ResponseCache != nil {
+		all = withResponseCache(all, r.deps.ResponseCache)
+	}
+
+	// Redaction wraps everything else so cache annotations and fallbacks are redacted too.
+	if r.deps.Redactor != nil {
+		all = withRedaction(all, r.deps.Redactor)
 	}
 
 	return all
 }
+
+// BuildAll assembles every tool the server should expose; see NewRegistry.
+func BuildAll(deps Dependencies) ([]server.ServerTool, error) {
+	registry, err := NewRegistry(deps)
+	if err != nil {
+		return nil, err
+	}
+	return registry.Tools(), nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
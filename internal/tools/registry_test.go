@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func catalogByName(t *testing.T, deps Dependencies) map[string]ToolInfo {
+	t.Helper()
+	registry, err := NewRegistry(deps)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	byName := make(map[string]ToolInfo)
+	for _, info := range registry.Catalog() {
+		byName[info.Name] = info
+	}
+	return byName
+}
+
+func TestRegistryRejectsDuplicateTools(t *testing.T) {
+	saved := toolGroups
+	defer func() { toolGroups = saved }()
+	toolGroups = append([]toolGroup{{auth: AuthAny, build: newReportsTools}}, saved...)
+
+	if _, err := NewRegistry(Dependencies{}); err == nil || !strings.Contains(err.Error(), "registered twice") {
+		t.Fatalf("expected a duplicate tool error, got %v", err)
+	}
+}
+
+func TestRegistryDropsPlaceholdersShadowedByRealTools(t *testing.T) {
+	saved := placeholderSpecs
+	defer func() { placeholderSpecs = saved }()
+	placeholderSpecs = append(append([]toolSpec(nil), saved...), toolSpec{Name: reportsCreateReportSpec.Name, Title: "Reports"})
+
+	registry, err := NewRegistry(Dependencies{})
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	var matches []ToolInfo
+	for _, info := range registry.Catalog() {
+		if info.Name == reportsCreateReportSpec.Name {
+			matches = append(matches, info)
+		}
+	}
+	if len(matches) != 1 || matches[0].Placeholder {
+		t.Fatalf("expected only the real reports.createReport, got %+v", matches)
+	}
+}
+
+func TestRegistryClassifiesTools(t *testing.T) {
+	catalog := catalogByName(t, Dependencies{})
+
+	listOrders := catalog[ordersListOrdersSpec.Name]
+	if listOrders.Domain != "orders" || listOrders.Auth != AuthSeller || listOrders.Mutating || listOrders.RateLimit != "orders.getOrders" || listOrders.Unavailable != "" {
+		t.Fatalf("unexpected orders.listOrders metadata: %+v", listOrders)
+	}
+	if reason := catalog[vendorOrdersGetPurchaseOrdersSpec.Name].Unavailable; reason != "needs a vendor account" {
+		t.Fatalf("vendor tools should be unavailable to sellers, got %q", reason)
+	}
+	if reason := catalog[analyticsSQLSpec.Name].Unavailable; !strings.Contains(reason, "SP_API_STORE_PATH") {
+		t.Fatalf("store tools should say the store is missing, got %q", reason)
+	}
+	if !catalog["catalog.lookupItem"].Placeholder {
+		t.Fatalf("catalog.lookupItem should be a placeholder")
+	}
+}
+
+func TestRegistryFiltersDomains(t *testing.T) {
+	registry, err := NewRegistry(Dependencies{EnabledDomains: []string{"orders", "reports"}, DisabledDomains: []string{"reports"}})
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	for _, tool := range registry.Tools() {
+		if !strings.HasPrefix(tool.Tool.Name, "orders.") {
+			t.Fatalf("only orders tools should be exposed, got %s", tool.Tool.Name)
+		}
+	}
+
+	if _, err := NewRegistry(Dependencies{DisabledDomains: []string{"ordrs"}}); err == nil || !strings.Contains(err.Error(), "unknown tool domain") {
+		t.Fatalf("expected an unknown domain error, got %v", err)
+	}
+}
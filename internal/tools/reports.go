@@ -11,7 +11,6 @@ import (
 	"github.com/amzapi/selling-partner-api-sdk/reports"
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
 )
@@ -24,11 +23,13 @@ type reportsGetReportsArgs struct {
 	CreatedSince       string   `json:"createdSince"`
 	CreatedUntil       string   `json:"createdUntil"`
 	NextToken          string   `json:"nextToken"`
+	pagingArgs
 }
 
 type reportsGetReportsResult struct {
 	Reports     []reports.Report `json:"reports"`
 	NextToken   string           `json:"nextToken,omitempty"`
+	Paging      *pagingSummary   `json:"paging,omitempty"`
 	RetrievedAt time.Time        `json:"retrievedAt"`
 }
 
@@ -73,11 +74,11 @@ type reportsGetReportDocumentResult struct {
 	RetrievedAt          time.Time `json:"retrievedAt"`
 }
 
-func newReportsTools(deps Dependencies) []server.ServerTool {
+func newReportsTools(deps Dependencies) []registeredTool {
 	spClient := deps.SellingPartner
 
-	getReportsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args reportsGetReportsArgs) (*mcp.CallToolResult, error) {
-		return executeReportsGetReports(ctx, args, spClient)
+	getReportsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args reportsGetReportsArgs) (*mcp.CallToolResult, error) {
+		return executeReportsGetReports(withProgressToken(ctx, req), args, spClient)
 	})
 
 	createReportHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args reportsCreateReportArgs) (*mcp.CallToolResult, error) {
@@ -92,11 +93,11 @@ func newReportsTools(deps Dependencies) []server.ServerTool {
 		return executeReportsGetReportDocument(ctx, strings.TrimSpace(args.ReportDocumentID), spClient)
 	})
 
-	return []server.ServerTool{
-		serverToolFromSpec(reportsGetReportsSpec, getReportsHandler),
-		serverToolFromSpec(reportsCreateReportSpec, createReportHandler),
-		serverToolFromSpec(reportsGetReportSpec, getReportHandler),
-		serverToolFromSpec(reportsGetReportDocumentSpec, getReportDocumentHandler),
+	return []registeredTool{
+		toolFromSpec(reportsGetReportsSpec, getReportsHandler),
+		newDryRunTool(reportsCreateReportSpec, deps, createReportHandler),
+		toolFromSpec(reportsGetReportSpec, getReportHandler),
+		toolFromSpec(reportsGetReportDocumentSpec, getReportDocumentHandler),
 	}
 }
 
@@ -108,10 +109,6 @@ func executeReportsGetReports(ctx context.Context, args reportsGetReportsArgs, s
 
 	params := &reports.GetReportsParams{}
 
-	if nextToken := strings.TrimSpace(args.NextToken); nextToken != "" {
-		params.NextToken = &nextToken
-	}
-
 	if len(args.ReportTypes) > 0 {
 		params.ReportTypes = &args.ReportTypes
 	}
@@ -140,41 +137,59 @@ func executeReportsGetReports(ctx context.Context, args reportsGetReportsArgs, s
 		}
 	}
 
-	httpResp, err := client.GetReports(ctx, params)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("reports.getReports request failed", err), nil
-	}
-	if httpResp == nil {
-		return mcp.NewToolResultError("reports.getReports returned no response"), nil
-	}
+	// Amazon expects nextToken as the only parameter when requesting later pages.
+	fetch := func(ctx context.Context, token string) ([]reports.Report, string, *mcp.CallToolResult) {
+		pageParams := params
+		if token != "" {
+			pageParams = &reports.GetReportsParams{NextToken: &token}
+		}
 
-	body, readErr := io.ReadAll(httpResp.Body)
-	defer httpResp.Body.Close()
-	if readErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to read reports.getReports response", readErr), nil
-	}
+		httpResp, err := client.GetReports(ctx, pageParams)
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("reports.getReports request failed", err)
+		}
+		if httpResp == nil {
+			return nil, "", mcp.NewToolResultError("reports.getReports returned no response")
+		}
 
-	decoded, decodeErr := decodeReportsGetReports(body)
-	if decodeErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to decode reports.getReports response", decodeErr), nil
-	}
+		body, readErr := io.ReadAll(httpResp.Body)
+		defer httpResp.Body.Close()
+		if readErr != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to read reports.getReports response", readErr)
+		}
 
-	if err := ensureReportsAPIResponse("getReports", httpResp, body, decoded.apiErrors); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		decoded, decodeErr := decodeReportsGetReports(body)
+		if decodeErr != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode reports.getReports response", decodeErr)
+		}
+
+		if err := ensureReportsAPIResponse("getReports", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("reports.getReports response payload is empty")
+		}
+
+		return decoded.reports, decoded.nextToken, nil
 	}
 
-	if !decoded.payloadPresent {
-		return mcp.NewToolResultError("reports.getReports response payload is empty"), nil
+	reportList, next, paging, failure := collectPages(ctx, pagedOperation{tool: reportsGetReportsSpec.Name, operation: reportsGetReportsSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
 	}
 
 	result := reportsGetReportsResult{
-		Reports:     decoded.reports,
-		NextToken:   decoded.nextToken,
+		Reports:     reportList,
+		NextToken:   next,
+		Paging:      paging,
 		RetrievedAt: time.Now().UTC(),
 	}
 
 	fallback := fmt.Sprintf("Retrieved %d reports", len(result.Reports))
-	if result.NextToken != "" {
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
 		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
 	}
 
@@ -329,43 +344,53 @@ func executeReportsGetReportDocument(ctx context.Context, reportDocumentID strin
 		return mcp.NewToolResultError("reportDocumentId is required"), nil
 	}
 
+	decoded, failure := fetchReportDocument(ctx, client, reportDocumentID)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := reportsGetReportDocumentResult{
+		ReportDocumentID:     decoded.reportDocumentID,
+		URL:                  decoded.url,
+		CompressionAlgorithm: decoded.compressionAlgorithm,
+		RetrievedAt:          time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved download URL for report document %s", result.ReportDocumentID)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// fetchReportDocument looks up the download URL and compression of a report document.
+func fetchReportDocument(ctx context.Context, client *reports.Client, reportDocumentID string) (reportsGetReportDocumentDecoded, *mcp.CallToolResult) {
 	httpResp, err := client.GetReportDocument(ctx, reportDocumentID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("reports.getReportDocument request failed", err), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultErrorFromErr("reports.getReportDocument request failed", err)
 	}
 	if httpResp == nil {
-		return mcp.NewToolResultError("reports.getReportDocument returned no response"), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultError("reports.getReportDocument returned no response")
 	}
 
 	body, readErr := io.ReadAll(httpResp.Body)
 	defer httpResp.Body.Close()
 	if readErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to read reports.getReportDocument response", readErr), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultErrorFromErr("failed to read reports.getReportDocument response", readErr)
 	}
 
 	decoded, decodeErr := decodeReportsGetReportDocument(body)
 	if decodeErr != nil {
-		return mcp.NewToolResultErrorFromErr("failed to decode reports.getReportDocument response", decodeErr), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultErrorFromErr("failed to decode reports.getReportDocument response", decodeErr)
 	}
 
 	if err := ensureReportsAPIResponse("getReportDocument", httpResp, body, decoded.apiErrors); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultError(err.Error())
 	}
 
 	if !decoded.payloadPresent {
-		return mcp.NewToolResultError("reports.getReportDocument response payload is empty"), nil
+		return reportsGetReportDocumentDecoded{}, mcp.NewToolResultError("reports.getReportDocument response payload is empty")
 	}
 
-	result := reportsGetReportDocumentResult{
-		ReportDocumentID:     decoded.reportDocumentID,
-		URL:                  decoded.url,
-		CompressionAlgorithm: decoded.compressionAlgorithm,
-		RetrievedAt:          time.Now().UTC(),
-	}
-
-	fallback := fmt.Sprintf("Retrieved download URL for report document %s", result.ReportDocumentID)
-
-	return mcp.NewToolResultStructured(result, fallback), nil
+	return decoded, nil
 }
 
 func ensureReportsClient(spClient spapi.Client) (*reports.Client, *mcp.CallToolResult) {
@@ -390,7 +415,7 @@ func ensureReportsClient(spClient spapi.Client) (*reports.Client, *mcp.CallToolR
 }
 
 func buildReportsClient(spClient spapi.Client) (*reports.Client, error) {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient := newSellingPartnerHTTPClient()
 
 	return &reports.Client{
 		Endpoint:      spClient.Endpoint(),
@@ -54,6 +54,10 @@ func decodeReportsGetReports(body []byte) (reportsGetReportsDecoded, error) {
 		decoded.payloadPresent = true
 		decoded.reports = dto.Payload.Reports
 		decoded.nextToken = valueOrEmpty(dto.Payload.NextToken)
+	} else if dto.Reports != nil {
+		decoded.payloadPresent = true
+		decoded.reports = dto.Reports
+		decoded.nextToken = valueOrEmpty(dto.NextToken)
 	}
 
 	return decoded, nil
@@ -77,6 +81,9 @@ func decodeReportsCreateReport(body []byte) (reportsCreateReportDecoded, error)
 	if dto.Payload != nil {
 		decoded.payloadPresent = true
 		decoded.reportID = dto.Payload.ReportId
+	} else if dto.ReportId != nil {
+		decoded.payloadPresent = true
+		decoded.reportID = *dto.ReportId
 	}
 
 	return decoded, nil
@@ -100,6 +107,14 @@ func decodeReportsGetReport(body []byte) (reportsGetReportDecoded, error) {
 	if dto.Payload != nil {
 		decoded.payloadPresent = true
 		decoded.report = *dto.Payload
+	} else if dto.ReportId != nil {
+		// 2021-06-30 returns the report itself rather than a payload wrapper.
+		var report reports.Report
+		if err := json.Unmarshal(trimmed, &report); err != nil {
+			return reportsGetReportDecoded{}, err
+		}
+		decoded.payloadPresent = true
+		decoded.report = report
 	}
 
 	return decoded, nil
@@ -121,6 +136,11 @@ func decodeReportsGetReportDocument(body []byte) (reportsGetReportDocumentDecode
 	}
 
 	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		decoded.reportDocumentID = dto.Payload.ReportDocumentId
+		decoded.url = dto.Payload.Url
+		decoded.compressionAlgorithm = valueOrEmpty(dto.Payload.CompressionAlgorithm)
+	} else if dto.ReportDocumentId != nil || dto.Url != nil {
 		decoded.payloadPresent = true
 		decoded.reportDocumentID = valueOrEmpty(dto.ReportDocumentId)
 		decoded.url = valueOrEmpty(dto.Url)
@@ -130,9 +150,12 @@ func decodeReportsGetReportDocument(body []byte) (reportsGetReportDocumentDecode
 	return decoded, nil
 }
 
+// The response DTOs accept both the payload-wrapped 2020-09-04 shape and the top-level 2021-06-30 shape.
 type reportsGetReportsResponseDTO struct {
-	Errors  *reports.ErrorList           `json:"errors,omitempty"`
-	Payload *reportsGetReportsPayloadDTO `json:"payload,omitempty"`
+	Errors    *reports.ErrorList           `json:"errors,omitempty"`
+	Payload   *reportsGetReportsPayloadDTO `json:"payload,omitempty"`
+	Reports   []reports.Report             `json:"reports,omitempty"`
+	NextToken *string                      `json:"nextToken,omitempty"`
 }
 
 type reportsGetReportsPayloadDTO struct {
@@ -141,8 +164,9 @@ type reportsGetReportsPayloadDTO struct {
 }
 
 type reportsCreateReportResponseDTO struct {
-	Errors  *reports.ErrorList                 `json:"errors,omitempty"`
-	Payload *reportsCreateReportResultDTO      `json:"payload,omitempty"`
+	Errors   *reports.ErrorList            `json:"errors,omitempty"`
+	Payload  *reportsCreateReportResultDTO `json:"payload,omitempty"`
+	ReportId *string                       `json:"reportId,omitempty"`
 }
 
 type reportsCreateReportResultDTO struct {
@@ -150,14 +174,15 @@ type reportsCreateReportResultDTO struct {
 }
 
 type reportsGetReportResponseDTO struct {
-	Errors  *reports.ErrorList `json:"errors,omitempty"`
-	Payload *reports.Report    `json:"payload,omitempty"`
+	Errors   *reports.ErrorList `json:"errors,omitempty"`
+	Payload  *reports.Report    `json:"payload,omitempty"`
+	ReportId *string            `json:"reportId,omitempty"`
 }
 
 type reportsGetReportDocumentResponseDTO struct {
-	Errors               *reports.ErrorList `json:"errors,omitempty"`
+	Errors               *reports.ErrorList      `json:"errors,omitempty"`
 	Payload              *reports.ReportDocument `json:"payload,omitempty"`
-	ReportDocumentId     *string            `json:"reportDocumentId,omitempty"`
-	Url                  *string            `json:"url,omitempty"`
-	CompressionAlgorithm *string            `json:"compressionAlgorithm,omitempty"`
-}
\ No newline at end of file
+	ReportDocumentId     *string                 `json:"reportDocumentId,omitempty"`
+	Url                  *string                 `json:"url,omitempty"`
+	CompressionAlgorithm *string                 `json:"compressionAlgorithm,omitempty"`
+}
@@ -0,0 +1,30 @@
+package tools
+
+import "testing"
+
+// The Reports API 2021-06-30 returns its results at the top level; the older 2020-09-04 shape wraps them in payload.
+func TestDecodeReportsUnwrappedBodies(t *testing.T) {
+	created, err := decodeReportsCreateReport([]byte(`{"reportId":"51390019964"}`))
+	if err != nil || !created.payloadPresent || created.reportID != "51390019964" {
+		t.Fatalf("createReport decoded to %+v (%v)", created, err)
+	}
+	created, err = decodeReportsCreateReport([]byte(`{"payload":{"reportId":"51390019965"}}`))
+	if err != nil || !created.payloadPresent || created.reportID != "51390019965" {
+		t.Fatalf("wrapped createReport decoded to %+v (%v)", created, err)
+	}
+
+	report, err := decodeReportsGetReport([]byte(`{"reportId":"51390019964","reportType":"GET_MERCHANT_LISTINGS_ALL_DATA","processingStatus":"DONE","reportDocumentId":"amzn1.spdoc.1.4.na.example"}`))
+	if err != nil || !report.payloadPresent || report.report.ReportId != "51390019964" || report.report.ProcessingStatus != "DONE" || report.report.ReportDocumentId == nil {
+		t.Fatalf("getReport decoded to %+v (%v)", report, err)
+	}
+
+	document, err := decodeReportsGetReportDocument([]byte(`{"reportDocumentId":"amzn1.spdoc.1.4.na.example","url":"https://example.com/doc","compressionAlgorithm":"GZIP"}`))
+	if err != nil || !document.payloadPresent || document.reportDocumentID != "amzn1.spdoc.1.4.na.example" || document.url != "https://example.com/doc" || document.compressionAlgorithm != "GZIP" {
+		t.Fatalf("getReportDocument decoded to %+v (%v)", document, err)
+	}
+
+	empty, err := decodeReportsGetReport([]byte(`{"errors":[{"code":"NotFound","message":"Report not found"}]}`))
+	if err != nil || empty.payloadPresent || empty.apiErrors == nil {
+		t.Fatalf("an errors-only body should not count as a report, got %+v (%v)", empty, err)
+	}
+}
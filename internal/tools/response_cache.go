@@ -0,0 +1,310 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/cache"
+)
+
+// cachePolicy names a cacheable GET operation by its path. Personal operations return buyer PII and are never cached,
+// whatever the configuration says.
+type cachePolicy struct {
+	operation string
+	path      *regexp.Regexp
+	ttl       time.Duration
+	personal  bool
+}
+
+// cachePolicies is matched in order, so the personal entries come first. Operations without an entry are not cached.
+var cachePolicies = []cachePolicy{
+	{operation: "orders.getOrderAddress", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+/address$`), personal: true},
+	{operation: "orders.getOrderBuyerInfo", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+/buyerInfo$`), personal: true},
+	{operation: "orders.getOrderItemsBuyerInfo", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+/orderItems/buyerInfo$`), personal: true},
+	{operation: "orders.getOrderRegulatedInfo", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+/regulatedInfo$`), personal: true},
+	{operation: "vendorDirectFulfillment", path: regexp.MustCompile(`^/vendor/directFulfillment/`), personal: true},
+	{operation: "catalog", path: regexp.MustCompile(`^/catalog/`), ttl: 24 * time.Hour},
+	{operation: "productTypeDefinitions", path: regexp.MustCompile(`^/definitions/2020-09-01/productTypes`), ttl: 24 * time.Hour},
+	{operation: "productPricing", path: regexp.MustCompile(`^/products/pricing/`), ttl: time.Minute},
+	{operation: "orders.getOrder", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+$`), ttl: 5 * time.Minute},
+	{operation: "orders.getOrderItems", path: regexp.MustCompile(`^/orders/v0/orders/[^/]+/orderItems$`), ttl: 5 * time.Minute},
+	{operation: "orders.getOrders", path: regexp.MustCompile(`^/orders/v0/orders$`)},
+	{operation: "listings.getListingsRestrictions", path: regexp.MustCompile(`^/listings/2021-08-01/restrictions$`), ttl: time.Hour},
+	{operation: "fbaInventory.getInventorySummaries", path: regexp.MustCompile(`^/fba/inventory/v1/summaries$`), ttl: time.Minute},
+	{operation: "sales.getOrderMetrics", path: regexp.MustCompile(`^/sales/v1/orderMetrics$`), ttl: 5 * time.Minute},
+	{operation: "aplusContent.getContentDocument", path: regexp.MustCompile(`^/aplus/2020-11-01/contentDocuments/[^/]+$`), ttl: 5 * time.Minute},
+}
+
+// ResponseCache serves repeated SP-API reads from a backend. Keys combine a seller namespace, the operation and the
+// normalised query, so two sellers (or two accounts in one seller's disk cache) never share entries.
+type ResponseCache struct {
+	backend   cache.Backend
+	namespace string
+	ttls      map[string]time.Duration
+}
+
+// NewResponseCache applies TTL overrides, keyed by operation name, on top of the defaults. A zero TTL disables caching
+// for that operation. Unknown operations and personal ones are rejected so a typo cannot silently cache buyer data.
+func NewResponseCache(backend cache.Backend, namespace string, overrides map[string]time.Duration) (*ResponseCache, error) {
+	ttls := make(map[string]time.Duration, len(cachePolicies))
+	for _, policy := range cachePolicies {
+		if !policy.personal {
+			ttls[policy.operation] = policy.ttl
+		}
+	}
+
+	for operation, ttl := range overrides {
+		if _, ok := ttls[operation]; !ok {
+			return nil, fmt.Errorf("no cacheable operation named %q; choose from %s", operation, strings.Join(cacheableOperations(), ", "))
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("cache TTL for %s must not be negative", operation)
+		}
+		ttls[operation] = ttl
+	}
+
+	return &ResponseCache{backend: backend, namespace: namespace, ttls: ttls}, nil
+}
+
+func cacheableOperations() []string {
+	var names []string
+	for _, policy := range cachePolicies {
+		if !policy.personal {
+			names = append(names, policy.operation)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the operation and TTL for a request path; ok is false when the path must not be cached.
+func (c *ResponseCache) lookup(path string) (string, time.Duration, bool) {
+	for _, policy := range cachePolicies {
+		if !policy.path.MatchString(path) {
+			continue
+		}
+		if policy.personal {
+			return "", 0, false
+		}
+		ttl := c.ttls[policy.operation]
+		return policy.operation, ttl, ttl > 0
+	}
+	return "", 0, false
+}
+
+// key normalises the query so parameter order (and repeated values given in a different order) do not split entries.
+func (c *ResponseCache) key(operation string, target *url.URL) string {
+	query := target.Query()
+	for name, values := range query {
+		sort.Strings(values)
+		query[name] = values
+	}
+	return c.namespace + "|" + operation + "|" + target.Path + "?" + query.Encode()
+}
+
+// cacheScope follows one tool call through the context so the transport can honour bypassCache and report what it
+// served.
+type cacheScope struct {
+	cache  *ResponseCache
+	bypass bool
+
+	mu     sync.Mutex
+	hits   int
+	misses int
+	oldest time.Duration
+}
+
+type cacheScopeKey struct{}
+
+func (s *cacheScope) record(hit bool, age time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !hit {
+		s.misses++
+		return
+	}
+	s.hits++
+	if age > s.oldest {
+		s.oldest = age
+	}
+}
+
+// withoutResponseCache detaches ctx from the calling tool's cache scope. Sync tools use it: a mirror must copy what
+// SP-API says now, not what an earlier read cached.
+func withoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheScopeKey{}, nil)
+}
+
+// cacheStatus is attached to read results that made at least one cacheable request.
+type cacheStatus struct {
+	ServedFromCache bool `json:"servedFromCache"`
+	Hits            int  `json:"hits"`
+	Misses          int  `json:"misses"`
+	AgeSeconds      int  `json:"ageSeconds,omitempty"`
+	Bypassed        bool `json:"bypassed,omitempty"`
+}
+
+func (s *cacheScope) status() (cacheStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.hits+s.misses == 0 {
+		return cacheStatus{}, false
+	}
+	return cacheStatus{
+		ServedFromCache: s.misses == 0,
+		Hits:            s.hits,
+		Misses:          s.misses,
+		AgeSeconds:      int(s.oldest.Seconds()),
+		Bypassed:        s.bypass,
+	}, true
+}
+
+// cachingTransport answers cacheable GETs from the cache of the calling tool's scope. Requests outside a tool call, or
+// for operations without a policy, go straight to the network.
+type cachingTransport struct {
+	base http.RoundTripper
+}
+
+func (t cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	scope, _ := req.Context().Value(cacheScopeKey{}).(*cacheScope)
+	if scope == nil || req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	operation, ttl, ok := scope.cache.lookup(req.URL.Path)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	key := scope.cache.key(operation, req.URL)
+	if !scope.bypass {
+		if entry, found := scope.cache.backend.Get(key); found {
+			scope.record(true, time.Since(entry.StoredAt))
+			return cachedResponse(req, entry), nil
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	scope.record(false, 0)
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	now := time.Now()
+	scope.cache.backend.Set(key, cache.Entry{
+		Status:    resp.StatusCode,
+		Header:    resp.Header.Clone(),
+		Body:      body,
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+	})
+
+	return resp, nil
+}
+
+func cachedResponse(req *http.Request, entry cache.Entry) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
+
+// withResponseCache gives every tool call a cache scope. Read-only tools gain a bypassCache argument and report cache
+// use in their result; a successful write purges the cache, since cached reads may now be stale.
+func withResponseCache(tools []server.ServerTool, responseCache *ResponseCache) []server.ServerTool {
+	for i := range tools {
+		tool := &tools[i]
+		readOnly := tool.Tool.Annotations.ReadOnlyHint == nil || *tool.Tool.Annotations.ReadOnlyHint
+
+		if readOnly {
+			if tool.Tool.InputSchema.Properties == nil {
+				tool.Tool.InputSchema.Properties = make(map[string]any)
+			}
+			tool.Tool.InputSchema.Properties["bypassCache"] = map[string]any{
+				"type":        "boolean",
+				"description": "Skip cached SP-API responses and fetch fresh data.",
+			}
+		}
+
+		handler := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope := &cacheScope{cache: responseCache, bypass: readOnly && req.GetBool("bypassCache", false)}
+			result, err := handler(context.WithValue(ctx, cacheScopeKey{}, scope), req)
+
+			if !readOnly {
+				if err == nil && result != nil && !result.IsError {
+					responseCache.backend.Purge()
+				}
+				return result, err
+			}
+
+			if status, ok := scope.status(); ok && err == nil {
+				annotateCacheStatus(result, status)
+			}
+			return result, err
+		}
+	}
+	return tools
+}
+
+// annotateCacheStatus adds a cache field to the structured result and notes cached data in the text fallback.
+func annotateCacheStatus(result *mcp.CallToolResult, status cacheStatus) {
+	if result == nil || result.IsError {
+		return
+	}
+
+	if result.StructuredContent != nil {
+		if encoded, err := json.Marshal(result.StructuredContent); err == nil {
+			var fields map[string]any
+			if json.Unmarshal(encoded, &fields) == nil && fields != nil {
+				fields["cache"] = status
+				result.StructuredContent = fields
+			}
+		}
+	}
+
+	if status.Hits == 0 {
+		return
+	}
+	note := fmt.Sprintf(" (%d of %d SP-API responses from cache, up to %ds old; pass bypassCache for fresh data)", status.Hits, status.Hits+status.Misses, status.AgeSeconds)
+	for i, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			text.Text += note
+			result.Content[i] = text
+			break
+		}
+	}
+}
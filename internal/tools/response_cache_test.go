@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/cache"
+)
+
+func TestCachingTransport(t *testing.T) {
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"payload":{}}`))
+	}))
+	defer upstream.Close()
+
+	responseCache, err := NewResponseCache(cache.NewMemory(10), "seller", map[string]time.Duration{"orders.getOrder": time.Minute})
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	client := &http.Client{Transport: cachingTransport{base: http.DefaultTransport}}
+
+	get := func(scope *cacheScope, path string) {
+		t.Helper()
+		ctx := context.WithValue(context.Background(), cacheScopeKey{}, scope)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	scope := &cacheScope{cache: responseCache}
+	get(scope, "/orders/v0/orders/111?b=2&a=1")
+	get(scope, "/orders/v0/orders/111?a=1&b=2")
+	if calls != 1 {
+		t.Fatalf("expected the second read to hit the cache, got %d upstream calls", calls)
+	}
+	if status, _ := scope.status(); status.Hits != 1 || status.Misses != 1 || status.ServedFromCache {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	get(&cacheScope{cache: responseCache, bypass: true}, "/orders/v0/orders/111?a=1&b=2")
+	if calls != 2 {
+		t.Fatalf("expected bypassCache to reach upstream, got %d calls", calls)
+	}
+
+	addressScope := &cacheScope{cache: responseCache}
+	get(addressScope, "/orders/v0/orders/111/address")
+	get(addressScope, "/orders/v0/orders/111/address")
+	if calls != 4 {
+		t.Fatalf("buyer address must never be cached, got %d calls", calls)
+	}
+	if _, ok := addressScope.status(); ok {
+		t.Fatalf("personal requests should not report cache status")
+	}
+
+	if _, err := NewResponseCache(cache.NewMemory(10), "seller", map[string]time.Duration{"orders.getOrderAddress": time.Minute}); err == nil {
+		t.Fatalf("expected personal operations to be rejected as TTL overrides")
+	}
+}
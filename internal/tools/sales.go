@@ -11,7 +11,6 @@ import (
 	sales "github.com/amzapi/selling-partner-api-sdk/sales"
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
-	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
 )
@@ -42,15 +41,15 @@ type salesGetOrderMetricsResult struct {
 	RetrievedAt         time.Time                    `json:"retrievedAt"`
 }
 
-func newSalesTools(deps Dependencies) []server.ServerTool {
+func newSalesTools(deps Dependencies) []registeredTool {
 	spClient := deps.SellingPartner
 
 	orderMetricsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args salesGetOrderMetricsArgs) (*mcp.CallToolResult, error) {
 		return executeSalesGetOrderMetrics(ctx, args, spClient)
 	})
 
-	return []server.ServerTool{
-		serverToolFromSpec(salesGetOrderMetricsSpec, orderMetricsHandler),
+	return []registeredTool{
+		toolFromSpec(salesGetOrderMetricsSpec, orderMetricsHandler),
 	}
 }
 
@@ -219,7 +218,7 @@ func ensureSalesClient(spClient spapi.Client) (*sales.Client, *mcp.CallToolResul
 }
 
 func buildSalesClient(spClient spapi.Client) (*sales.Client, error) {
-	httpClient := &http.Client{Timeout: 30 * time.Second}
+	httpClient := newSellingPartnerHTTPClient()
 
 	return &sales.Client{
 		Endpoint:      spClient.Endpoint(),
@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/audit"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// spRequest describes a call to an SP-API operation that the SDK does not ship a generated client for.
+type spRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   any
+}
+
+// spAPIError mirrors the error object shared by every SP-API model.
+type spAPIError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Details *string `json:"details,omitempty"`
+}
+
+// spErrorsDTO decodes the top-level errors array that SP-API returns alongside (or instead of) a payload.
+type spErrorsDTO struct {
+	Errors []spAPIError `json:"errors,omitempty"`
+}
+
+// sellingPartnerTransport carries every SP-API call, SDK or raw, so per-call features such as the response cache apply
+// to both. The audit transport sits below the cache, so the audit trail lists the requests that actually reached Amazon;
+// dry-run interception sits above both, so previewed writes neither reach Amazon nor appear in the trail.
+var sellingPartnerTransport http.RoundTripper = dryRunTransport{base: cachingTransport{base: audit.Transport{Base: baseTransport{}}}}
+
+type baseTransportKey struct{}
+
+// baseTransport sends requests through Dependencies.Transport when the tool call carries one, such as a cassette, and
+// through http.DefaultTransport otherwise.
+type baseTransport struct{}
+
+func (baseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if base, ok := req.Context().Value(baseTransportKey{}).(http.RoundTripper); ok {
+		return base.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// withBaseTransport routes every SP-API request a tool makes through base.
+func withBaseTransport(tools []server.ServerTool, base http.RoundTripper) []server.ServerTool {
+	for i := range tools {
+		handler := tools[i].Handler
+		tools[i].Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return handler(context.WithValue(ctx, baseTransportKey{}, base), req)
+		}
+	}
+	return tools
+}
+
+func newSellingPartnerHTTPClient() *http.Client {
+	return &http.Client{Timeout: 30 * time.Second, Transport: sellingPartnerTransport}
+}
+
+func ensureSellingPartnerClient(spClient spapi.Client) *mcp.CallToolResult {
+	if spClient == nil {
+		return mcp.NewToolResultError("Selling Partner API client is not initialised")
+	}
+
+	if status := spClient.Status(); !status.Ready {
+		message := strings.TrimSpace(status.Message)
+		if message == "" {
+			message = "Selling Partner API client is not ready"
+		}
+		return mcp.NewToolResultError(message)
+	}
+
+	return nil
+}
+
+// doSellingPartnerRequest signs and sends a raw SP-API request, returning the response together with its fully read body.
+func doSellingPartnerRequest(ctx context.Context, spClient spapi.Client, spReq spRequest) (*http.Response, []byte, error) {
+	endpoint, err := url.Parse(strings.TrimRight(spClient.Endpoint(), "/") + spReq.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building request URL: %w", err)
+	}
+	if len(spReq.Query) > 0 {
+		endpoint.RawQuery = spReq.Query.Encode()
+	}
+
+	var body io.Reader
+	if spReq.Body != nil {
+		encoded, err := json.Marshal(spReq.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, spReq.Method, endpoint.String(), body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building request: %w", err)
+	}
+
+	req.Header.Set("X-Amzn-Requestid", uuid.NewString())
+	req.Header.Set("Accept", "application/json")
+	if spReq.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := spClient.AuthorizeRequest(req); err != nil {
+		return nil, nil, fmt.Errorf("authorize request: %w", err)
+	}
+
+	httpClient := newSellingPartnerHTTPClient()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return resp, respBody, nil
+}
+
+func ensureSellingPartnerAPIResponse(operation string, resp *http.Response, body []byte, errors []spAPIError) error {
+	if resp == nil {
+		return fmt.Errorf("%s: no HTTP response returned", operation)
+	}
+
+	if os.Getenv("VERBOSE") == "true" {
+		log.Printf("[DEBUG] %s - HTTP Status: %d %s", operation, resp.StatusCode, http.StatusText(resp.StatusCode))
+		log.Printf("[DEBUG] %s - Response Body: %s", operation, string(body))
+	}
+
+	statusCode := resp.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		// Try to extract detailed error messages from the error list first
+		if len(errors) > 0 {
+			return fmt.Errorf("%s: request failed with status %d %s: %s", operation, statusCode, http.StatusText(statusCode), formatSellingPartnerErrors(errors))
+		}
+		// Fall back to body snippet if no structured errors available
+		return fmt.Errorf("%s: request failed with status %d %s: %s", operation, statusCode, http.StatusText(statusCode), sanitizeBodySnippet(body))
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%s: %s", operation, formatSellingPartnerErrors(errors))
+	}
+
+	return nil
+}
+
+func formatSellingPartnerErrors(list []spAPIError) string {
+	segments := make([]string, 0, len(list))
+	for _, apiErr := range list {
+		var builder strings.Builder
+		builder.WriteString(strings.TrimSpace(apiErr.Message))
+		if apiErr.Code != "" {
+			builder.WriteString(" (" + apiErr.Code + ")")
+		}
+		if apiErr.Details != nil {
+			detail := strings.TrimSpace(*apiErr.Details)
+			if detail != "" {
+				builder.WriteString(": " + detail)
+			}
+		}
+		segments = append(segments, builder.String())
+	}
+	return strings.Join(segments, "; ")
+}
+
+// decodeSellingPartnerErrors extracts the errors array from any SP-API response body, ignoring bodies that are not JSON.
+func decodeSellingPartnerErrors(body []byte) []spAPIError {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil
+	}
+
+	var dto spErrorsDTO
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return nil
+	}
+	return dto.Errors
+}
@@ -6,8 +6,9 @@ var ordersListOrdersSpec = toolSpec{
 	Name:        "orders.listOrders",
 	Title:       "Order Processing",
 	Description: "List orders created or updated within a time window, optionally filtered by status and fulfillment details.",
-	Guidance:    "Leverage the Orders API GetOrders operation to page through orders by marketplace and timeframe. When supplying a next token, omit other filters.",
-	Options: []mcp.ToolOption{
+	Guidance:    "Leverage the Orders API GetOrders operation to page through orders by marketplace and timeframe. When supplying a next token, omit other filters. For long creation ranges set splitDays; orders are merged, de-duplicated and sorted by purchase date. maxItems/maxPages bound the whole call; when they stop it early, resume truncated windows with their nextToken and the rest with resumeCreatedAfter as createdAfter.",
+	RateLimit:   "orders.getOrders",
+	Options: withPagingOptions(
 		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("One or more marketplace identifiers. Required unless using nextToken.")),
 		mcp.WithString("createdAfter", mcp.Description("ISO 8601 timestamp filter for order creation time.")),
 		mcp.WithString("createdBefore", mcp.Description("ISO 8601 timestamp upper bound for creation time.")),
@@ -22,7 +23,9 @@ var ordersListOrdersSpec = toolSpec{
 		mcp.WithArray("easyShipShipmentStatuses", mcp.WithStringItems(), mcp.Description("Optional Amazon Easy Ship status filters.")),
 		mcp.WithArray("amazonOrderIds", mcp.WithStringItems(), mcp.Description("Optional list of specific Amazon order IDs to retrieve.")),
 		mcp.WithString("nextToken", mcp.Description("Pagination token returned from a previous listOrders call.")),
-	},
+		mcp.WithNumber("splitDays", mcp.Description("Split the createdAfter/createdBefore range into windows of this many days (1-30) and fetch every page of each window concurrently.")),
+		mcp.WithNumber("concurrency", mcp.Description("With splitDays, how many windows to fetch at once (1-4, default 2). Requests still share the GetOrders rate limit.")),
+	),
 }
 
 var ordersGetOrderSpec = toolSpec{
@@ -60,10 +63,11 @@ var ordersGetOrderItemsSpec = toolSpec{
 	Title:       "Order Processing",
 	Description: "List the line items for a specific Amazon order, supporting pagination via next tokens.",
 	Guidance:    "Call the Orders API getOrderItems operation to retrieve order line items and handle pagination using next tokens for large orders.",
-	Options: []mcp.ToolOption{
+	RateLimit:   "orders.getOrderItems",
+	Options: withPagingOptions(
 		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
 		mcp.WithString("nextToken", mcp.Description("Pagination token returned from a previous getOrderItems call.")),
-	},
+	),
 }
 
 var ordersGetOrderItemsBuyerInfoSpec = toolSpec{
@@ -71,10 +75,11 @@ var ordersGetOrderItemsBuyerInfoSpec = toolSpec{
 	Title:       "Order Processing",
 	Description: "Retrieve buyer information for each order item, including gift notes and customization data.",
 	Guidance:    "Use the Orders API getOrderItemsBuyerInfo operation to fetch buyer-specific details (gift messages, customization URLs) for each order line item.",
-	Options: []mcp.ToolOption{
+	RateLimit:   "orders.getOrderItemsBuyerInfo",
+	Options: withPagingOptions(
 		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
 		mcp.WithString("nextToken", mcp.Description("Pagination token returned from a previous getOrderItemsBuyerInfo call.")),
-	},
+	),
 }
 
 var salesGetOrderMetricsSpec = toolSpec{
@@ -100,7 +105,8 @@ var reportsGetReportsSpec = toolSpec{
 	Title:       "Report Management",
 	Description: "List reports with optional filtering by type, processing status, creation time, and marketplace.",
 	Guidance:    "Use the Reports API getReports operation to retrieve existing reports. Filter by report types, processing statuses, or time ranges to narrow results.",
-	Options: []mcp.ToolOption{
+	RateLimit:   "reports.getReports",
+	Options: withPagingOptions(
 		mcp.WithArray("reportTypes", mcp.WithStringItems(), mcp.Description("Filter by specific report types (e.g., GET_FLAT_FILE_ALL_ORDERS_DATA_BY_LAST_UPDATE).")),
 		mcp.WithArray("processingStatuses", mcp.WithStringItems(), mcp.Enum("SUBMITTED", "IN_PROGRESS", "CANCELLED", "DONE", "FATAL"), mcp.Description("Filter by processing status.")),
 		mcp.WithArray("marketplaceIds", mcp.WithStringItems(), mcp.Description("Filter by marketplace identifiers.")),
@@ -108,7 +114,7 @@ var reportsGetReportsSpec = toolSpec{
 		mcp.WithString("createdSince", mcp.Description("ISO 8601 timestamp to filter reports created after this time.")),
 		mcp.WithString("createdUntil", mcp.Description("ISO 8601 timestamp to filter reports created before this time.")),
 		mcp.WithString("nextToken", mcp.Description("Pagination token from previous getReports call.")),
-	},
+	),
 }
 
 var reportsCreateReportSpec = toolSpec{
@@ -150,14 +156,15 @@ var fbaInventoryGetInventorySummariesSpec = toolSpec{
 	Title:       "FBA Inventory Management",
 	Description: "Retrieve inventory summaries for FBA items with detailed quantity breakdown and availability status.",
 	Guidance:    "Use the FBA Inventory API getInventorySummaries operation to get current inventory levels, inbound quantities, and availability status. Filter by SKU or marketplace for specific inventory views.",
-	Options: []mcp.ToolOption{
+	RateLimit:   "fbaInventory.getInventorySummaries",
+	Options: withPagingOptions(
 		mcp.WithString("granularityType", mcp.Required(), mcp.Enum("Marketplace"), mcp.Description("The granularity type for inventory aggregation (currently only Marketplace is supported).")),
 		mcp.WithString("granularityId", mcp.Description("The granularity ID - for Marketplace granularity, provide the marketplace identifier.")),
 		mcp.WithString("startDateTime", mcp.Description("ISO 8601 timestamp to filter inventory summaries updated after this time.")),
 		mcp.WithArray("sellerSkus", mcp.WithStringItems(), mcp.Description("Filter results to specific seller SKUs.")),
 		mcp.WithString("nextToken", mcp.Description("Pagination token from previous getInventorySummaries call.")),
 		mcp.WithBoolean("details", mcp.Description("Include detailed inventory breakdown (fulfillable, inbound, reserved quantities). Default: true.")),
-	},
+	),
 }
 
 var productPricingGetPricingSpec = toolSpec{
@@ -187,16 +194,607 @@ var productPricingGetCompetitivePricingSpec = toolSpec{
 	},
 }
 
-var placeholderSpecs = []toolSpec{
-	{
-		Name:        "auth.beginAuthorization",
-		Title:       "Authentication",
-		Description: "Initiate Login with Amazon workflow for the Selling Partner API.",
-		Guidance:    "Provide Login with Amazon authorization URLs and exchange refresh tokens using the Tokens API when implementing this tool.",
-		Options: []mcp.ToolOption{
-			mcp.WithString("marketplaceId", mcp.Description("Optional marketplace to scope the authorization.")),
-		},
+var dataKioskCreateQuerySpec = toolSpec{
+	Name:        "dataKiosk.createQuery",
+	Title:       "Data Kiosk",
+	Description: "Submit a Data Kiosk GraphQL query for asynchronous processing.",
+	Guidance:    "Use the Data Kiosk API createQuery operation to run GraphQL queries against Amazon analytics datasets. Poll dataKiosk.getQuery with the returned queryId and fetch results with dataKiosk.getDocument.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("query", mcp.Required(), mcp.Description("GraphQL query document, e.g. query { analytics_salesAndTraffic_2023_11_15 { ... } }.")),
+		mcp.WithString("paginationToken", mcp.Description("Pagination token from a previous query's pagination.nextToken to fetch the next page of results.")),
+	},
+}
+
+var dataKioskGetQueriesSpec = toolSpec{
+	Name:        "dataKiosk.getQueries",
+	Title:       "Data Kiosk",
+	Description: "List Data Kiosk queries with optional filtering by processing status and creation time.",
+	Guidance:    "Use the Data Kiosk API getQueries operation to find previously submitted queries and their processing status.",
+	RateLimit:   "dataKiosk.getQueries",
+	Options: withPagingOptions(
+		mcp.WithArray("processingStatuses", mcp.WithStringItems(), mcp.Enum("CANCELLED", "DONE", "FATAL", "IN_PROGRESS", "IN_QUEUE"), mcp.Description("Filter by processing status.")),
+		mcp.WithNumber("pageSize", mcp.Description("Number of queries to return (1-100, default 10).")),
+		mcp.WithString("createdSince", mcp.Description("ISO 8601 timestamp to filter queries created after this time.")),
+		mcp.WithString("createdUntil", mcp.Description("ISO 8601 timestamp to filter queries created before this time.")),
+		mcp.WithString("paginationToken", mcp.Description("Pagination token from a previous getQueries call.")),
+	),
+}
+
+var dataKioskGetQuerySpec = toolSpec{
+	Name:        "dataKiosk.getQuery",
+	Title:       "Data Kiosk",
+	Description: "Get the processing status of a Data Kiosk query, including its data or error document ID once finished.",
+	Guidance:    "Monitor query processing using the Data Kiosk API getQuery operation. When status is DONE, pass dataDocumentId to dataKiosk.getDocument; when FATAL, inspect errorDocumentId.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("queryId", mcp.Required(), mcp.Description("Query identifier returned from createQuery.")),
+	},
+}
+
+var dataKioskCancelQuerySpec = toolSpec{
+	Name:        "dataKiosk.cancelQuery",
+	Title:       "Data Kiosk",
+	Description: "Cancel a Data Kiosk query that is still queued or in progress.",
+	Guidance:    "Use the Data Kiosk API cancelQuery operation to stop queries that are no longer needed. Queries that already finished cannot be cancelled.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("queryId", mcp.Required(), mcp.Description("Query identifier returned from createQuery.")),
+	},
+}
+
+var dataKioskGetDocumentSpec = toolSpec{
+	Name:        "dataKiosk.getDocument",
+	Title:       "Data Kiosk",
+	Description: "Get a Data Kiosk data or error document, downloading and parsing its JSONL records.",
+	Guidance:    "Use the Data Kiosk API getDocument operation to obtain a pre-signed document URL. The document is downloaded and each JSONL line is returned as a record unless download is false.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("documentId", mcp.Required(), mcp.Description("Data or error document identifier from getQuery.")),
+		mcp.WithBoolean("download", mcp.Description("Download and parse the document records. Default: true.")),
+		mcp.WithNumber("maxRecords", mcp.Description("Maximum number of records to return (default 500).")),
+	},
+}
+
+var dataKioskListSavedQueriesSpec = toolSpec{
+	Name:        "dataKiosk.listSavedQueries",
+	Title:       "Data Kiosk",
+	Description: "List the saved Data Kiosk GraphQL queries that can be run by name.",
+	Guidance:    "Browse the bundled query library before calling dataKiosk.runSavedQuery.",
+}
+
+var dataKioskRunSavedQuerySpec = toolSpec{
+	Name:        "dataKiosk.runSavedQuery",
+	Title:       "Data Kiosk",
+	Description: "Run a saved Data Kiosk query (sales and traffic by ASIN or date, economics) for a date range.",
+	Guidance:    "Renders a saved GraphQL query with the supplied dates and marketplaces and submits it with createQuery. Set wait to poll until the query finishes and return its records.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("name", mcp.Required(), mcp.Enum("salesAndTrafficByAsin", "salesAndTrafficByDate", "economicsBySku"), mcp.Description("Saved query name.")),
+		mcp.WithString("startDate", mcp.Required(), mcp.Description("Inclusive start date formatted as YYYY-MM-DD.")),
+		mcp.WithString("endDate", mcp.Required(), mcp.Description("Inclusive end date formatted as YYYY-MM-DD.")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers to query (for example ATVPDKIKX0DER).")),
+		mcp.WithString("aggregateBy", mcp.Description("Aggregation level; CHILD, PARENT, or SKU for salesAndTrafficByAsin and DAY, WEEK, or MONTH otherwise.")),
+		mcp.WithBoolean("wait", mcp.Description("Poll until the query finishes and download its records. Default: false.")),
+		mcp.WithNumber("waitSeconds", mcp.Description("Maximum seconds to wait when wait is true (default 60, max 300).")),
+		mcp.WithNumber("maxRecords", mcp.Description("Maximum number of records to return when waiting (default 500).")),
+	},
+}
+
+var uploadsCreateUploadDestinationSpec = toolSpec{
+	Name:        "uploads.createUploadDestinationForResource",
+	Title:       "Uploads",
+	Description: "Create a pre-signed upload destination for a Selling Partner API resource.",
+	Guidance:    "Returns an uploadDestinationId plus the URL and headers for a PUT request. Compute contentMD5 as the base64-encoded MD5 of the file, or use uploads.uploadFile to handle the whole flow.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("resource", mcp.Required(), mcp.Description("Resource path the upload is for, for example aplus/2020-11-01/contentDocuments.")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Exactly one marketplace identifier.")),
+		mcp.WithString("contentMD5", mcp.Required(), mcp.Description("Base64-encoded MD5 digest of the file content.")),
+		mcp.WithString("contentType", mcp.Description("MIME type of the file, for example image/jpeg.")),
+	},
+}
+
+var uploadsUploadFileSpec = toolSpec{
+	Name:        "uploads.uploadFile",
+	Title:       "Uploads",
+	Description: "Upload a local file to Amazon and return its upload destination identifier.",
+	Guidance:    "Reads the file from the server's upload directory (50 MB limit), creates an upload destination, and PUTs the content. Reference the returned uploadDestinationId from A+ image modules.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("filePath", mcp.Required(), mcp.Description("Path to the file inside the server's upload directory (SP_API_UPLOAD_DIR), absolute or relative to it.")),
+		mcp.WithString("resource", mcp.Required(), mcp.Description("Resource path the upload is for, for example aplus/2020-11-01/contentDocuments.")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Exactly one marketplace identifier.")),
+		mcp.WithString("contentType", mcp.Description("MIME type override; detected from the file extension and content when omitted.")),
+	},
+}
+
+var aplusSearchContentDocumentsSpec = toolSpec{
+	Name:        "aplus.searchContentDocuments",
+	Title:       "A+ Content",
+	Description: "List A+ content documents for a marketplace.",
+	Guidance:    "Returns content reference keys with name, status, and badges. Pass nextPageToken back as pageToken to continue.",
+	RateLimit:   "aplusContent.searchContentDocuments",
+	Options: withPagingOptions(
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithString("pageToken", mcp.Description("Token returned by a previous call to fetch the next page.")),
+	),
+}
+
+var aplusGetContentDocumentSpec = toolSpec{
+	Name:        "aplus.getContentDocument",
+	Title:       "A+ Content",
+	Description: "Retrieve an A+ content document and its metadata.",
+	Guidance:    "Returns the full content document, which can be edited and passed to aplus.updateContentDocument.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("contentReferenceKey", mcp.Required(), mcp.Description("Content reference key returned by search or create.")),
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithArray("includedDataSet", mcp.WithStringItems(mcp.Enum("CONTENTS", "METADATA")), mcp.Description("Data sets to include. Default: CONTENTS and METADATA.")),
+	},
+}
+
+var aplusValidateContentDocumentSpec = toolSpec{
+	Name:        "aplus.validateContentDocument",
+	Title:       "A+ Content",
+	Description: "Validate an A+ content document, optionally against the ASINs it will be attached to.",
+	Guidance:    "Runs local structure checks, then Amazon's validateContentDocumentAsinRelations. Returns valid plus a list of issues with severity, source, code, and message; nothing is saved.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithObject("contentDocument", mcp.Required(), mcp.Description("A+ ContentDocument with name, contentType, locale, and contentModuleList.")),
+		mcp.WithArray("asins", mcp.WithStringItems(), mcp.Description("Optional ASINs to validate the document against.")),
+	},
+}
+
+var aplusCreateContentDocumentSpec = toolSpec{
+	Name:        "aplus.createContentDocument",
+	Title:       "A+ Content",
+	Description: "Create a new A+ content document.",
+	Guidance:    "Set validateOnly to check the document without saving it. Saving requires SP_API_ENABLE_WRITES=true. Attach ASINs afterwards with aplus.postContentDocumentAsinRelations.",
+	Mutating:    true,
+	Options: []mcp.ToolOption{
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithObject("contentDocument", mcp.Required(), mcp.Description("A+ ContentDocument with name, contentType, locale, and contentModuleList.")),
+		mcp.WithBoolean("validateOnly", mcp.Description("Validate the document and return issues without creating it. Default: false.")),
+		mcp.WithArray("asins", mcp.WithStringItems(), mcp.Description("ASINs to validate against when validateOnly is true.")),
+	},
+}
+
+var aplusUpdateContentDocumentSpec = toolSpec{
+	Name:        "aplus.updateContentDocument",
+	Title:       "A+ Content",
+	Description: "Replace an existing A+ content document.",
+	Guidance:    "Send the complete document; Amazon replaces the stored version and resubmits it for moderation. Set validateOnly to check it without saving. Saving requires SP_API_ENABLE_WRITES=true.",
+	Mutating:    true,
+	Options: []mcp.ToolOption{
+		mcp.WithString("contentReferenceKey", mcp.Required(), mcp.Description("Content reference key of the document to replace.")),
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithObject("contentDocument", mcp.Required(), mcp.Description("A+ ContentDocument with name, contentType, locale, and contentModuleList.")),
+		mcp.WithBoolean("validateOnly", mcp.Description("Validate the document and return issues without updating it. Default: false.")),
+		mcp.WithArray("asins", mcp.WithStringItems(), mcp.Description("ASINs to validate against when validateOnly is true.")),
+	},
+}
+
+var aplusPostContentDocumentAsinRelationsSpec = toolSpec{
+	Name:        "aplus.postContentDocumentAsinRelations",
+	Title:       "A+ Content",
+	Description: "Set the ASINs an A+ content document is attached to.",
+	Guidance:    "Replaces the full ASIN set; ASINs not listed are detached and an empty list detaches all. Requires SP_API_ENABLE_WRITES=true.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("contentReferenceKey", mcp.Required(), mcp.Description("Content reference key of the document.")),
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace identifier (for example ATVPDKIKX0DER).")),
+		mcp.WithArray("asins", mcp.Required(), mcp.WithStringItems(), mcp.Description("Complete set of ASINs to attach the document to.")),
+	},
+}
+
+var productTypeSearchSpec = toolSpec{
+	Name:        "productTypeDefinitions.searchDefinitionsProductTypes",
+	Title:       "Product Type Definitions",
+	Description: "Search Amazon product types by keywords or item name.",
+	Guidance:    "Use itemName for the best match to a product title, or keywords for a broader list. Pass the chosen name to getDefinitionsProductType.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers to search (for example ATVPDKIKX0DER).")),
+		mcp.WithArray("keywords", mcp.WithStringItems(), mcp.Description("Keywords to match; cannot be combined with itemName.")),
+		mcp.WithString("itemName", mcp.Description("Product title to find recommended product types for; cannot be combined with keywords.")),
+		mcp.WithString("locale", mcp.Description("Locale for display names (for example en_US).")),
+		mcp.WithString("searchLocale", mcp.Description("Locale used to interpret keywords and itemName.")),
+	},
+}
+
+var productTypeGetDefinitionSpec = toolSpec{
+	Name:        "productTypeDefinitions.getDefinitionsProductType",
+	Title:       "Product Type Definitions",
+	Description: "Retrieve a product type definition, its property groups and required attributes.",
+	Guidance:    "Downloads and caches the product type JSON Schema so listings can be validated locally. Request the schema fragments for specific attributes with properties.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("productType", mcp.Required(), mcp.Description("Product type name (for example LUGGAGE).")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers (for example ATVPDKIKX0DER).")),
+		mcp.WithString("sellerId", mcp.Description("Selling partner identifier for seller-specific requirements.")),
+		mcp.WithString("productTypeVersion", mcp.Description("Schema version. Default: LATEST.")),
+		mcp.WithString("requirements", mcp.Enum("LISTING", "LISTING_PRODUCT_ONLY", "LISTING_OFFER_ONLY"), mcp.Description("Requirement set. Default: LISTING.")),
+		mcp.WithString("requirementsEnforced", mcp.Enum("ENFORCED", "NOT_ENFORCED"), mcp.Description("Whether required attributes are enforced in the schema. Default: ENFORCED.")),
+		mcp.WithString("locale", mcp.Description("Locale for labels and descriptions. Default: DEFAULT.")),
+		mcp.WithArray("properties", mcp.WithStringItems(), mcp.Description("Attribute names whose schema fragments should be returned.")),
+	},
+}
+
+var productTypeValidateAttributesSpec = toolSpec{
+	Name:        "productTypeDefinitions.validateListingAttributes",
+	Title:       "Product Type Definitions",
+	Description: "Validate a listing attributes payload against the product type JSON Schema locally.",
+	Guidance:    "Runs offline once the schema is cached, including Amazon keywords such as maxUtf8ByteLength and selectors. Fix every issue before submitting the listing instead of relying on VALIDATION_PREVIEW.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("productType", mcp.Required(), mcp.Description("Product type name (for example LUGGAGE).")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers (for example ATVPDKIKX0DER).")),
+		mcp.WithObject("attributes", mcp.Required(), mcp.Description("Listing attributes object, as sent in a Listings Items put or patch request.")),
+		mcp.WithString("sellerId", mcp.Description("Selling partner identifier for seller-specific requirements.")),
+		mcp.WithString("productTypeVersion", mcp.Description("Schema version. Default: LATEST.")),
+		mcp.WithString("requirements", mcp.Enum("LISTING", "LISTING_PRODUCT_ONLY", "LISTING_OFFER_ONLY"), mcp.Description("Requirement set. Default: LISTING.")),
+		mcp.WithString("requirementsEnforced", mcp.Enum("ENFORCED", "NOT_ENFORCED"), mcp.Description("Whether required attributes are enforced. Default: ENFORCED.")),
+		mcp.WithString("locale", mcp.Description("Locale for labels and descriptions. Default: DEFAULT.")),
+	},
+}
+
+var listingsGetRestrictionsSpec = toolSpec{
+	Name:        "listings.getListingsRestrictions",
+	Title:       "Listings",
+	Description: "Return the listing restrictions for an ASIN, condition and marketplaces.",
+	Guidance:    "Uses the Listings Restrictions API. sellerId defaults to SP_API_SELLER_ID. Each reason carries a reasonCode (APPROVAL_REQUIRED, ASIN_NOT_FOUND, NOT_ELIGIBLE) and links such as the approval request page.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("asin", mcp.Required(), mcp.Description("ASIN to check.")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers (for example ATVPDKIKX0DER).")),
+		mcp.WithString("conditionType", mcp.Enum("new_new", "new_open_box", "new_oem", "refurbished_refurbished", "used_like_new", "used_very_good", "used_good", "used_acceptable", "collectible_like_new", "collectible_very_good", "collectible_good", "collectible_acceptable", "club_club"), mcp.Description("Offer condition to check. Omit to check all conditions.")),
+		mcp.WithString("sellerId", mcp.Description("Merchant token; defaults to SP_API_SELLER_ID.")),
+		mcp.WithString("reasonLocale", mcp.Description("Locale for reason messages (for example en_US).")),
+	},
+}
+
+var listingsCheckSellabilitySpec = toolSpec{
+	Name:        "listings.checkSellability",
+	Title:       "Listings",
+	Description: "Report whether an ASIN can be listed and sent to FBA, per marketplace.",
+	Guidance:    "Combines getListingsRestrictions with fbaInbound.getItemEligibilityPreview. Each marketplace reports canList, approvalRequired, approval links and FBA eligibility with ineligibility reason codes. Use program NONE to skip the FBA check.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("asin", mcp.Required(), mcp.Description("ASIN to evaluate.")),
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplace identifiers (for example ATVPDKIKX0DER).")),
+		mcp.WithString("conditionType", mcp.Description("Offer condition to check (for example new_new). Omit to check all conditions.")),
+		mcp.WithString("sellerId", mcp.Description("Merchant token; defaults to SP_API_SELLER_ID.")),
+		mcp.WithString("reasonLocale", mcp.Description("Locale for reason messages (for example en_US).")),
+		mcp.WithString("program", mcp.Enum("INBOUND", "COMMINGLING", "NONE"), mcp.Description("FBA eligibility program to check. Default: INBOUND.")),
+	},
+}
+
+var vendorOrdersGetPurchaseOrdersSpec = toolSpec{
+	Name:        "vendorOrders.getPurchaseOrders",
+	Title:       "Vendor Orders",
+	Description: "List Vendor Central purchase orders by creation or change window.",
+	Guidance:    "Filter by createdAfter/createdBefore or changedAfter/changedBefore (ISO 8601). Pass nextToken back to page. Set includeDetails to false for a lighter listing.",
+	RateLimit:   "vendorOrders.getPurchaseOrders",
+	Options: withPagingOptions(
+		mcp.WithString("createdAfter", mcp.Description("Return orders created after this ISO 8601 timestamp.")),
+		mcp.WithString("createdBefore", mcp.Description("Return orders created before this ISO 8601 timestamp.")),
+		mcp.WithString("changedAfter", mcp.Description("Return orders changed after this ISO 8601 timestamp.")),
+		mcp.WithString("changedBefore", mcp.Description("Return orders changed before this ISO 8601 timestamp.")),
+		mcp.WithString("purchaseOrderState", mcp.Enum("New", "Acknowledged", "Closed"), mcp.Description("Filter by purchase order state.")),
+		mcp.WithBoolean("isPOChanged", mcp.Description("Only return orders that Amazon changed after acknowledgement.")),
+		mcp.WithString("orderingVendorCode", mcp.Description("Vendor code the orders were placed with.")),
+		mcp.WithBoolean("includeDetails", mcp.Description("Include order details. Default: true.")),
+		mcp.WithString("sortOrder", mcp.Enum("ASC", "DESC"), mcp.Description("Sort by creation date.")),
+		mcp.WithNumber("limit", mcp.Description("Orders per page (max 100).")),
+		mcp.WithString("nextToken", mcp.Description("Token from a previous page.")),
+	),
+}
+
+var vendorOrdersGetPurchaseOrderSpec = toolSpec{
+	Name:        "vendorOrders.getPurchaseOrder",
+	Title:       "Vendor Orders",
+	Description: "Retrieve a Vendor Central purchase order with its items.",
+	Guidance:    "Returns the purchase order state and full order details, including items and ship windows.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("purchaseOrderNumber", mcp.Required(), mcp.Description("Purchase order number.")),
+	},
+}
+
+var vendorOrdersSubmitAcknowledgementSpec = toolSpec{
+	Name:        "vendorOrders.submitAcknowledgement",
+	Title:       "Vendor Orders",
+	Description: "Acknowledge Vendor Central purchase orders, accepting, rejecting or backordering items.",
+	Guidance:    "Each document is an OrderAcknowledgement (purchaseOrderNumber, sellingParty, acknowledgementDate, items). Requires SP_API_ENABLE_WRITES=true. Track the returned transactionId with vendor.getTransactionStatus.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("documents", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("OrderAcknowledgement objects to submit.")),
+	},
+}
+
+var vendorInvoicesSubmitInvoicesSpec = toolSpec{
+	Name:        "vendorInvoices.submitInvoices",
+	Title:       "Vendor Invoices",
+	Description: "Submit invoices or credit notes for Vendor Central purchase orders.",
+	Guidance:    "Each document is an Invoice following the Vendor Invoices API model. Requires SP_API_ENABLE_WRITES=true. Track the returned transactionId with vendor.getTransactionStatus.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("documents", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("Invoice objects to submit.")),
 	},
+}
+
+var vendorGetTransactionStatusSpec = toolSpec{
+	Name:        "vendor.getTransactionStatus",
+	Title:       "Vendor Transactions",
+	Description: "Check the processing status of a vendor submission.",
+	Guidance:    "Use the transactionId returned by a vendor submit tool. Set directFulfillment for Direct Fulfillment acknowledgements and shipment confirmations.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("transactionId", mcp.Required(), mcp.Description("Transaction identifier returned by a submission.")),
+		mcp.WithBoolean("directFulfillment", mcp.Description("Look the transaction up in the Direct Fulfillment transactions API. Default: false.")),
+	},
+}
+
+var vendorDirectFulfillmentGetOrdersSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.getOrders",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "List Direct Fulfillment purchase orders created in a time window.",
+	Guidance:    "createdAfter and createdBefore (ISO 8601) are required unless nextToken is provided.",
+	RateLimit:   "vendorDirectFulfillment.getOrders",
+	Options: withPagingOptions(
+		mcp.WithString("createdAfter", mcp.Description("Return orders created after this ISO 8601 timestamp.")),
+		mcp.WithString("createdBefore", mcp.Description("Return orders created before this ISO 8601 timestamp.")),
+		mcp.WithString("shipFromPartyId", mcp.Description("Warehouse identifier the orders ship from.")),
+		mcp.WithString("status", mcp.Enum("NEW", "SHIPPED", "ACCEPTED", "CANCELLED"), mcp.Description("Filter by order status.")),
+		mcp.WithBoolean("includeDetails", mcp.Description("Include order details. Default: true.")),
+		mcp.WithString("sortOrder", mcp.Enum("ASC", "DESC"), mcp.Description("Sort by creation date.")),
+		mcp.WithNumber("limit", mcp.Description("Orders per page (max 100).")),
+		mcp.WithString("nextToken", mcp.Description("Token from a previous page.")),
+	),
+}
+
+var vendorDirectFulfillmentGetOrderSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.getOrder",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "Retrieve a Direct Fulfillment purchase order.",
+	Guidance:    "Returns the order details, including ship-to party, items and shipment method.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("purchaseOrderNumber", mcp.Required(), mcp.Description("Purchase order number.")),
+	},
+}
+
+var vendorDirectFulfillmentSubmitAcknowledgementSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.submitAcknowledgement",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "Acknowledge Direct Fulfillment orders.",
+	Guidance:    "Each document is an OrderAcknowledgementItem (purchaseOrderNumber, vendorOrderNumber, acknowledgementDate, acknowledgementStatus, sellingParty, shipFromParty, itemAcknowledgements). Requires SP_API_ENABLE_WRITES=true.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("documents", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("OrderAcknowledgementItem objects to submit.")),
+	},
+}
+
+var vendorDirectFulfillmentGetShippingLabelsSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.getShippingLabels",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "List Direct Fulfillment shipping labels created in a time window.",
+	Guidance:    "createdAfter and createdBefore (ISO 8601) are required unless nextToken is provided.",
+	RateLimit:   "vendorDirectFulfillment.getShippingLabels",
+	Options: withPagingOptions(
+		mcp.WithString("createdAfter", mcp.Description("Return labels created after this ISO 8601 timestamp.")),
+		mcp.WithString("createdBefore", mcp.Description("Return labels created before this ISO 8601 timestamp.")),
+		mcp.WithString("shipFromPartyId", mcp.Description("Warehouse identifier the labels were created for.")),
+		mcp.WithString("sortOrder", mcp.Enum("ASC", "DESC"), mcp.Description("Sort by creation date.")),
+		mcp.WithNumber("limit", mcp.Description("Labels per page (max 100).")),
+		mcp.WithString("nextToken", mcp.Description("Token from a previous page.")),
+	),
+}
+
+var vendorDirectFulfillmentGetShippingLabelSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.getShippingLabel",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "Retrieve the shipping label for a Direct Fulfillment order.",
+	Guidance:    "Returns the label format and base64-encoded label data for each package.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("purchaseOrderNumber", mcp.Required(), mcp.Description("Purchase order number.")),
+	},
+}
+
+var vendorDirectFulfillmentSubmitShipmentConfirmationsSpec = toolSpec{
+	Name:        "vendorDirectFulfillment.submitShipmentConfirmations",
+	Title:       "Vendor Direct Fulfillment",
+	Description: "Confirm shipment of Direct Fulfillment orders.",
+	Guidance:    "Each document is a ShipmentConfirmation (purchaseOrderNumber, shipmentDetails, sellingParty, shipFromParty, items, containers). Requires SP_API_ENABLE_WRITES=true.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("documents", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("ShipmentConfirmation objects to submit.")),
+	},
+}
+
+var vendorInvoicesSubmitDirectFulfillmentInvoicesSpec = toolSpec{
+	Name:        "vendorInvoices.submitDirectFulfillmentInvoices",
+	Title:       "Vendor Invoices",
+	Description: "Submit invoices for Direct Fulfillment orders.",
+	Guidance:    "Each document is an InvoiceDetail following the Direct Fulfillment Payments API model. Requires SP_API_ENABLE_WRITES=true.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("documents", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("InvoiceDetail objects to submit.")),
+	},
+}
+
+var ordersConfirmShipmentSpec = toolSpec{
+	Name:        "orders.confirmShipment",
+	Title:       "Order Processing",
+	Description: "Confirm shipment of a merchant-fulfilled order with package and carrier details.",
+	Guidance:    "Calls the Orders API confirmShipment operation. shipDate defaults to now. Requires SP_API_ENABLE_WRITES=true unless dryRun is set, which returns the request without sending it.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace the order was placed in.")),
+		mcp.WithString("packageReferenceId", mcp.Required(), mcp.Description("Seller-defined identifier for the package, unique within the order.")),
+		mcp.WithString("carrierCode", mcp.Required(), mcp.Description("Carrier code such as UPS, USPS or Other.")),
+		mcp.WithString("carrierName", mcp.Description("Carrier name; required when carrierCode is Other.")),
+		mcp.WithString("shippingMethod", mcp.Description("Carrier service used for the package.")),
+		mcp.WithString("trackingNumber", mcp.Required(), mcp.Description("Carrier tracking number.")),
+		mcp.WithString("shipDate", mcp.Description("ISO 8601 ship timestamp; defaults to now.")),
+		mcp.WithString("shipFromSupplySourceId", mcp.Description("Supply source the package ships from, for Seller Fulfilled Prime and multi-location sellers.")),
+		mcp.WithArray("orderItems", mcp.Required(), mcp.Items(map[string]any{"type": "object"}), mcp.Description("Items in the package: orderItemId, quantity and optional transparencyCodes.")),
+		mcp.WithString("codCollectionMethod", mcp.Enum("DirectPayment"), mcp.Description("Cash on delivery collection method, where applicable.")),
+	},
+}
+
+var ordersUpdateShipmentStatusSpec = toolSpec{
+	Name:        "orders.updateShipmentStatus",
+	Title:       "Order Processing",
+	Description: "Update the pickup status of an order shipment.",
+	Guidance:    "Used for in-store and locker pickup flows. Omit orderItems to update the whole order. Requires SP_API_ENABLE_WRITES=true unless dryRun is set.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
+		mcp.WithString("marketplaceId", mcp.Required(), mcp.Description("Marketplace the order was placed in.")),
+		mcp.WithString("shipmentStatus", mcp.Required(), mcp.Enum("ReadyForPickup", "PickedUp", "RefusedPickup"), mcp.Description("New shipment status.")),
+		mcp.WithArray("orderItems", mcp.Items(map[string]any{"type": "object"}), mcp.Description("Optional items to update: orderItemId and quantity.")),
+	},
+}
+
+var ordersGetOrderRegulatedInfoSpec = toolSpec{
+	Name:        "orders.getOrderRegulatedInfo",
+	Title:       "Order Processing",
+	Description: "Retrieve regulated information and verification status for an order.",
+	Guidance:    "Returns the regulated fields, whether a dosage label is required and the valid rejection reasons to use with orders.updateVerificationStatus.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
+	},
+}
+
+var ordersUpdateVerificationStatusSpec = toolSpec{
+	Name:        "orders.updateVerificationStatus",
+	Title:       "Order Processing",
+	Description: "Approve or reject the regulated information on an order.",
+	Guidance:    "rejectionReasonId is required when rejecting; take it from orders.getOrderRegulatedInfo. Requires SP_API_ENABLE_WRITES=true unless dryRun is set.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
+		mcp.WithString("status", mcp.Required(), mcp.Enum("Approved", "Rejected"), mcp.Description("Verification decision.")),
+		mcp.WithString("validUntil", mcp.Description("ISO 8601 timestamp until which the approval is valid.")),
+		mcp.WithString("rejectionReasonId", mcp.Description("Rejection reason identifier; required when status is Rejected.")),
+		mcp.WithObject("verificationDetails", mcp.Description("Additional verification details, such as prescriptionDetail.")),
+	},
+}
+
+var ordersGetOrderItemsApprovalsSpec = toolSpec{
+	Name:        "orders.getOrderItemsApprovals",
+	Title:       "Order Processing",
+	Description: "List approval requests for order items, such as tire installation or substitutions.",
+	Guidance:    "Filter by approval type and status, and page with nextToken.",
+	RateLimit:   "orders.getOrderItemsApprovals",
+	Options: withPagingOptions(
+		mcp.WithString("amazonOrderId", mcp.Required(), mcp.Description("Amazon order identifier (e.g. 123-1234567-1234567).")),
+		mcp.WithString("marketplaceId", mcp.Description("Marketplace the order was placed in.")),
+		mcp.WithArray("itemApprovalTypes", mcp.WithStringItems(), mcp.Description("Optional approval types to include.")),
+		mcp.WithArray("itemApprovalStatus", mcp.WithStringItems(), mcp.Description("Optional approval statuses to include.")),
+		mcp.WithString("nextToken", mcp.Description("Token from a previous page.")),
+	),
+}
+
+var syncOrdersSpec = toolSpec{
+	Name:        "sync.orders",
+	Title:       "Local Order Store",
+	Description: "Pull orders changed since the last sync into the local order store, one watermark per marketplace.",
+	Guidance:    "Run before store.queryOrders. The first sync starts from since (default 30 days ago); later syncs only fetch orders updated after the stored watermark. Each page is saved as it arrives. When a run hits maxPages or fails part way it reports complete=false or an error, and the next call resumes where it stopped.",
+	RateLimit:   "orders.getOrders",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplaces to sync.")),
+		mcp.WithString("since", mcp.Description("ISO 8601 start for marketplaces that have never synced. Default: 30 days ago.")),
+		mcp.WithBoolean("includeItems", mcp.Description("Also mirror order items, which SKU filters need. Costs one rate-limited call per order. Default: true.")),
+		mcp.WithNumber("maxPages", mcp.Description("Stop each marketplace after this many GetOrders pages (default 50, max 100).")),
+	},
+}
+
+var storeQueryOrdersSpec = toolSpec{
+	Name:        "store.queryOrders",
+	Title:       "Local Order Store",
+	Description: "Query orders in the local order store without calling SP-API.",
+	Guidance:    "Answers reflect the last sync.orders run; syncStatus shows each marketplace's watermark. matched and totalsByCurrency cover every match, while orders holds the newest up to limit.",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("marketplaceIds", mcp.WithStringItems(), mcp.Description("Optional marketplaces to include.")),
+		mcp.WithArray("orderStatuses", mcp.WithStringItems(), mcp.Description("Optional order statuses (e.g. Shipped, Unshipped, Canceled).")),
+		mcp.WithString("fulfillmentChannel", mcp.Description("Optional fulfillment channel: AFN or MFN.")),
+		mcp.WithString("sellerSku", mcp.Description("Only orders containing this SKU. Requires items to have been synced.")),
+		mcp.WithString("purchasedAfter", mcp.Description("ISO 8601 lower bound on purchase date (inclusive).")),
+		mcp.WithString("purchasedBefore", mcp.Description("ISO 8601 upper bound on purchase date (exclusive).")),
+		mcp.WithString("shipState", mcp.Description("Ship-to state or region, case-insensitive.")),
+		mcp.WithNumber("minTotal", mcp.Description("Minimum order total.")),
+		mcp.WithNumber("maxTotal", mcp.Description("Maximum order total.")),
+		mcp.WithNumber("limit", mcp.Description("Orders to return, newest first (default 100, max 1000).")),
+		mcp.WithBoolean("includeItems", mcp.Description("Include mirrored order items with each order. Default: false.")),
+	},
+}
+
+var syncInventorySpec = toolSpec{
+	Name:        "sync.inventory",
+	Title:       "Local Order Store",
+	Description: "Snapshot FBA inventory summaries for each marketplace into the local store.",
+	Guidance:    "Each complete run replaces the marketplace's inventory rows, so SKUs no longer in FBA drop out. Query the result with analytics.sql.",
+	RateLimit:   "fbaInventory.getInventorySummaries",
+	Options: []mcp.ToolOption{
+		mcp.WithArray("marketplaceIds", mcp.Required(), mcp.WithStringItems(), mcp.Description("Marketplaces to snapshot.")),
+		mcp.WithNumber("maxPages", mcp.Description("Stop each marketplace after this many pages of 50 SKUs (default 50, max 100). A truncated snapshot is merged instead of replacing.")),
+	},
+}
+
+var syncFinancialEventsSpec = toolSpec{
+	Name:        "sync.financialEvents",
+	Title:       "Local Order Store",
+	Description: "Copy Finances API events (shipment charges and fees, refunds, service fees, adjustments) into the local store.",
+	Guidance:    "Without postedAfter the sync continues from the last complete run (first run: 30 days back). Events in the synced range are replaced, so re-running a range never double counts.",
+	RateLimit:   "finances.listFinancialEvents",
+	Options: []mcp.ToolOption{
+		mcp.WithString("postedAfter", mcp.Description("ISO 8601 start of the posted-date range. Default: the stored watermark.")),
+		mcp.WithString("postedBefore", mcp.Description("ISO 8601 end of the range; at least two minutes ago. Default: two minutes ago.")),
+		mcp.WithNumber("maxPages", mcp.Description("Stop after this many pages (default 50, max 100). An incomplete run does not move the watermark.")),
+	},
+}
+
+var storeImportReportSpec = toolSpec{
+	Name:        "store.importReport",
+	Title:       "Local Order Store",
+	Description: "Download a tab- or comma-delimited report document and store its rows for analytics.sql.",
+	Guidance:    "Use once reports.getReport shows DONE. Rows land in report_rows as JSON keyed by column header; importing the same document again replaces its rows.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("reportDocumentId", mcp.Required(), mcp.Description("Report document identifier from reports.getReport.")),
+		mcp.WithString("reportType", mcp.Description("Report type to record with the rows (e.g. GET_MERCHANT_LISTINGS_ALL_DATA) so queries can filter on it.")),
+	},
+}
+
+var analyticsSQLSpec = toolSpec{
+	Name:        "analytics.sql",
+	Title:       "Analytics",
+	Description: "Run a read-only SQL SELECT against the local store (orders, order items, FBA inventory, financial events, report rows).",
+	Guidance:    "Read the amazon-sp-api://store/schema resource for tables and columns. SQLite dialect; one SELECT or WITH statement, no semicolons inside it. Results reflect the last sync tools run.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("query", mcp.Required(), mcp.Description("A single SELECT or WITH statement.")),
+		mcp.WithNumber("maxRows", mcp.Description("Rows to return (default 200, max 5000).")),
+		mcp.WithNumber("timeoutSeconds", mcp.Description("Statement timeout in seconds (default 10, max 60).")),
+	},
+}
+
+var adminQueryAuditLogSpec = toolSpec{
+	Name:        "admin.queryAuditLog",
+	Title:       "Administration",
+	Description: "Search the tool call audit log: who called which tool, with what arguments, which SP-API requests it made and how it ended.",
+	Guidance:    "Records come back newest first. Filter by tool pattern (e.g. listings.*), principal, outcome or time range; set mutatingOnly to review changes to listings, shipments and content. Arguments are stored after PII redaction.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("tool", mcp.Description("Tool name or pattern, e.g. orders.confirmShipment or listings.*.")),
+		mcp.WithString("principal", mcp.Description("Authenticated principal name.")),
+		mcp.WithString("outcome", mcp.Enum("ok", "error", "failed"), mcp.Description("Call outcome.")),
+		mcp.WithString("since", mcp.Description("ISO 8601 lower bound (inclusive).")),
+		mcp.WithString("until", mcp.Description("ISO 8601 upper bound (exclusive).")),
+		mcp.WithBoolean("mutatingOnly", mcp.Description("Only return calls to write tools.")),
+		mcp.WithNumber("limit", mcp.Description("Records to return (default 100, max 1000).")),
+	},
+}
+
+var authBeginAuthorizationSpec = toolSpec{
+	Name:        "auth.beginAuthorization",
+	Title:       "Authentication",
+	Description: "Start onboarding a seller: returns the Seller Central consent link that authorizes this app and stores the seller's refresh token as a new credential profile.",
+	Guidance:    "Send consentUrl to the seller. After they approve, Seller Central redirects their browser to this server, which exchanges the code with Login with Amazon and saves the profile. Check progress with auth.getAuthorizationStatus; the link expires after 30 minutes.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("profile", mcp.Required(), mcp.Description("Name for the new credential profile, e.g. acme-us. An existing name is refused unless overwrite is set.")),
+		mcp.WithString("marketplaceId", mcp.Description("Marketplace whose Seller Central the seller signs in to (default ATVPDKIKX0DER); it also picks the profile's regional endpoint.")),
+		mcp.WithBoolean("draft", mcp.Description("Authorize a draft app that is not yet published (adds version=beta).")),
+		mcp.WithBoolean("overwrite", mcp.Description("Re-authorize an existing profile: its refresh token, seller ID and endpoint are replaced and its other settings kept.")),
+	},
+}
+
+var authGetAuthorizationStatusSpec = toolSpec{
+	Name:        "auth.getAuthorizationStatus",
+	Title:       "Authentication",
+	Description: "Report whether a seller authorization started with auth.beginAuthorization is pending, completed, failed or expired.",
+	Options: []mcp.ToolOption{
+		mcp.WithString("state", mcp.Required(), mcp.Description("state returned by auth.beginAuthorization.")),
+	},
+}
+
+var placeholderSpecs = []toolSpec{
 	{
 		Name:        "catalog.lookupItem",
 		Title:       "Catalog",
@@ -217,16 +815,6 @@ var placeholderSpecs = []toolSpec{
 			mcp.WithString("marketplaceId", mcp.Description("Optional marketplace override when looking up inventory.")),
 		},
 	},
-	{
-		Name:        "reports.createReport",
-		Title:       "Reports",
-		Description: "Submit an asynchronous report creation request.",
-		Guidance:    "Leverage the Reports API createReport operation and monitor the resulting report document until it is ready for download.",
-		Options: []mcp.ToolOption{
-			mcp.WithString("reportType", mcp.Required(), mcp.Description("Report type identifier, e.g. GET_FLAT_FILE_ALL_ORDERS_DATA_BY_LAST_UPDATE.")),
-			mcp.WithArray("marketplaceIds", mcp.Description("Optional list of marketplaces to include."), mcp.WithStringItems()),
-		},
-	},
 	{
 		Name:        "feeds.submitFeed",
 		Title:       "Feed Submission",
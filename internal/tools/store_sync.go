@@ -0,0 +1,440 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amzapi/selling-partner-api-sdk/fbaInventory"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
+)
+
+const (
+	financialEventsDataset       = "financialEvents"
+	financesEventsPath           = "/finances/v0/financialEvents"
+	maxImportedReportRows        = 200000
+	defaultFinancesPageSize      = 100
+	defaultInventorySyncMaxPages = 50
+)
+
+type syncInventoryArgs struct {
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	MaxPages       *int     `json:"maxPages"`
+}
+
+type syncInventoryMarketplaceResult struct {
+	MarketplaceID string `json:"marketplaceId"`
+	Summaries     int    `json:"summaries"`
+	Pages         int    `json:"pages"`
+	Complete      bool   `json:"complete"`
+}
+
+type syncInventoryResult struct {
+	Marketplaces []syncInventoryMarketplaceResult `json:"marketplaces"`
+	RetrievedAt  time.Time                        `json:"retrievedAt"`
+}
+
+type syncFinancialEventsArgs struct {
+	PostedAfter  string `json:"postedAfter"`
+	PostedBefore string `json:"postedBefore"`
+	MaxPages     *int   `json:"maxPages"`
+}
+
+type syncFinancialEventsResult struct {
+	PostedAfter  time.Time `json:"postedAfter"`
+	PostedBefore time.Time `json:"postedBefore"`
+	Rows         int       `json:"rows"`
+	Pages        int       `json:"pages"`
+	Complete     bool      `json:"complete"`
+	Watermark    time.Time `json:"watermark"`
+	RetrievedAt  time.Time `json:"retrievedAt"`
+}
+
+type storeImportReportArgs struct {
+	ReportDocumentID string `json:"reportDocumentId"`
+	ReportType       string `json:"reportType"`
+}
+
+type storeImportReportResult struct {
+	ReportDocumentID string    `json:"reportDocumentId"`
+	ReportType       string    `json:"reportType,omitempty"`
+	Columns          []string  `json:"columns"`
+	Rows             int       `json:"rows"`
+	RetrievedAt      time.Time `json:"retrievedAt"`
+}
+
+func newStoreSyncTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+	localStore := deps.Store
+
+	syncInventoryHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args syncInventoryArgs) (*mcp.CallToolResult, error) {
+		return executeSyncInventory(withoutResponseCache(withProgressToken(ctx, req)), args, spClient, localStore)
+	})
+
+	syncFinancialEventsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args syncFinancialEventsArgs) (*mcp.CallToolResult, error) {
+		return executeSyncFinancialEvents(withProgressToken(ctx, req), args, spClient, localStore)
+	})
+
+	importReportHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args storeImportReportArgs) (*mcp.CallToolResult, error) {
+		return executeStoreImportReport(ctx, args, spClient, localStore)
+	})
+
+	return []registeredTool{
+		toolFromSpec(syncInventorySpec, syncInventoryHandler),
+		toolFromSpec(syncFinancialEventsSpec, syncFinancialEventsHandler),
+		toolFromSpec(storeImportReportSpec, importReportHandler),
+	}
+}
+
+// executeSyncInventory snapshots FBA inventory per marketplace. A complete snapshot replaces the marketplace's rows so
+// SKUs that left FBA disappear; a truncated one only upserts what it saw.
+func executeSyncInventory(ctx context.Context, args syncInventoryArgs, spClient spapi.Client, localStore *store.Store) (*mcp.CallToolResult, error) {
+	if localStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	client, failure := ensureFBAInventoryClient(spClient)
+	if failure != nil {
+		return failure, nil
+	}
+
+	marketplaces := trimStringSlice(args.MarketplaceIDs)
+	if len(marketplaces) == 0 {
+		return mcp.NewToolResultError("marketplaceIds is required"), nil
+	}
+
+	maxPages := sanitizePagingCap(args.MaxPages, defaultInventorySyncMaxPages, maxPagingMaxPages)
+	details := true
+
+	result := syncInventoryResult{Marketplaces: make([]syncInventoryMarketplaceResult, 0, len(marketplaces))}
+	for _, marketplaceID := range marketplaces {
+		params := fbaInventory.GetInventorySummariesParams{
+			GranularityType: "Marketplace",
+			GranularityId:   marketplaceID,
+			Details:         &details,
+			MarketplaceIds:  []string{marketplaceID},
+		}
+
+		fetch := func(ctx context.Context, token string) ([]fbaInventory.InventorySummary, string, *mcp.CallToolResult) {
+			pageParams := params
+			pageParams.NextToken = stringPtr(token)
+			decoded, failure := fetchInventorySummariesPage(ctx, client, &pageParams)
+			if failure != nil {
+				return nil, "", failure
+			}
+			return decoded.inventorySummaries, decoded.nextToken, nil
+		}
+
+		summaries, _, paging, failure := collectPages(ctx, pagedOperation{tool: syncInventorySpec.Name, operation: syncInventorySpec.RateLimit}, pagingArgs{AllPages: true, MaxPages: &maxPages, MaxItems: intPtr(maxPagingMaxItems)}, "", fetch)
+		if failure != nil {
+			return failure, nil
+		}
+
+		records := make([]store.InventorySummary, 0, len(summaries))
+		for _, summary := range summaries {
+			if record, ok := storeInventoryFromAPI(summary, marketplaceID); ok {
+				records = append(records, record)
+			}
+		}
+
+		save := localStore.ReplaceInventory
+		if paging.Truncated {
+			save = localStore.SaveInventory
+		}
+		if err := save(ctx, marketplaceID, records, time.Now().UTC()); err != nil {
+			return mcp.NewToolResultErrorFromErr("sync.inventory could not save inventory", err), nil
+		}
+
+		result.Marketplaces = append(result.Marketplaces, syncInventoryMarketplaceResult{
+			MarketplaceID: marketplaceID,
+			Summaries:     len(records),
+			Pages:         paging.Pages,
+			Complete:      !paging.Truncated,
+		})
+	}
+
+	result.RetrievedAt = time.Now().UTC()
+
+	parts := make([]string, 0, len(result.Marketplaces))
+	for _, summary := range result.Marketplaces {
+		part := fmt.Sprintf("%s: %d SKUs", summary.MarketplaceID, summary.Summaries)
+		if !summary.Complete {
+			part += " (incomplete, raise maxPages)"
+		}
+		parts = append(parts, part)
+	}
+
+	return mcp.NewToolResultStructured(result, "Synced inventory for "+strings.Join(parts, "; ")), nil
+}
+
+// executeSyncFinancialEvents replaces the stored events for one posted-date range. Without postedAfter the range starts
+// at the last complete sync, so repeated calls stay incremental; an incomplete run leaves the watermark in place.
+func executeSyncFinancialEvents(ctx context.Context, args syncFinancialEventsArgs, spClient spapi.Client, localStore *store.Store) (*mcp.CallToolResult, error) {
+	if localStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	state, err := localStore.DatasetState(ctx, financialEventsDataset)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("sync.financialEvents could not read sync state", err), nil
+	}
+
+	now := time.Now().UTC()
+	after := state.Watermark
+	if after.IsZero() {
+		after = now.Add(-defaultSyncLookback).Truncate(time.Second)
+	}
+	if raw := strings.TrimSpace(args.PostedAfter); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return mcp.NewToolResultError("postedAfter must be an ISO 8601 timestamp"), nil
+		}
+		after = parsed.UTC()
+	}
+
+	before := now.Add(-ordersCreatedBeforeMinDelay).Truncate(time.Second)
+	if raw := strings.TrimSpace(args.PostedBefore); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return mcp.NewToolResultError("postedBefore must be an ISO 8601 timestamp"), nil
+		}
+		if parsed.After(before) {
+			return mcp.NewToolResultError("postedBefore must be at least two minutes in the past"), nil
+		}
+		before = parsed.UTC()
+	}
+	if !after.Before(before) {
+		return mcp.NewToolResultError("postedAfter must be before postedBefore"), nil
+	}
+
+	maxPages := sanitizePagingCap(args.MaxPages, defaultSyncMaxPages, maxPagingMaxPages)
+
+	fetch := func(ctx context.Context, token string) ([]financesEventsDTO, string, *mcp.CallToolResult) {
+		query := url.Values{}
+		query.Set("MaxResultsPerPage", strconv.Itoa(defaultFinancesPageSize))
+		if token != "" {
+			query.Set("NextToken", token)
+		} else {
+			query.Set("PostedAfter", after.Format(time.RFC3339))
+			query.Set("PostedBefore", before.Format(time.RFC3339))
+		}
+
+		resp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{Method: http.MethodGet, Path: financesEventsPath, Query: query})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("finances.listFinancialEvents request failed", err)
+		}
+
+		decoded, err := decodeFinancesListFinancialEvents(body)
+		if err != nil {
+			if apiErr := ensureSellingPartnerAPIResponse("listFinancialEvents", resp, body, nil); apiErr != nil {
+				return nil, "", mcp.NewToolResultError(apiErr.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode finances.listFinancialEvents response", err)
+		}
+		if err := ensureSellingPartnerAPIResponse("listFinancialEvents", resp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("finances.listFinancialEvents response payload is empty")
+		}
+
+		return []financesEventsDTO{decoded.events}, decoded.nextToken, nil
+	}
+
+	pages, _, paging, failure := collectPages(ctx, pagedOperation{tool: syncFinancialEventsSpec.Name, operation: syncFinancialEventsSpec.RateLimit}, pagingArgs{AllPages: true, MaxPages: &maxPages}, "", fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	var rows []store.FinancialEvent
+	for _, page := range pages {
+		rows = append(rows, flattenFinancialEvents(page, after)...)
+	}
+
+	if err := localStore.ReplaceFinancialEvents(ctx, after, before, rows); err != nil {
+		return mcp.NewToolResultErrorFromErr("sync.financialEvents could not save events", err), nil
+	}
+
+	result := syncFinancialEventsResult{
+		PostedAfter:  after,
+		PostedBefore: before,
+		Rows:         len(rows),
+		Pages:        paging.Pages,
+		Complete:     !paging.Truncated,
+		Watermark:    state.Watermark,
+	}
+
+	// Only a contiguous, complete range may move the watermark; an explicit older range just refreshes history.
+	if result.Complete && (state.Watermark.IsZero() || !after.After(state.Watermark)) && before.After(state.Watermark) {
+		state.Watermark = before
+		result.Watermark = before
+	}
+	state.LastSyncedAt = time.Now().UTC()
+	if err := localStore.SaveDatasetState(ctx, state); err != nil {
+		return mcp.NewToolResultErrorFromErr("sync.financialEvents could not save sync state", err), nil
+	}
+
+	result.RetrievedAt = time.Now().UTC()
+
+	fallback := fmt.Sprintf("Stored %d financial event rows posted %s to %s", result.Rows, after.Format(time.RFC3339), before.Format(time.RFC3339))
+	if !result.Complete {
+		fallback += "; the range has more pages than maxPages allows, narrow it with postedBefore or raise maxPages"
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeStoreImportReport(ctx context.Context, args storeImportReportArgs, spClient spapi.Client, localStore *store.Store) (*mcp.CallToolResult, error) {
+	if localStore == nil {
+		return mcp.NewToolResultError(storeDisabledMessage), nil
+	}
+
+	client, failure := ensureReportsClient(spClient)
+	if failure != nil {
+		return failure, nil
+	}
+
+	documentID := strings.TrimSpace(args.ReportDocumentID)
+	if documentID == "" {
+		return mcp.NewToolResultError("reportDocumentId is required"), nil
+	}
+
+	document, failure := fetchReportDocument(ctx, client, documentID)
+	if failure != nil {
+		return failure, nil
+	}
+
+	data, err := downloadDocument(ctx, document.url)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("store.importReport could not download the report", err), nil
+	}
+
+	columns, rows, err := parseDelimitedReport(data)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("store.importReport could not parse the report", err), nil
+	}
+
+	reportType := strings.TrimSpace(args.ReportType)
+	if err := localStore.ImportReportRows(ctx, documentID, reportType, columns, rows, time.Now().UTC()); err != nil {
+		return mcp.NewToolResultErrorFromErr("store.importReport could not save the report", err), nil
+	}
+
+	result := storeImportReportResult{
+		ReportDocumentID: documentID,
+		ReportType:       reportType,
+		Columns:          columns,
+		Rows:             len(rows),
+		RetrievedAt:      time.Now().UTC(),
+	}
+
+	return mcp.NewToolResultStructured(result, fmt.Sprintf("Imported %d rows with %d columns from report document %s", result.Rows, len(columns), documentID)), nil
+}
+
+// parseDelimitedReport reads a flat-file report: a header row followed by data rows, separated by tabs (most SP-API
+// reports) or commas. JSON and XML reports are rejected rather than stored as one opaque row.
+func parseDelimitedReport(data []byte) ([]string, [][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil, fmt.Errorf("report is empty")
+	}
+	if trimmed[0] == '{' || trimmed[0] == '[' || trimmed[0] == '<' {
+		return nil, nil, fmt.Errorf("only tab- or comma-delimited reports can be imported; use reports.getReportDocument for JSON or XML reports")
+	}
+
+	header := trimmed
+	if end := bytes.IndexByte(header, '\n'); end >= 0 {
+		header = header[:end]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = ','
+	if bytes.Contains(header, []byte{'\t'}) {
+		reader.Comma = '\t'
+	}
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading header: %w", err)
+	}
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading row %d: %w", len(rows)+1, err)
+		}
+		if len(rows) == maxImportedReportRows {
+			return nil, nil, fmt.Errorf("report has more than %d rows", maxImportedReportRows)
+		}
+		rows = append(rows, record)
+	}
+
+	return columns, rows, nil
+}
+
+func storeInventoryFromAPI(summary fbaInventory.InventorySummary, marketplaceID string) (store.InventorySummary, bool) {
+	sku := valueOrEmpty(summary.SellerSku)
+	if sku == "" {
+		return store.InventorySummary{}, false
+	}
+
+	record := store.InventorySummary{
+		MarketplaceID: marketplaceID,
+		SellerSKU:     sku,
+		ASIN:          valueOrEmpty(summary.Asin),
+		FnSKU:         valueOrEmpty(summary.FnSku),
+		ProductName:   valueOrEmpty(summary.ProductName),
+		Condition:     valueOrEmpty(summary.Condition),
+		TotalQuantity: intValue(summary.TotalQuantity),
+	}
+	if summary.LastUpdatedTime != nil {
+		record.LastUpdatedTime = summary.LastUpdatedTime.UTC()
+	}
+	if details := summary.InventoryDetails; details != nil {
+		record.FulfillableQuantity = intValue(details.FulfillableQuantity)
+		record.InboundWorkingQuantity = intValue(details.InboundWorkingQuantity)
+		record.InboundShippedQuantity = intValue(details.InboundShippedQuantity)
+		record.InboundReceivingQuantity = intValue(details.InboundReceivingQuantity)
+		if details.ReservedQuantity != nil {
+			record.ReservedQuantity = intValue(details.ReservedQuantity.TotalReservedQuantity)
+		}
+		if details.UnfulfillableQuantity != nil {
+			record.UnfulfillableQuantity = intValue(details.UnfulfillableQuantity.TotalUnfulfillableQuantity)
+		}
+	}
+	if raw, err := json.Marshal(summary); err == nil {
+		record.Raw = raw
+	}
+	return record, true
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
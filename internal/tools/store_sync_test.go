@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDelimitedReport(t *testing.T) {
+	columns, rows, err := parseDelimitedReport([]byte("\xef\xbb\xbfsku\tasin\tprice\nSKU-1\tB000000001\t9.99\nSKU-2\tB000000002\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(columns) != 3 || columns[0] != "sku" || len(rows) != 2 || rows[1][0] != "SKU-2" {
+		t.Fatalf("unexpected parse %v %v", columns, rows)
+	}
+
+	if _, _, err := parseDelimitedReport([]byte(`{"reportSpecification":{}}`)); err == nil {
+		t.Fatalf("expected JSON reports to be rejected")
+	}
+}
+
+func TestFlattenFinancialEvents(t *testing.T) {
+	fallback := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := financesEventsDTO{
+		ShipmentEventList: []financesShipmentEventDTO{{
+			AmazonOrderID: "111",
+			PostedDate:    "2026-01-02T00:00:00Z",
+			ShipmentItemList: []financesShipmentItemDTO{{
+				SellerSKU:       "SKU-1",
+				QuantityShipped: 2,
+				ItemChargeList:  []financesChargeDTO{{ChargeType: "Principal", ChargeAmount: &financesCurrencyDTO{CurrencyCode: "USD", CurrencyAmount: 20}}},
+				ItemFeeList:     []financesFeeDTO{{FeeType: "Commission", FeeAmount: &financesCurrencyDTO{CurrencyCode: "USD", CurrencyAmount: -3}}},
+			}},
+		}},
+		ServiceFeeEventList: []financesServiceFeeEventDTO{{
+			FeeList: []financesFeeDTO{{FeeType: "Subscription", FeeAmount: &financesCurrencyDTO{CurrencyCode: "USD", CurrencyAmount: -39.99}}},
+		}},
+	}
+
+	rows := flattenFinancialEvents(events, fallback)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %+v", rows)
+	}
+	if rows[0].AmountKind != "charge" || rows[0].Amount != 20 || rows[0].SellerSKU != "SKU-1" || rows[0].Quantity != 2 {
+		t.Fatalf("unexpected charge row %+v", rows[0])
+	}
+	if rows[1].AmountKind != "fee" || rows[1].Amount != -3 {
+		t.Fatalf("unexpected fee row %+v", rows[1])
+	}
+	if rows[2].EventType != "ServiceFee" || !rows[2].PostedDate.Equal(fallback) {
+		t.Fatalf("expected service fee to take the fallback date, got %+v", rows[2])
+	}
+}
@@ -4,16 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/audit"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/onboarding"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/redact"
 	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/store"
 )
 
 // Dependencies carries the external clients that tool handlers can leverage.
 type Dependencies struct {
 	SellingPartner spapi.Client
+	// AllowWrites gates tools that change seller data; see newWriteTool.
+	AllowWrites bool
+	// SellerID is the default sellerId for APIs that require one when the caller does not pass it.
+	SellerID string
+	// Vendor is true when the credentials belong to a Vendor Central account; it selects vendor tools over seller ones.
+	Vendor bool
+	// Store is the local order mirror; nil when SP_API_STORE_PATH is unset.
+	Store *store.Store
+	// UploadDir is the only directory uploads.uploadFile reads from; empty leaves the tool out.
+	UploadDir string
+	// DryRun makes every write-capable tool return its request preview instead of sending it.
+	DryRun bool
+	// Transport carries SP-API requests in place of http.DefaultTransport, such as an spapi.Cassette; nil uses the
+	// default.
+	Transport http.RoundTripper
+	// ResponseCache serves repeated SP-API reads; nil disables caching.
+	ResponseCache *ResponseCache
+	// Redactor removes buyer PII from every tool result; nil disables redaction.
+	Redactor *redact.Redactor
+	// AuditLog answers admin.queryAuditLog; nil when auditing is off or the sink cannot be read back.
+	AuditLog audit.Querier
+	// Onboarding backs auth.beginAuthorization; nil when seller authorization is not configured.
+	Onboarding *onboarding.Flow
+	// EnabledDomains limits registration to these tool domains, such as "orders"; empty enables every domain.
+	EnabledDomains []string
+	// DisabledDomains removes domains after EnabledDomains is applied.
+	DisabledDomains []string
 }
 
 type toolSpec struct {
@@ -21,16 +53,30 @@ type toolSpec struct {
 	Title       string
 	Description string
 	Guidance    string
-	Options     []mcp.ToolOption
+	// Mutating marks tools that change seller data so clients see accurate annotations and the write gate applies.
+	Mutating bool
+	// SideEffects marks tools whose requests start work in SP-API, such as a report, without changing seller data.
+	// They are annotated as neither read-only nor idempotent, but not as destructive; see newDryRunTool.
+	SideEffects bool
+	// RateLimit is the defaultRateLimits key of the SP-API operation the tool pages through, if any.
+	RateLimit string
+	Options   []mcp.ToolOption
 }
 
-func serverToolFromSpec(spec toolSpec, handler server.ToolHandlerFunc) server.ServerTool {
+// registeredTool is a built tool together with the spec it was built from, so the registry can classify it.
+type registeredTool struct {
+	server.ServerTool
+	spec toolSpec
+}
+
+func toolFromSpec(spec toolSpec, handler server.ToolHandlerFunc) registeredTool {
+	readOnly := !spec.Mutating && !spec.SideEffects
 	options := []mcp.ToolOption{
 		mcp.WithDescription(spec.Description),
 		mcp.WithTitleAnnotation(spec.Title),
-		mcp.WithReadOnlyHintAnnotation(true),
-		mcp.WithDestructiveHintAnnotation(false),
-		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithReadOnlyHintAnnotation(readOnly),
+		mcp.WithDestructiveHintAnnotation(spec.Mutating),
+		mcp.WithIdempotentHintAnnotation(readOnly),
 		mcp.WithOpenWorldHintAnnotation(true),
 	}
 
@@ -38,14 +84,17 @@ func serverToolFromSpec(spec toolSpec, handler server.ToolHandlerFunc) server.Se
 
 	tool := mcp.NewTool(spec.Name, options...)
 
-	return server.ServerTool{
-		Tool:    tool,
-		Handler: handler,
+	return registeredTool{
+		ServerTool: server.ServerTool{
+			Tool:    tool,
+			Handler: handler,
+		},
+		spec: spec,
 	}
 }
 
-func newPlaceholderTool(spec toolSpec, deps Dependencies) server.ServerTool {
-	return serverToolFromSpec(spec, placeholderHandler(spec, deps))
+func newPlaceholderTool(spec toolSpec, deps Dependencies) registeredTool {
+	return toolFromSpec(spec, placeholderHandler(spec, deps))
 }
 
 func placeholderHandler(spec toolSpec, _ Dependencies) server.ToolHandlerFunc {
@@ -0,0 +1,364 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/amzapi/selling-partner-api-sdk/uploads"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+// maxUploadBytes bounds local files sent through uploads.uploadFile.
+const maxUploadBytes = 50 << 20
+
+type uploadsCreateUploadDestinationArgs struct {
+	Resource       string   `json:"resource"`
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	ContentMD5     string   `json:"contentMD5"`
+	ContentType    string   `json:"contentType"`
+}
+
+type uploadsCreateUploadDestinationResult struct {
+	UploadDestinationID string            `json:"uploadDestinationId"`
+	URL                 string            `json:"url"`
+	Headers             map[string]string `json:"headers,omitempty"`
+	RetrievedAt         time.Time         `json:"retrievedAt"`
+}
+
+type uploadsUploadFileArgs struct {
+	FilePath       string   `json:"filePath"`
+	Resource       string   `json:"resource"`
+	MarketplaceIDs []string `json:"marketplaceIds"`
+	ContentType    string   `json:"contentType"`
+}
+
+type uploadsUploadFileResult struct {
+	UploadDestinationID string    `json:"uploadDestinationId"`
+	Resource            string    `json:"resource"`
+	FileName            string    `json:"fileName"`
+	ContentType         string    `json:"contentType"`
+	ContentMD5          string    `json:"contentMD5"`
+	SizeBytes           int       `json:"sizeBytes"`
+	RetrievedAt         time.Time `json:"retrievedAt"`
+}
+
+type uploadDestination struct {
+	id      string
+	url     string
+	headers map[string]string
+}
+
+func newUploadsTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	createUploadDestinationHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args uploadsCreateUploadDestinationArgs) (*mcp.CallToolResult, error) {
+		return executeUploadsCreateUploadDestination(ctx, args, spClient)
+	})
+
+	return []registeredTool{
+		newWriteTool(uploadsCreateUploadDestinationSpec, deps, createUploadDestinationHandler),
+	}
+}
+
+// newUploadFileTools is registered apart from newUploadsTools because it reads local files, which needs
+// SP_API_UPLOAD_DIR.
+func newUploadFileTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+	uploadDir := deps.UploadDir
+
+	uploadFileHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args uploadsUploadFileArgs) (*mcp.CallToolResult, error) {
+		return executeUploadsUploadFile(ctx, args, uploadDir, spClient)
+	})
+
+	return []registeredTool{
+		newWriteTool(uploadsUploadFileSpec, deps, uploadFileHandler),
+	}
+}
+
+func executeUploadsCreateUploadDestination(ctx context.Context, args uploadsCreateUploadDestinationArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	client, failure := ensureUploadsClient(spClient)
+	if failure != nil {
+		return failure, nil
+	}
+
+	resource := strings.Trim(strings.TrimSpace(args.Resource), "/")
+	if resource == "" {
+		return mcp.NewToolResultError("resource is required"), nil
+	}
+
+	marketplaces := trimStringSlice(args.MarketplaceIDs)
+	if len(marketplaces) != 1 {
+		return mcp.NewToolResultError("marketplaceIds must contain exactly one marketplace"), nil
+	}
+
+	contentMD5 := strings.TrimSpace(args.ContentMD5)
+	if contentMD5 == "" {
+		return mcp.NewToolResultError("contentMD5 is required"), nil
+	}
+
+	destination, err := createUploadDestination(ctx, client, resource, marketplaces, contentMD5, strings.TrimSpace(args.ContentType))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := uploadsCreateUploadDestinationResult{
+		UploadDestinationID: destination.id,
+		URL:                 destination.url,
+		Headers:             destination.headers,
+		RetrievedAt:         time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Created upload destination %s for %s; PUT the file to url with the returned headers", result.UploadDestinationID, resource)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeUploadsUploadFile(ctx context.Context, args uploadsUploadFileArgs, uploadDir string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	client, failure := ensureUploadsClient(spClient)
+	if failure != nil {
+		return failure, nil
+	}
+
+	filePath := strings.TrimSpace(args.FilePath)
+	if filePath == "" {
+		return mcp.NewToolResultError("filePath is required"), nil
+	}
+
+	resource := strings.Trim(strings.TrimSpace(args.Resource), "/")
+	if resource == "" {
+		return mcp.NewToolResultError("resource is required"), nil
+	}
+
+	marketplaces := trimStringSlice(args.MarketplaceIDs)
+	if len(marketplaces) != 1 {
+		return mcp.NewToolResultError("marketplaceIds must contain exactly one marketplace"), nil
+	}
+
+	filePath, err := resolveUploadPath(uploadDir, filePath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	content, err := readUploadFile(filePath)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	contentType := strings.TrimSpace(args.ContentType)
+	if contentType == "" {
+		contentType = detectUploadContentType(filePath, content)
+	}
+
+	contentMD5 := contentMD5Base64(content)
+
+	destination, err := createUploadDestination(ctx, client, resource, marketplaces, contentMD5, contentType)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := putUploadContent(ctx, destination, content, contentType, contentMD5); err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to upload file content", err), nil
+	}
+
+	result := uploadsUploadFileResult{
+		UploadDestinationID: destination.id,
+		Resource:            resource,
+		FileName:            filepath.Base(filePath),
+		ContentType:         contentType,
+		ContentMD5:          contentMD5,
+		SizeBytes:           len(content),
+		RetrievedAt:         time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Uploaded %s (%d bytes) to upload destination %s", result.FileName, result.SizeBytes, result.UploadDestinationID)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func createUploadDestination(ctx context.Context, client *uploads.Client, resource string, marketplaces []string, contentMD5, contentType string) (uploadDestination, error) {
+	params := &uploads.CreateUploadDestinationForResourceParams{
+		MarketplaceIds: marketplaces,
+		ContentMD5:     contentMD5,
+		ContentType:    stringPtr(contentType),
+	}
+
+	httpResp, err := client.CreateUploadDestinationForResource(ctx, resource, params)
+	if err != nil {
+		return uploadDestination{}, fmt.Errorf("uploads.createUploadDestinationForResource request failed: %w", err)
+	}
+	if httpResp == nil {
+		return uploadDestination{}, fmt.Errorf("uploads.createUploadDestinationForResource returned no response")
+	}
+
+	body, readErr := io.ReadAll(httpResp.Body)
+	defer httpResp.Body.Close()
+	if readErr != nil {
+		return uploadDestination{}, fmt.Errorf("failed to read uploads.createUploadDestinationForResource response: %w", readErr)
+	}
+
+	decoded, decodeErr := decodeUploadsCreateUploadDestination(body)
+	if decodeErr != nil {
+		if err := ensureUploadsAPIResponse("createUploadDestinationForResource", httpResp, body, nil); err != nil {
+			return uploadDestination{}, err
+		}
+		return uploadDestination{}, fmt.Errorf("failed to decode uploads.createUploadDestinationForResource response: %w", decodeErr)
+	}
+
+	if err := ensureUploadsAPIResponse("createUploadDestinationForResource", httpResp, body, decoded.apiErrors); err != nil {
+		return uploadDestination{}, err
+	}
+
+	if !decoded.payloadPresent || decoded.destination.url == "" {
+		return uploadDestination{}, fmt.Errorf("uploads.createUploadDestinationForResource response payload is empty")
+	}
+
+	return decoded.destination, nil
+}
+
+// putUploadContent sends the file body to the pre-signed destination using the headers Amazon returned.
+func putUploadContent(ctx context.Context, destination uploadDestination, content []byte, contentType, contentMD5 string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, destination.url, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("building upload request: %w", err)
+	}
+
+	req.ContentLength = int64(len(content))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-MD5", contentMD5)
+	for key, value := range destination.headers {
+		req.Header.Set(key, value)
+	}
+
+	// The shared transport lets dry runs, cassettes and the audit trail see the upload like any other request.
+	httpClient := &http.Client{Timeout: 2 * time.Minute, Transport: sellingPartnerTransport}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("upload failed with status %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), sanitizeBodySnippet(body))
+	}
+
+	return nil
+}
+
+// resolveUploadPath confines uploads to uploadDir, so a client cannot have the server read and ship any file it can
+// name. Relative paths are taken from uploadDir, and symlinks are resolved before the check so a link inside the
+// directory cannot point outside it.
+func resolveUploadPath(uploadDir, filePath string) (string, error) {
+	root, err := filepath.Abs(uploadDir)
+	if err != nil {
+		return "", fmt.Errorf("resolving the upload directory: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return "", fmt.Errorf("resolving the upload directory: %w", err)
+	}
+
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(root, filePath)
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(filePath))
+	if err != nil {
+		return "", fmt.Errorf("cannot read %s: %w", filePath, err)
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the upload directory; place the file under SP_API_UPLOAD_DIR", filePath)
+	}
+	return resolved, nil
+}
+
+func readUploadFile(filePath string) ([]byte, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", filePath, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", filePath)
+	}
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("%s is empty", filePath)
+	}
+	if info.Size() > maxUploadBytes {
+		return nil, fmt.Errorf("%s exceeds the %d byte upload limit", filePath, maxUploadBytes)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", filePath, err)
+	}
+	return content, nil
+}
+
+func detectUploadContentType(filePath string, content []byte) string {
+	if byExtension := mime.TypeByExtension(strings.ToLower(filepath.Ext(filePath))); byExtension != "" {
+		return byExtension
+	}
+	return http.DetectContentType(content)
+}
+
+func contentMD5Base64(content []byte) string {
+	sum := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func ensureUploadsClient(spClient spapi.Client) (*uploads.Client, *mcp.CallToolResult) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return nil, failure
+	}
+
+	client, err := buildUploadsClient(spClient)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr("failed to create uploads client", err)
+	}
+
+	return client, nil
+}
+
+func buildUploadsClient(spClient spapi.Client) (*uploads.Client, error) {
+	httpClient := newSellingPartnerHTTPClient()
+
+	return uploads.NewClient(
+		spClient.Endpoint(),
+		uploads.WithHTTPClient(httpClient),
+		uploads.WithRequestBefore(buildUploadsRequestBefore(spClient)),
+	)
+}
+
+func buildUploadsRequestBefore(spClient spapi.Client) uploads.RequestBeforeFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-Amzn-Requestid", uuid.NewString())
+		req.Header.Set("Accept", "application/json")
+		if err := spClient.AuthorizeRequest(req); err != nil {
+			return fmt.Errorf("authorize request: %w", err)
+		}
+		return nil
+	}
+}
+
+func ensureUploadsAPIResponse(operation string, resp *http.Response, body []byte, errors *uploads.ErrorList) error {
+	var list []spAPIError
+	if errors != nil {
+		for _, apiErr := range *errors {
+			list = append(list, spAPIError{Code: apiErr.Code, Message: apiErr.Message, Details: apiErr.Details})
+		}
+	}
+	return ensureSellingPartnerAPIResponse(operation, resp, body, list)
+}
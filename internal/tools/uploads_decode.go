@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/amzapi/selling-partner-api-sdk/uploads"
+)
+
+type uploadsCreateUploadDestinationDecoded struct {
+	destination    uploadDestination
+	apiErrors      *uploads.ErrorList
+	payloadPresent bool
+}
+
+func decodeUploadsCreateUploadDestination(body []byte) (uploadsCreateUploadDestinationDecoded, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return uploadsCreateUploadDestinationDecoded{}, fmt.Errorf("response body is empty")
+	}
+
+	var dto uploads.CreateUploadDestinationResponse
+	if err := json.Unmarshal(trimmed, &dto); err != nil {
+		return uploadsCreateUploadDestinationDecoded{}, err
+	}
+
+	decoded := uploadsCreateUploadDestinationDecoded{
+		apiErrors: dto.Errors,
+	}
+
+	if dto.Payload != nil {
+		decoded.payloadPresent = true
+		decoded.destination = uploadDestination{
+			id:      valueOrEmpty(dto.Payload.UploadDestinationId),
+			url:     valueOrEmpty(dto.Payload.Url),
+			headers: convertUploadHeaders(dto.Payload.Headers),
+		}
+	}
+
+	return decoded, nil
+}
+
+// convertUploadHeaders flattens the loosely typed header map from the SDK model into request-ready strings.
+func convertUploadHeaders(headers *map[string]interface{}) map[string]string {
+	if headers == nil || len(*headers) == 0 {
+		return nil
+	}
+
+	converted := make(map[string]string, len(*headers))
+	for key, value := range *headers {
+		key = strings.TrimSpace(key)
+		if key == "" || value == nil {
+			continue
+		}
+		converted[key] = fmt.Sprint(value)
+	}
+	return converted
+}
@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestResolveUploadPathStaysInsideUploadDir(t *testing.T) {
+	uploadDir := t.TempDir()
+	outside := t.TempDir()
+	for path, content := range map[string]string{
+		filepath.Join(uploadDir, "images", "banner.png"): "png",
+		filepath.Join(outside, "id_rsa"):                 "secret",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "id_rsa"), filepath.Join(uploadDir, "link.png")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	for _, allowed := range []string{"images/banner.png", filepath.Join(uploadDir, "images", "banner.png"), "images/../images/banner.png"} {
+		resolved, err := resolveUploadPath(uploadDir, allowed)
+		if err != nil || filepath.Base(resolved) != "banner.png" {
+			t.Errorf("resolveUploadPath(%q) = %q, %v", allowed, resolved, err)
+		}
+	}
+
+	for _, refused := range []string{
+		filepath.Join(outside, "id_rsa"),
+		"../" + filepath.Base(outside) + "/id_rsa",
+		"link.png",
+	} {
+		if resolved, err := resolveUploadPath(uploadDir, refused); err == nil || !strings.Contains(err.Error(), "outside the upload directory") {
+			t.Errorf("resolveUploadPath(%q) = %q, %v; want it refused", refused, resolved, err)
+		}
+	}
+}
+
+func TestUploadToolsAreWriteTools(t *testing.T) {
+	registry, err := NewRegistry(Dependencies{UploadDir: t.TempDir(), EnabledDomains: []string{"uploads"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range registry.Tools() {
+		names = append(names, tool.Tool.Name)
+		if readOnly := tool.Tool.Annotations.ReadOnlyHint; readOnly == nil || *readOnly {
+			t.Errorf("%s should not be advertised as read-only", tool.Tool.Name)
+		}
+		req := mcp.CallToolRequest{}
+		req.Params.Name = tool.Tool.Name
+		req.Params.Arguments = map[string]any{"resource": "aplus/2020-11-01/contentDocuments", "marketplaceIds": []any{"ATVPDKIKX0DER"}, "contentMD5": "x", "filePath": "banner.png"}
+		result, err := tool.Handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s: %v", tool.Tool.Name, err)
+		}
+		if !result.IsError || !strings.Contains(resultText(result), "SP_API_ENABLE_WRITES") {
+			t.Errorf("%s ran with writes disabled: %s", tool.Tool.Name, resultText(result))
+		}
+	}
+	if len(names) != 2 {
+		t.Errorf("uploads tools = %v, want both", names)
+	}
+
+	if reason := catalogByName(t, Dependencies{})[uploadsUploadFileSpec.Name].Unavailable; !strings.Contains(reason, "SP_API_UPLOAD_DIR") {
+		t.Errorf("uploads.uploadFile should need SP_API_UPLOAD_DIR, got %q", reason)
+	}
+}
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// vendorPurchaseOrder covers both Vendor Orders and Direct Fulfillment orders. The order details are large and differ
+// between the two APIs, so they are passed through as raw JSON.
+type vendorPurchaseOrder struct {
+	PurchaseOrderNumber string          `json:"purchaseOrderNumber"`
+	PurchaseOrderState  string          `json:"purchaseOrderState,omitempty"`
+	OrderDetails        json.RawMessage `json:"orderDetails,omitempty"`
+}
+
+// vendorShippingLabel mirrors the Direct Fulfillment Shipping ShippingLabel model; party and label data pass through.
+type vendorShippingLabel struct {
+	PurchaseOrderNumber string          `json:"purchaseOrderNumber"`
+	SellingParty        json.RawMessage `json:"sellingParty,omitempty"`
+	ShipFromParty       json.RawMessage `json:"shipFromParty,omitempty"`
+	LabelFormat         string          `json:"labelFormat,omitempty"`
+	LabelData           json.RawMessage `json:"labelData,omitempty"`
+}
+
+type vendorTransactionStatus struct {
+	TransactionID string       `json:"transactionId"`
+	Status        string       `json:"status"`
+	Errors        []spAPIError `json:"errors,omitempty"`
+}
+
+type vendorOrderListDecoded struct {
+	orders         []vendorPurchaseOrder
+	nextToken      string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type vendorOrderDecoded struct {
+	order          vendorPurchaseOrder
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type vendorShippingLabelListDecoded struct {
+	labels         []vendorShippingLabel
+	nextToken      string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type vendorShippingLabelDecoded struct {
+	label          vendorShippingLabel
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type vendorTransactionDecoded struct {
+	transactionID  string
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+type vendorTransactionStatusDecoded struct {
+	status         vendorTransactionStatus
+	apiErrors      []spAPIError
+	payloadPresent bool
+}
+
+// vendorPayload unwraps a vendor response. The v1 Vendor APIs nest results under payload, while the Direct Fulfillment
+// 2021-12-28 APIs return them at the top level; both report failures in a top-level errors array.
+func vendorPayload(body []byte, wrapped bool) ([]byte, []spAPIError, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, nil, fmt.Errorf("response body is empty")
+	}
+
+	var envelope struct {
+		Payload json.RawMessage `json:"payload,omitempty"`
+		Errors  []spAPIError    `json:"errors,omitempty"`
+	}
+	if err := json.Unmarshal(trimmed, &envelope); err != nil {
+		return nil, nil, err
+	}
+
+	if !wrapped {
+		if len(envelope.Errors) > 0 {
+			return nil, envelope.Errors, nil
+		}
+		return trimmed, nil, nil
+	}
+
+	if len(envelope.Payload) == 0 || bytes.Equal(envelope.Payload, []byte("null")) {
+		return nil, envelope.Errors, nil
+	}
+	return envelope.Payload, envelope.Errors, nil
+}
+
+func decodeVendorOrderList(body []byte, wrapped bool) (vendorOrderListDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, wrapped)
+	if err != nil {
+		return vendorOrderListDecoded{}, err
+	}
+
+	decoded := vendorOrderListDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	var dto struct {
+		Pagination *vendorPaginationDTO   `json:"pagination,omitempty"`
+		Orders     *[]vendorPurchaseOrder `json:"orders,omitempty"`
+	}
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return vendorOrderListDecoded{}, err
+	}
+
+	decoded.payloadPresent = true
+	if dto.Orders != nil {
+		decoded.orders = *dto.Orders
+	}
+	decoded.nextToken = dto.Pagination.token()
+
+	return decoded, nil
+}
+
+func decodeVendorOrder(body []byte, wrapped bool) (vendorOrderDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, wrapped)
+	if err != nil {
+		return vendorOrderDecoded{}, err
+	}
+
+	decoded := vendorOrderDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	if err := json.Unmarshal(payload, &decoded.order); err != nil {
+		return vendorOrderDecoded{}, err
+	}
+	decoded.payloadPresent = decoded.order.PurchaseOrderNumber != ""
+
+	return decoded, nil
+}
+
+func decodeVendorShippingLabelList(body []byte) (vendorShippingLabelListDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, false)
+	if err != nil {
+		return vendorShippingLabelListDecoded{}, err
+	}
+
+	decoded := vendorShippingLabelListDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	var dto struct {
+		Pagination     *vendorPaginationDTO   `json:"pagination,omitempty"`
+		ShippingLabels *[]vendorShippingLabel `json:"shippingLabels,omitempty"`
+	}
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return vendorShippingLabelListDecoded{}, err
+	}
+
+	decoded.payloadPresent = true
+	if dto.ShippingLabels != nil {
+		decoded.labels = *dto.ShippingLabels
+	}
+	decoded.nextToken = dto.Pagination.token()
+
+	return decoded, nil
+}
+
+func decodeVendorShippingLabel(body []byte) (vendorShippingLabelDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, false)
+	if err != nil {
+		return vendorShippingLabelDecoded{}, err
+	}
+
+	decoded := vendorShippingLabelDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	if err := json.Unmarshal(payload, &decoded.label); err != nil {
+		return vendorShippingLabelDecoded{}, err
+	}
+	decoded.payloadPresent = decoded.label.PurchaseOrderNumber != ""
+
+	return decoded, nil
+}
+
+func decodeVendorTransaction(body []byte, wrapped bool) (vendorTransactionDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, wrapped)
+	if err != nil {
+		return vendorTransactionDecoded{}, err
+	}
+
+	decoded := vendorTransactionDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	var dto struct {
+		TransactionID string `json:"transactionId"`
+	}
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return vendorTransactionDecoded{}, err
+	}
+
+	decoded.transactionID = dto.TransactionID
+	decoded.payloadPresent = dto.TransactionID != ""
+
+	return decoded, nil
+}
+
+func decodeVendorTransactionStatus(body []byte, wrapped bool) (vendorTransactionStatusDecoded, error) {
+	payload, apiErrors, err := vendorPayload(body, wrapped)
+	if err != nil {
+		return vendorTransactionStatusDecoded{}, err
+	}
+
+	decoded := vendorTransactionStatusDecoded{apiErrors: apiErrors}
+	if payload == nil {
+		return decoded, nil
+	}
+
+	var dto struct {
+		TransactionStatus *vendorTransactionStatus `json:"transactionStatus,omitempty"`
+	}
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return vendorTransactionStatusDecoded{}, err
+	}
+
+	if dto.TransactionStatus != nil {
+		decoded.payloadPresent = true
+		decoded.status = *dto.TransactionStatus
+	}
+
+	return decoded, nil
+}
+
+type vendorPaginationDTO struct {
+	NextToken *string `json:"nextToken,omitempty"`
+}
+
+func (p *vendorPaginationDTO) token() string {
+	if p == nil {
+		return ""
+	}
+	return valueOrEmpty(p.NextToken)
+}
@@ -0,0 +1,347 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	vendorDirectFulfillmentOrdersBasePath       = "/vendor/directFulfillment/orders/2021-12-28"
+	vendorDirectFulfillmentShippingBasePath     = "/vendor/directFulfillment/shipping/2021-12-28"
+	vendorDirectFulfillmentPaymentsBasePath     = "/vendor/directFulfillment/payments/v1"
+	vendorDirectFulfillmentTransactionsBasePath = "/vendor/directFulfillment/transactions/2021-12-28"
+)
+
+type vendorDirectFulfillmentListArgs struct {
+	CreatedAfter    string `json:"createdAfter"`
+	CreatedBefore   string `json:"createdBefore"`
+	ShipFromPartyID string `json:"shipFromPartyId"`
+	Status          string `json:"status"`
+	IncludeDetails  *bool  `json:"includeDetails"`
+	SortOrder       string `json:"sortOrder"`
+	Limit           *int   `json:"limit"`
+	NextToken       string `json:"nextToken"`
+	pagingArgs
+}
+
+type vendorShippingLabelListResult struct {
+	ShippingLabels []vendorShippingLabel `json:"shippingLabels"`
+	NextToken      string                `json:"nextToken,omitempty"`
+	Paging         *pagingSummary        `json:"paging,omitempty"`
+	RetrievedAt    time.Time             `json:"retrievedAt"`
+}
+
+type vendorShippingLabelResult struct {
+	ShippingLabel vendorShippingLabel `json:"shippingLabel"`
+	RetrievedAt   time.Time           `json:"retrievedAt"`
+}
+
+func newVendorDirectFulfillmentTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	getOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args vendorDirectFulfillmentListArgs) (*mcp.CallToolResult, error) {
+		return executeVendorDirectFulfillmentGetOrders(withProgressToken(ctx, req), args, spClient)
+	})
+
+	getOrderHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorGetPurchaseOrderArgs) (*mcp.CallToolResult, error) {
+		return executeVendorDirectFulfillmentGetOrder(ctx, strings.TrimSpace(args.PurchaseOrderNumber), spClient)
+	})
+
+	submitAcknowledgementHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorSubmitArgs) (*mcp.CallToolResult, error) {
+		return executeVendorSubmit(ctx, vendorSubmission{
+			toolName:  vendorDirectFulfillmentSubmitAcknowledgementSpec.Name,
+			operation: "submitAcknowledgement",
+			path:      vendorDirectFulfillmentOrdersBasePath + "/acknowledgements",
+			field:     "orderAcknowledgements",
+		}, args, spClient)
+	})
+
+	getShippingLabelsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args vendorDirectFulfillmentListArgs) (*mcp.CallToolResult, error) {
+		return executeVendorDirectFulfillmentGetShippingLabels(withProgressToken(ctx, req), args, spClient)
+	})
+
+	getShippingLabelHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorGetPurchaseOrderArgs) (*mcp.CallToolResult, error) {
+		return executeVendorDirectFulfillmentGetShippingLabel(ctx, strings.TrimSpace(args.PurchaseOrderNumber), spClient)
+	})
+
+	submitShipmentConfirmationsHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorSubmitArgs) (*mcp.CallToolResult, error) {
+		return executeVendorSubmit(ctx, vendorSubmission{
+			toolName:  vendorDirectFulfillmentSubmitShipmentConfirmationsSpec.Name,
+			operation: "submitShipmentConfirmations",
+			path:      vendorDirectFulfillmentShippingBasePath + "/shipmentConfirmations",
+			field:     "shipmentConfirmations",
+		}, args, spClient)
+	})
+
+	submitInvoicesHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorSubmitArgs) (*mcp.CallToolResult, error) {
+		return executeVendorSubmit(ctx, vendorSubmission{
+			toolName:  vendorInvoicesSubmitDirectFulfillmentInvoicesSpec.Name,
+			operation: "submitInvoice",
+			path:      vendorDirectFulfillmentPaymentsBasePath + "/invoices",
+			field:     "invoices",
+			wrapped:   true,
+		}, args, spClient)
+	})
+
+	return []registeredTool{
+		toolFromSpec(vendorDirectFulfillmentGetOrdersSpec, getOrdersHandler),
+		toolFromSpec(vendorDirectFulfillmentGetOrderSpec, getOrderHandler),
+		newWriteTool(vendorDirectFulfillmentSubmitAcknowledgementSpec, deps, submitAcknowledgementHandler),
+		toolFromSpec(vendorDirectFulfillmentGetShippingLabelsSpec, getShippingLabelsHandler),
+		toolFromSpec(vendorDirectFulfillmentGetShippingLabelSpec, getShippingLabelHandler),
+		newWriteTool(vendorDirectFulfillmentSubmitShipmentConfirmationsSpec, deps, submitShipmentConfirmationsHandler),
+		newWriteTool(vendorInvoicesSubmitDirectFulfillmentInvoicesSpec, deps, submitInvoicesHandler),
+	}
+}
+
+func executeVendorDirectFulfillmentGetOrders(ctx context.Context, args vendorDirectFulfillmentListArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	query, failure := buildVendorDirectFulfillmentListQuery(args)
+	if failure != nil {
+		return failure, nil
+	}
+	setQueryValue(query, "status", strings.ToUpper(strings.TrimSpace(args.Status)))
+	if args.IncludeDetails != nil {
+		query.Set("includeDetails", strconv.FormatBool(*args.IncludeDetails))
+	}
+
+	fetch := func(ctx context.Context, token string) ([]vendorPurchaseOrder, string, *mcp.CallToolResult) {
+		pageQuery := maps.Clone(query)
+		setQueryValue(pageQuery, "nextToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   vendorDirectFulfillmentOrdersBasePath + "/purchaseOrders",
+			Query:  pageQuery,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("vendorDirectFulfillment.getOrders request failed", err)
+		}
+
+		decoded, decodeErr := decodeVendorOrderList(body, false)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("getOrders", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode vendorDirectFulfillment.getOrders response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("getOrders", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("vendorDirectFulfillment.getOrders response payload is empty")
+		}
+
+		return decoded.orders, decoded.nextToken, nil
+	}
+
+	orders, next, paging, failure := collectPages(ctx, pagedOperation{tool: vendorDirectFulfillmentGetOrdersSpec.Name, operation: vendorDirectFulfillmentGetOrdersSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := vendorPurchaseOrderListResult{
+		Orders:      orders,
+		NextToken:   next,
+		Paging:      paging,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d direct fulfillment orders", len(result.Orders))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeVendorDirectFulfillmentGetOrder(ctx context.Context, purchaseOrderNumber string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if purchaseOrderNumber == "" {
+		return mcp.NewToolResultError("purchaseOrderNumber is required"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   vendorDirectFulfillmentOrdersBasePath + "/purchaseOrders/" + url.PathEscape(purchaseOrderNumber),
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("vendorDirectFulfillment.getOrder request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeVendorOrder(body, false)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getOrder", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode vendorDirectFulfillment.getOrder response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getOrder", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("vendorDirectFulfillment.getOrder response payload is empty"), nil
+	}
+
+	result := vendorPurchaseOrderResult{
+		Order:       decoded.order,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	return mcp.NewToolResultStructured(result, fmt.Sprintf("Direct fulfillment order %s", result.Order.PurchaseOrderNumber)), nil
+}
+
+func executeVendorDirectFulfillmentGetShippingLabels(ctx context.Context, args vendorDirectFulfillmentListArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	query, failure := buildVendorDirectFulfillmentListQuery(args)
+	if failure != nil {
+		return failure, nil
+	}
+
+	fetch := func(ctx context.Context, token string) ([]vendorShippingLabel, string, *mcp.CallToolResult) {
+		pageQuery := maps.Clone(query)
+		setQueryValue(pageQuery, "nextToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   vendorDirectFulfillmentShippingBasePath + "/shippingLabels",
+			Query:  pageQuery,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("vendorDirectFulfillment.getShippingLabels request failed", err)
+		}
+
+		decoded, decodeErr := decodeVendorShippingLabelList(body)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("getShippingLabels", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode vendorDirectFulfillment.getShippingLabels response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("getShippingLabels", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("vendorDirectFulfillment.getShippingLabels response payload is empty")
+		}
+
+		return decoded.labels, decoded.nextToken, nil
+	}
+
+	labels, next, paging, failure := collectPages(ctx, pagedOperation{tool: vendorDirectFulfillmentGetShippingLabelsSpec.Name, operation: vendorDirectFulfillmentGetShippingLabelsSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := vendorShippingLabelListResult{
+		ShippingLabels: labels,
+		NextToken:      next,
+		Paging:         paging,
+		RetrievedAt:    time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d shipping labels", len(result.ShippingLabels))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeVendorDirectFulfillmentGetShippingLabel(ctx context.Context, purchaseOrderNumber string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if purchaseOrderNumber == "" {
+		return mcp.NewToolResultError("purchaseOrderNumber is required"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   vendorDirectFulfillmentShippingBasePath + "/shippingLabels/" + url.PathEscape(purchaseOrderNumber),
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("vendorDirectFulfillment.getShippingLabel request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeVendorShippingLabel(body)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getShippingLabel", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode vendorDirectFulfillment.getShippingLabel response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getShippingLabel", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("vendorDirectFulfillment.getShippingLabel response payload is empty"), nil
+	}
+
+	result := vendorShippingLabelResult{
+		ShippingLabel: decoded.label,
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Shipping label for %s", result.ShippingLabel.PurchaseOrderNumber)
+	if result.ShippingLabel.LabelFormat != "" {
+		fallback = fmt.Sprintf("%s (%s)", fallback, result.ShippingLabel.LabelFormat)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// buildVendorDirectFulfillmentListQuery applies the window and paging parameters shared by the Direct Fulfillment
+// list operations. Both bounds are required by Amazon unless a nextToken is supplied; the token itself is added per page.
+func buildVendorDirectFulfillmentListQuery(args vendorDirectFulfillmentListArgs) (url.Values, *mcp.CallToolResult) {
+	query := url.Values{}
+
+	createdAfter := strings.TrimSpace(args.CreatedAfter)
+	createdBefore := strings.TrimSpace(args.CreatedBefore)
+	nextToken := strings.TrimSpace(args.NextToken)
+	if nextToken == "" && (createdAfter == "" || createdBefore == "") {
+		return nil, mcp.NewToolResultError("createdAfter and createdBefore are required unless nextToken is provided")
+	}
+
+	setQueryValue(query, "createdAfter", createdAfter)
+	setQueryValue(query, "createdBefore", createdBefore)
+	setQueryValue(query, "shipFromPartyId", args.ShipFromPartyID)
+	setQueryValue(query, "sortOrder", strings.ToUpper(strings.TrimSpace(args.SortOrder)))
+	if limit := sanitizeVendorLimit(args.Limit); limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	return query, nil
+}
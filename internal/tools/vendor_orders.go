@@ -0,0 +1,369 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/spapi"
+)
+
+const (
+	vendorOrdersBasePath       = "/vendor/orders/v1"
+	vendorInvoicesBasePath     = "/vendor/payments/v1"
+	vendorTransactionsBasePath = "/vendor/transactions/v1"
+	vendorMaxPageSize          = 100
+)
+
+type vendorGetPurchaseOrdersArgs struct {
+	CreatedAfter       string `json:"createdAfter"`
+	CreatedBefore      string `json:"createdBefore"`
+	ChangedAfter       string `json:"changedAfter"`
+	ChangedBefore      string `json:"changedBefore"`
+	PurchaseOrderState string `json:"purchaseOrderState"`
+	IsPOChanged        *bool  `json:"isPOChanged"`
+	OrderingVendorCode string `json:"orderingVendorCode"`
+	IncludeDetails     *bool  `json:"includeDetails"`
+	SortOrder          string `json:"sortOrder"`
+	Limit              *int   `json:"limit"`
+	NextToken          string `json:"nextToken"`
+	pagingArgs
+}
+
+type vendorPurchaseOrderListResult struct {
+	Orders      []vendorPurchaseOrder `json:"orders"`
+	NextToken   string                `json:"nextToken,omitempty"`
+	Paging      *pagingSummary        `json:"paging,omitempty"`
+	RetrievedAt time.Time             `json:"retrievedAt"`
+}
+
+type vendorGetPurchaseOrderArgs struct {
+	PurchaseOrderNumber string `json:"purchaseOrderNumber"`
+}
+
+type vendorPurchaseOrderResult struct {
+	Order       vendorPurchaseOrder `json:"order"`
+	RetrievedAt time.Time           `json:"retrievedAt"`
+}
+
+// vendorSubmitArgs carries a batch of documents for the vendor submission operations. The documents follow Amazon's
+// published models and are forwarded as-is.
+type vendorSubmitArgs struct {
+	Documents []map[string]any `json:"documents"`
+}
+
+type vendorSubmitResult struct {
+	TransactionID string    `json:"transactionId"`
+	DocumentCount int       `json:"documentCount"`
+	RetrievedAt   time.Time `json:"retrievedAt"`
+}
+
+type vendorGetTransactionStatusArgs struct {
+	TransactionID     string `json:"transactionId"`
+	DirectFulfillment bool   `json:"directFulfillment"`
+}
+
+type vendorTransactionStatusResult struct {
+	Transaction vendorTransactionStatus `json:"transaction"`
+	RetrievedAt time.Time               `json:"retrievedAt"`
+}
+
+func newVendorOrdersTools(deps Dependencies) []registeredTool {
+	spClient := deps.SellingPartner
+
+	getPurchaseOrdersHandler := mcp.NewTypedToolHandler(func(ctx context.Context, req mcp.CallToolRequest, args vendorGetPurchaseOrdersArgs) (*mcp.CallToolResult, error) {
+		return executeVendorGetPurchaseOrders(withProgressToken(ctx, req), args, spClient)
+	})
+
+	getPurchaseOrderHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorGetPurchaseOrderArgs) (*mcp.CallToolResult, error) {
+		return executeVendorGetPurchaseOrder(ctx, strings.TrimSpace(args.PurchaseOrderNumber), spClient)
+	})
+
+	submitAcknowledgementHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorSubmitArgs) (*mcp.CallToolResult, error) {
+		return executeVendorSubmit(ctx, vendorSubmission{
+			toolName:  vendorOrdersSubmitAcknowledgementSpec.Name,
+			operation: "submitAcknowledgement",
+			path:      vendorOrdersBasePath + "/acknowledgements",
+			field:     "acknowledgements",
+			wrapped:   true,
+		}, args, spClient)
+	})
+
+	submitInvoicesHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorSubmitArgs) (*mcp.CallToolResult, error) {
+		return executeVendorSubmit(ctx, vendorSubmission{
+			toolName:  vendorInvoicesSubmitInvoicesSpec.Name,
+			operation: "submitInvoices",
+			path:      vendorInvoicesBasePath + "/invoices",
+			field:     "invoices",
+			wrapped:   true,
+		}, args, spClient)
+	})
+
+	getTransactionStatusHandler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, args vendorGetTransactionStatusArgs) (*mcp.CallToolResult, error) {
+		return executeVendorGetTransactionStatus(ctx, args, spClient)
+	})
+
+	return []registeredTool{
+		toolFromSpec(vendorOrdersGetPurchaseOrdersSpec, getPurchaseOrdersHandler),
+		toolFromSpec(vendorOrdersGetPurchaseOrderSpec, getPurchaseOrderHandler),
+		newWriteTool(vendorOrdersSubmitAcknowledgementSpec, deps, submitAcknowledgementHandler),
+		newWriteTool(vendorInvoicesSubmitInvoicesSpec, deps, submitInvoicesHandler),
+		toolFromSpec(vendorGetTransactionStatusSpec, getTransactionStatusHandler),
+	}
+}
+
+func executeVendorGetPurchaseOrders(ctx context.Context, args vendorGetPurchaseOrdersArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	query := url.Values{}
+	setQueryValue(query, "createdAfter", args.CreatedAfter)
+	setQueryValue(query, "createdBefore", args.CreatedBefore)
+	setQueryValue(query, "changedAfter", args.ChangedAfter)
+	setQueryValue(query, "changedBefore", args.ChangedBefore)
+	setQueryValue(query, "purchaseOrderState", args.PurchaseOrderState)
+	setQueryValue(query, "orderingVendorCode", args.OrderingVendorCode)
+	setQueryValue(query, "sortOrder", strings.ToUpper(strings.TrimSpace(args.SortOrder)))
+	if args.IsPOChanged != nil {
+		query.Set("isPOChanged", strconv.FormatBool(*args.IsPOChanged))
+	}
+	if args.IncludeDetails != nil {
+		query.Set("includeDetails", strconv.FormatBool(*args.IncludeDetails))
+	}
+	if limit := sanitizeVendorLimit(args.Limit); limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	fetch := func(ctx context.Context, token string) ([]vendorPurchaseOrder, string, *mcp.CallToolResult) {
+		pageQuery := maps.Clone(query)
+		setQueryValue(pageQuery, "nextToken", token)
+
+		httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+			Method: http.MethodGet,
+			Path:   vendorOrdersBasePath + "/purchaseOrders",
+			Query:  pageQuery,
+		})
+		if err != nil {
+			return nil, "", mcp.NewToolResultErrorFromErr("vendorOrders.getPurchaseOrders request failed", err)
+		}
+
+		decoded, decodeErr := decodeVendorOrderList(body, true)
+		if decodeErr != nil {
+			if err := ensureSellingPartnerAPIResponse("getPurchaseOrders", httpResp, body, nil); err != nil {
+				return nil, "", mcp.NewToolResultError(err.Error())
+			}
+			return nil, "", mcp.NewToolResultErrorFromErr("failed to decode vendorOrders.getPurchaseOrders response", decodeErr)
+		}
+
+		if err := ensureSellingPartnerAPIResponse("getPurchaseOrders", httpResp, body, decoded.apiErrors); err != nil {
+			return nil, "", mcp.NewToolResultError(err.Error())
+		}
+
+		if !decoded.payloadPresent {
+			return nil, "", mcp.NewToolResultError("vendorOrders.getPurchaseOrders response payload is empty")
+		}
+
+		return decoded.orders, decoded.nextToken, nil
+	}
+
+	orders, next, paging, failure := collectPages(ctx, pagedOperation{tool: vendorOrdersGetPurchaseOrdersSpec.Name, operation: vendorOrdersGetPurchaseOrdersSpec.RateLimit}, args.pagingArgs, strings.TrimSpace(args.NextToken), fetch)
+	if failure != nil {
+		return failure, nil
+	}
+
+	result := vendorPurchaseOrderListResult{
+		Orders:      orders,
+		NextToken:   next,
+		Paging:      paging,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d purchase orders", len(result.Orders))
+	if paging != nil {
+		fallback = pagingFallback(fallback, paging)
+	} else if result.NextToken != "" {
+		fallback = fmt.Sprintf("%s, more available via nextToken", fallback)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeVendorGetPurchaseOrder(ctx context.Context, purchaseOrderNumber string, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if purchaseOrderNumber == "" {
+		return mcp.NewToolResultError("purchaseOrderNumber is required"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   vendorOrdersBasePath + "/purchaseOrders/" + url.PathEscape(purchaseOrderNumber),
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("vendorOrders.getPurchaseOrder request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeVendorOrder(body, true)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getPurchaseOrder", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode vendorOrders.getPurchaseOrder response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getPurchaseOrder", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("vendorOrders.getPurchaseOrder response payload is empty"), nil
+	}
+
+	result := vendorPurchaseOrderResult{
+		Order:       decoded.order,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Purchase order %s", result.Order.PurchaseOrderNumber)
+	if result.Order.PurchaseOrderState != "" {
+		fallback = fmt.Sprintf("%s is %s", fallback, result.Order.PurchaseOrderState)
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+// vendorSubmission describes one of the vendor batch submission operations, which all accept a list of documents under
+// a single field and answer with a transactionId.
+type vendorSubmission struct {
+	toolName  string
+	operation string
+	path      string
+	field     string
+	wrapped   bool
+}
+
+func executeVendorSubmit(ctx context.Context, submission vendorSubmission, args vendorSubmitArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	if len(args.Documents) == 0 {
+		return mcp.NewToolResultError("documents must include at least one entry"), nil
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodPost,
+		Path:   submission.path,
+		Body:   map[string]any{submission.field: args.Documents},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(submission.toolName+" request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeVendorTransaction(body, submission.wrapped)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse(submission.operation, httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode "+submission.toolName+" response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse(submission.operation, httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError(submission.toolName + " response payload is empty"), nil
+	}
+
+	result := vendorSubmitResult{
+		TransactionID: decoded.transactionID,
+		DocumentCount: len(args.Documents),
+		RetrievedAt:   time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Submitted %d %s; track transaction %s with vendor.getTransactionStatus", result.DocumentCount, submission.field, result.TransactionID)
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func executeVendorGetTransactionStatus(ctx context.Context, args vendorGetTransactionStatusArgs, spClient spapi.Client) (*mcp.CallToolResult, error) {
+	if failure := ensureSellingPartnerClient(spClient); failure != nil {
+		return failure, nil
+	}
+
+	transactionID := strings.TrimSpace(args.TransactionID)
+	if transactionID == "" {
+		return mcp.NewToolResultError("transactionId is required"), nil
+	}
+
+	// Direct Fulfillment submissions are tracked by their own transaction API, which returns unwrapped responses.
+	path := vendorTransactionsBasePath + "/transactions/" + url.PathEscape(transactionID)
+	wrapped := true
+	if args.DirectFulfillment {
+		path = vendorDirectFulfillmentTransactionsBasePath + "/transactions/" + url.PathEscape(transactionID)
+		wrapped = false
+	}
+
+	httpResp, body, err := doSellingPartnerRequest(ctx, spClient, spRequest{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("vendor.getTransactionStatus request failed", err), nil
+	}
+
+	decoded, decodeErr := decodeVendorTransactionStatus(body, wrapped)
+	if decodeErr != nil {
+		if err := ensureSellingPartnerAPIResponse("getTransactionStatus", httpResp, body, nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to decode vendor.getTransactionStatus response", decodeErr), nil
+	}
+
+	if err := ensureSellingPartnerAPIResponse("getTransactionStatus", httpResp, body, decoded.apiErrors); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !decoded.payloadPresent {
+		return mcp.NewToolResultError("vendor.getTransactionStatus response payload is empty"), nil
+	}
+
+	result := vendorTransactionStatusResult{
+		Transaction: decoded.status,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	fallback := fmt.Sprintf("Transaction %s is %s", result.Transaction.TransactionID, result.Transaction.Status)
+	if len(result.Transaction.Errors) > 0 {
+		fallback = fmt.Sprintf("%s: %s", fallback, formatSellingPartnerErrors(result.Transaction.Errors))
+	}
+
+	return mcp.NewToolResultStructured(result, fallback), nil
+}
+
+func setQueryValue(query url.Values, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		query.Set(key, value)
+	}
+}
+
+func sanitizeVendorLimit(value *int) int {
+	if value == nil || *value <= 0 {
+		return 0
+	}
+	if *value > vendorMaxPageSize {
+		return vendorMaxPageSize
+	}
+	return *value
+}
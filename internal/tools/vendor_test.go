@@ -0,0 +1,59 @@
+package tools
+
+import "testing"
+
+func TestDecodeVendorOrderListWrappedAndUnwrapped(t *testing.T) {
+	wrapped := []byte(`{"payload":{"pagination":{"nextToken":"abc"},"orders":[{"purchaseOrderNumber":"2JK3S9VC","purchaseOrderState":"New","orderDetails":{"purchaseOrderType":"RegularOrder"}}]}}`)
+	decoded, err := decodeVendorOrderList(wrapped, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.payloadPresent || decoded.nextToken != "abc" || len(decoded.orders) != 1 || decoded.orders[0].PurchaseOrderState != "New" {
+		t.Fatalf("unexpected decode %+v", decoded)
+	}
+
+	unwrapped := []byte(`{"orders":[{"purchaseOrderNumber":"2JK3S9VD","orderDetails":{}}]}`)
+	decoded, err = decodeVendorOrderList(unwrapped, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.payloadPresent || decoded.nextToken != "" || decoded.orders[0].PurchaseOrderNumber != "2JK3S9VD" {
+		t.Fatalf("unexpected decode %+v", decoded)
+	}
+
+	failed := []byte(`{"errors":[{"code":"InvalidInput","message":"createdBefore is required"}]}`)
+	decoded, err = decodeVendorOrderList(failed, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.payloadPresent || len(decoded.apiErrors) != 1 {
+		t.Fatalf("expected error list without payload, got %+v", decoded)
+	}
+}
+
+func TestBuildAllSelectsToolsByAccountType(t *testing.T) {
+	names := func(deps Dependencies) map[string]bool {
+		set := make(map[string]bool)
+		tools, err := BuildAll(deps)
+		if err != nil {
+			t.Fatalf("BuildAll: %v", err)
+		}
+		for _, tool := range tools {
+			set[tool.Tool.Name] = true
+		}
+		return set
+	}
+
+	seller := names(Dependencies{})
+	if !seller[ordersListOrdersSpec.Name] || seller[vendorOrdersGetPurchaseOrdersSpec.Name] {
+		t.Fatalf("seller accounts should get seller tools only")
+	}
+
+	vendor := names(Dependencies{Vendor: true})
+	if vendor[ordersListOrdersSpec.Name] || !vendor[vendorOrdersGetPurchaseOrdersSpec.Name] {
+		t.Fatalf("vendor accounts should get vendor tools only")
+	}
+	if !vendor[reportsGetReportsSpec.Name] {
+		t.Fatalf("shared tools should be registered for vendors")
+	}
+}
@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// writesDisabledMessage explains how operators opt in to tools that change seller data.
+const writesDisabledMessage = "%s changes live seller data and is disabled; set SP_API_ENABLE_WRITES=true to allow write tools"
+
+// dryRunArgument is the argument write tools accept to preview their SP-API request without sending it.
+const dryRunArgument = "dryRun"
+
+// writePreview is returned instead of calling SP-API when a write tool runs in dry-run mode.
+type writePreview struct {
+	DryRun      bool       `json:"dryRun"`
+	Tool        string     `json:"tool"`
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	Query       url.Values `json:"query,omitempty"`
+	Body        any        `json:"body,omitempty"`
+	RetrievedAt time.Time  `json:"retrievedAt"`
+}
+
+// newWriteTool registers a tool that changes seller data. The tool stays visible so assistants can discover it, but the
+// handler refuses to run until writes are enabled in configuration. Dry runs only build a preview, so they are allowed
+// either way.
+func newWriteTool(spec toolSpec, deps Dependencies, handler server.ToolHandlerFunc) registeredTool {
+	spec.Mutating = true
+
+	gated := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !dryRunRequested(deps, req) {
+			if failure := ensureWritesAllowed(deps, spec.Name); failure != nil {
+				return failure, nil
+			}
+		}
+		return handler(ctx, req)
+	}
+
+	return newDryRunTool(spec, deps, gated)
+}
+
+// newDryRunTool registers a tool that sends SP-API requests with side effects, such as creating a report or a Data
+// Kiosk query. It gains a dryRun argument; in a dry run (per call, or for every call with SP_API_DRY_RUN) the handler
+// runs as usual, so local validation and preparatory reads still happen, but the first request that would change
+// anything is captured and returned as a preview instead of being sent.
+func newDryRunTool(spec toolSpec, deps Dependencies, handler server.ToolHandlerFunc) registeredTool {
+	spec.SideEffects = true
+	spec.Options = append(spec.Options, mcp.WithBoolean(dryRunArgument, mcp.Description("Validate and return the SP-API request without sending it.")))
+
+	previewing := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !dryRunRequested(deps, req) {
+			return handler(ctx, req)
+		}
+
+		scope := &dryRunScope{}
+		result, err := handler(context.WithValue(ctx, dryRunScopeKey{}, scope), req)
+		if captured, ok := scope.captured(); ok {
+			return newWritePreviewResult(spec.Name, captured), nil
+		}
+		return result, err
+	}
+
+	return toolFromSpec(spec, previewing)
+}
+
+// inDryRun reports whether ctx belongs to a dry-run call, for handlers that apply the write gate themselves.
+func inDryRun(ctx context.Context) bool {
+	scope, _ := ctx.Value(dryRunScopeKey{}).(*dryRunScope)
+	return scope != nil
+}
+
+// withoutDryRun lets a read-only POST, such as a validation call, through a dry run.
+func withoutDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunScopeKey{}, nil)
+}
+
+func dryRunRequested(deps Dependencies, req mcp.CallToolRequest) bool {
+	return deps.DryRun || req.GetBool(dryRunArgument, false)
+}
+
+// errDryRun stops a handler at the first request it would send in a dry run.
+var errDryRun = errors.New("dry run: request not sent")
+
+// dryRunScope holds the request captured during one dry-run call.
+type dryRunScope struct {
+	mu      sync.Mutex
+	request *spRequest
+}
+
+type dryRunScopeKey struct{}
+
+func (s *dryRunScope) capture(request spRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.request == nil {
+		s.request = &request
+	}
+}
+
+func (s *dryRunScope) captured() (spRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.request == nil {
+		return spRequest{}, false
+	}
+	return *s.request, true
+}
+
+// dryRunTransport intercepts requests that could change data inside a dry-run scope. GETs pass through, so tools that
+// look something up before writing still validate against live data.
+type dryRunTransport struct {
+	base http.RoundTripper
+}
+
+func (t dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	scope, _ := req.Context().Value(dryRunScopeKey{}).(*dryRunScope)
+	if scope == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	captured := spRequest{Method: req.Method, Path: req.URL.Path, Query: req.URL.Query()}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading dry-run request body: %w", err)
+		}
+		captured.Body = previewBody(body)
+	}
+	if len(captured.Query) == 0 {
+		captured.Query = nil
+	}
+	scope.capture(captured)
+
+	return nil, errDryRun
+}
+
+// previewBody shows JSON bodies as JSON and anything else, such as an uploaded file, by size only.
+func previewBody(body []byte) any {
+	if len(body) == 0 {
+		return nil
+	}
+	var decoded any
+	if json.Unmarshal(body, &decoded) == nil {
+		return decoded
+	}
+	return fmt.Sprintf("%d bytes", len(body))
+}
+
+// ensureWritesAllowed lets tools with a read-only mode (such as validation) apply the write gate only when they are
+// about to change data.
+func ensureWritesAllowed(deps Dependencies, toolName string) *mcp.CallToolResult {
+	if !deps.AllowWrites {
+		return mcp.NewToolResultError(fmt.Sprintf(writesDisabledMessage, toolName))
+	}
+	return nil
+}
+
+// newWritePreviewResult describes the request a write tool would send, after local validation has passed.
+func newWritePreviewResult(toolName string, spReq spRequest) *mcp.CallToolResult {
+	preview := writePreview{
+		DryRun:      true,
+		Tool:        toolName,
+		Method:      spReq.Method,
+		Path:        spReq.Path,
+		Query:       spReq.Query,
+		Body:        spReq.Body,
+		RetrievedAt: time.Now().UTC(),
+	}
+
+	target := preview.Path
+	if len(preview.Query) > 0 {
+		target = fmt.Sprintf("%s?%s", target, preview.Query.Encode())
+	}
+	fallback := fmt.Sprintf("Dry run: %s would send %s %s; nothing was changed", toolName, strings.ToUpper(preview.Method), target)
+
+	return mcp.NewToolResultStructured(preview, fallback)
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/berrydev-ai/sp-api-mcp-go/internal/cache"
+)
+
+func TestDryRunToolCapturesWrite(t *testing.T) {
+	var posts int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			posts++
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+
+	handler := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := newSellingPartnerHTTPClient()
+		lookup, _ := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+"/reports/2021-06-30/reports", nil)
+		resp, err := client.Do(lookup)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("lookup failed", err), nil
+		}
+		resp.Body.Close()
+
+		create, _ := http.NewRequestWithContext(ctx, http.MethodPost, upstream.URL+"/reports/2021-06-30/reports?x=1", strings.NewReader(`{"reportType":"GET_X"}`))
+		if _, err := client.Do(create); err != nil {
+			return mcp.NewToolResultErrorFromErr("createReport failed", err), nil
+		}
+		return mcp.NewToolResultText("created"), nil
+	}
+
+	call := func(deps Dependencies, arguments map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		tool := newDryRunTool(reportsCreateReportSpec, deps, handler)
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = arguments
+		result, err := tool.Handler(context.Background(), req)
+		if err != nil {
+			t.Fatalf("handler: %v", err)
+		}
+		return result
+	}
+
+	result := call(Dependencies{}, map[string]any{"dryRun": true})
+	preview, ok := result.StructuredContent.(writePreview)
+	if !ok {
+		t.Fatalf("expected a preview, got %+v", result)
+	}
+	if preview.Method != http.MethodPost || preview.Path != "/reports/2021-06-30/reports" || preview.Query.Get("x") != "1" {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if body, _ := preview.Body.(map[string]any); body["reportType"] != "GET_X" {
+		t.Fatalf("unexpected preview body %#v", preview.Body)
+	}
+	if posts != 0 {
+		t.Fatalf("dry run sent %d writes", posts)
+	}
+
+	if _, ok := call(Dependencies{DryRun: true}, nil).StructuredContent.(writePreview); !ok {
+		t.Fatal("global dry run did not return a preview")
+	}
+
+	if result := call(Dependencies{}, nil); result.IsError || posts != 1 {
+		t.Fatalf("live call: result %+v, %d writes", result, posts)
+	}
+}
+
+func TestDryRunToolsAreNotReadOnly(t *testing.T) {
+	responseCache, err := NewResponseCache(cache.NewMemory(10), "seller", nil)
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+
+	var serverTools []server.ServerTool
+	for _, tool := range append(newReportsTools(Dependencies{}), newDataKioskTools(Dependencies{})...) {
+		serverTools = append(serverTools, tool.ServerTool)
+	}
+	serverTools = withResponseCache(serverTools, responseCache)
+
+	sideEffects := map[string]bool{
+		"reports.createReport":      true,
+		"dataKiosk.createQuery":     true,
+		"dataKiosk.cancelQuery":     true,
+		"dataKiosk.runSavedQuery":   true,
+		"dataKiosk.getQuery":        false,
+		"reports.getReportDocument": false,
+	}
+	for _, tool := range serverTools {
+		want, ok := sideEffects[tool.Tool.Name]
+		if !ok {
+			continue
+		}
+		delete(sideEffects, tool.Tool.Name)
+
+		annotations := tool.Tool.Annotations
+		if *annotations.ReadOnlyHint == want || *annotations.IdempotentHint == want {
+			t.Errorf("%s: readOnly %v, idempotent %v", tool.Tool.Name, *annotations.ReadOnlyHint, *annotations.IdempotentHint)
+		}
+		if *annotations.DestructiveHint {
+			t.Errorf("%s should not be destructive", tool.Tool.Name)
+		}
+		_, bypass := tool.Tool.InputSchema.Properties["bypassCache"]
+		_, dryRun := tool.Tool.InputSchema.Properties[dryRunArgument]
+		if bypass == want || dryRun != want {
+			t.Errorf("%s: bypassCache %v, dryRun %v", tool.Tool.Name, bypass, dryRun)
+		}
+	}
+	if len(sideEffects) > 0 {
+		t.Fatalf("tools not registered: %v", sideEffects)
+	}
+}